@@ -0,0 +1,856 @@
+// Copyright 2015 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backends
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethmath "github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/event"
+	bls_core "github.com/harmony-one/bls/ffi/go/bls"
+	"github.com/harmony-one/harmony/accounts/abi"
+	"github.com/harmony-one/harmony/accounts/abi/bind"
+	"github.com/harmony-one/harmony/block"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/crypto/hash"
+	chain2 "github.com/harmony-one/harmony/internal/chain"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/harmony-one/harmony/shard"
+)
+
+// This nil assignment ensures at compile time that SimulatedBackend implements bind.ContractBackend.
+var (
+	_ bind.ContractBackend        = (*SimulatedBackend)(nil)
+	_ bind.DeployBackend          = (*SimulatedBackend)(nil)
+	_ bind.PendingContractCaller  = (*SimulatedBackend)(nil)
+	_ ethereum.PendingStateReader = (*SimulatedBackend)(nil)
+)
+
+var (
+	errBlockNumberUnsupported = errors.New("simulatedBackend cannot access blocks other than the latest block")
+	errBlockDoesNotExist      = errors.New("block does not exist in blockchain")
+)
+
+const (
+	// blockPeriod is the time in seconds between two simulated blocks.
+	blockPeriod = 10
+	// vrfSize is the size of the vrf and its proof in a block header.
+	vrfSize = 32 + 96
+	// callGasLimit is the gas given to calls which don't set one.
+	callGasLimit = 50000000
+)
+
+// SimulatedBackend implements bind.ContractBackend, simulating a beacon chain
+// node in the background. Its main purpose is to allow for easy testing of
+// contract bindings, including the ones using the staking, VRF and epoch
+// precompiles.
+//
+// Blocks are produced by a single harmony node committee, which signs every
+// block, and are processed by the real state processor when committed.
+// Transactions are admitted through the real tx pool.
+type SimulatedBackend struct {
+	database   ethdb.Database       // In memory database to store our testing data
+	blockchain *core.BlockChainImpl // Ethereum blockchain to handle the consensus
+	config     *params.ChainConfig  // Chain config of the simulated chain
+	factory    blockfactory.Factory // Header factory of the simulated chain
+	coinbase   common.Address       // Address of the committee member
+	gasLimit   uint64               // Gas limit of the blocks
+	txPool     *core.TxPool         // Pool holding the pending transactions
+
+	mu              sync.Mutex
+	pendingHeader   *block.Header // Header of the block being built
+	pendingState    *state.DB     // Currently pending state that will be the active on request
+	pendingGasPool  *core.GasPool // Gas left in the block being built
+	pendingTxs      []*types.Transaction
+	pendingReceipts []*types.Receipt
+}
+
+// simulatedChainConfig returns the chain config of the simulated backend,
+// which is the test config with rewards paid every block. The rewards of the
+// aggregated reward epoch are computed over the signatures of the last 64
+// blocks, which include the genesis block that has no signatures.
+func simulatedChainConfig() *params.ChainConfig {
+	config := *params.TestChainConfig
+	config.AggregatedRewardEpoch = params.EpochTBD
+	return &config
+}
+
+// simulatedTxPoolConfig is the config of the tx pool of the simulated
+// backend, which has no journal.
+func simulatedTxPoolConfig() core.TxPoolConfig {
+	config := core.DefaultTxPoolConfig
+	config.Journal = ""
+	return config
+}
+
+// NewSimulatedBackendWithDatabase creates a new binding backend based on the
+// given database and uses a simulated beacon chain for testing purposes.
+// A simulated backend always uses the chain ID of params.TestChainID.
+func NewSimulatedBackendWithDatabase(database ethdb.Database, alloc core.GenesisAlloc, gasLimit uint64) (*SimulatedBackend, error) {
+	config := simulatedChainConfig()
+	factory := blockfactory.NewFactory(config)
+
+	// The committee of a single harmony node, signing all the blocks. The key
+	// is the same for all the backends since the voting power of a committee
+	// is cached by epoch and shard.
+	var blsSecret bls_core.SecretKey
+	if err := blsSecret.SetLittleEndian(hash.Keccak256([]byte("simulated backend"))); err != nil {
+		return nil, err
+	}
+	var blsKey bls.SerializedPublicKey
+	if err := blsKey.FromLibBLSPublicKey(blsSecret.GetPublicKey()); err != nil {
+		return nil, err
+	}
+	coinbase := utils.GetAddressFromBLSPubKeyBytes(blsKey[:])
+	committee := shard.Committee{
+		ShardID: shard.BeaconChainShardID,
+		Slots:   shard.SlotList{{EcdsaAddress: coinbase, BLSPublicKey: blsKey}},
+	}
+
+	genesis := core.Genesis{
+		Config:     config,
+		Factory:    factory,
+		ShardID:    shard.BeaconChainShardID,
+		GasLimit:   gasLimit,
+		Alloc:      alloc,
+		ShardState: shard.State{Epoch: big.NewInt(core.GenesisEpoch), Shards: []shard.Committee{committee}},
+	}
+	genesis.MustCommit(database)
+
+	cacheConfig := &core.CacheConfig{Disabled: true, TrieCleanLimit: 256, TrieDirtyLimit: 256, TrieTimeLimit: 5 * time.Minute}
+	blockchain, err := core.NewBlockChain(
+		database, nil, nil, cacheConfig, config, chain2.NewEngine(), vm.Config{},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	backend := &SimulatedBackend{
+		database:   database,
+		blockchain: blockchain,
+		config:     config,
+		factory:    factory,
+		coinbase:   coinbase,
+		gasLimit:   gasLimit,
+	}
+	parent := blockchain.CurrentBlock().Header()
+	if err := backend.rollback(parent, new(big.Int).Add(parent.Time(), big.NewInt(blockPeriod))); err != nil {
+		blockchain.Stop()
+		return nil, err
+	}
+	return backend, nil
+}
+
+// NewSimulatedBackend creates a new binding backend using a simulated
+// beacon chain for testing purposes.
+// A simulated backend always uses the chain ID of params.TestChainID.
+func NewSimulatedBackend(alloc core.GenesisAlloc, gasLimit uint64) *SimulatedBackend {
+	backend, err := NewSimulatedBackendWithDatabase(rawdb.NewMemoryDatabase(), alloc, gasLimit)
+	if err != nil {
+		panic(err)
+	}
+	return backend
+}
+
+// Close terminates the underlying blockchain's update loop.
+func (b *SimulatedBackend) Close() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.txPool.Stop()
+	b.blockchain.Stop()
+	return nil
+}
+
+// Commit imports all the pending transactions as a single block and starts a
+// fresh new state.
+func (b *SimulatedBackend) Commit() common.Hash {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// The block is signed by the committee as soon as it is finalized
+	sigsReady := make(chan bool, 1)
+	sigsReady <- true
+	blk, _, err := b.blockchain.Engine().Finalize(
+		b.blockchain, b.blockchain, b.pendingHeader, b.pendingState,
+		b.pendingTxs, b.pendingReceipts, nil, nil, nil, nil, sigsReady,
+		func() uint64 { return b.pendingHeader.Number().Uint64() },
+	)
+	if err != nil {
+		panic(fmt.Errorf("could not finalize block: %v", err))
+	}
+	if _, err := b.blockchain.InsertChain(types.Blocks{blk}, false); err != nil {
+		panic(fmt.Errorf("could not commit block: %v", err))
+	}
+	// Using the block header as the parent, since the header of the current
+	// block may not be cached yet
+	parent := blk.Header()
+	if err := b.rollback(parent, new(big.Int).Add(parent.Time(), big.NewInt(blockPeriod))); err != nil {
+		panic(err)
+	}
+	return blk.Hash()
+}
+
+// Rollback aborts all pending transactions, reverting to the last committed state.
+func (b *SimulatedBackend) Rollback() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	parent := b.blockchain.CurrentBlock().Header()
+	if err := b.rollback(parent, new(big.Int).Add(parent.Time(), big.NewInt(blockPeriod))); err != nil {
+		panic(err)
+	}
+}
+
+// rollback starts an empty pending block on top of parent with the given
+// time, and a tx pool without transactions.
+func (b *SimulatedBackend) rollback(parent *block.Header, blockTime *big.Int) error {
+	statedb, err := b.blockchain.StateAt(parent.Root())
+	if err != nil {
+		return err
+	}
+	number := new(big.Int).Add(parent.Number(), common.Big1)
+	// The vrf is not verified, any deterministic randomness will do
+	vrf := make([]byte, vrfSize)
+	copy(vrf, hash.Keccak256(parent.Hash().Bytes(), number.Bytes()))
+
+	header := b.factory.NewHeader(parent.Epoch()).With().
+		ParentHash(parent.Hash()).
+		Coinbase(b.coinbase).
+		Number(number).
+		GasLimit(core.CalcGasLimit(parent, b.gasLimit, b.gasLimit)).
+		Time(blockTime).
+		ShardID(shard.BeaconChainShardID).
+		LastCommitBitmap([]byte{0x01}).
+		Vrf(vrf).
+		Header()
+	if b.config.IsLondon(header.Epoch()) {
+		header.SetBaseFee(core.CalcBaseFee(b.config, parent))
+	}
+
+	b.pendingHeader = header
+	b.pendingState = statedb
+	b.pendingGasPool = new(core.GasPool).AddGas(header.GasLimit())
+	b.pendingTxs = nil
+	b.pendingReceipts = nil
+	b.resetTxPool()
+	return nil
+}
+
+// resetTxPool replaces the tx pool by an empty one on top of the current
+// block.
+func (b *SimulatedBackend) resetTxPool() {
+	if b.txPool != nil {
+		b.txPool.Stop()
+	}
+	b.txPool = core.NewTxPool(simulatedTxPoolConfig(), b.config, b.blockchain, types.NewTransactionErrorSink())
+}
+
+// stateByBlockNumber retrieves a state by a given blocknumber.
+func (b *SimulatedBackend) stateByBlockNumber(ctx context.Context, blockNumber *big.Int) (*state.DB, error) {
+	if blockNumber == nil || blockNumber.Cmp(b.blockchain.CurrentBlock().Number()) == 0 {
+		return b.blockchain.State()
+	}
+	header, err := b.headerByNumberNoLock(blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return b.blockchain.StateAt(header.Root())
+}
+
+// CodeAt returns the code associated with a certain account in the blockchain.
+func (b *SimulatedBackend) CodeAt(ctx context.Context, contract common.Address, blockNumber *big.Int) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stateDB, err := b.stateByBlockNumber(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return stateDB.GetCode(contract), nil
+}
+
+// BalanceAt returns the wei balance of a certain account in the blockchain.
+func (b *SimulatedBackend) BalanceAt(ctx context.Context, contract common.Address, blockNumber *big.Int) (*big.Int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stateDB, err := b.stateByBlockNumber(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return stateDB.GetBalance(contract), nil
+}
+
+// NonceAt returns the nonce of a certain account in the blockchain.
+func (b *SimulatedBackend) NonceAt(ctx context.Context, contract common.Address, blockNumber *big.Int) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stateDB, err := b.stateByBlockNumber(ctx, blockNumber)
+	if err != nil {
+		return 0, err
+	}
+	return stateDB.GetNonce(contract), nil
+}
+
+// StorageAt returns the value of key in the storage of an account in the blockchain.
+func (b *SimulatedBackend) StorageAt(ctx context.Context, contract common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	stateDB, err := b.stateByBlockNumber(ctx, blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	val := stateDB.GetState(contract, key)
+	return val[:], nil
+}
+
+// TransactionReceipt returns the receipt of a transaction, ethereum.NotFound
+// if the transaction is not committed yet.
+func (b *SimulatedBackend) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	receipt, _, _, _ := rawdb.ReadReceipt(b.database, txHash, nil)
+	if receipt == nil {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+// TransactionByHash checks the pool of pending transactions in addition to the
+// blockchain. The isPending return value indicates whether the transaction has been
+// mined yet. Note that the transaction may not be part of the canonical chain even if
+// it's not pending.
+func (b *SimulatedBackend) TransactionByHash(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, tx := range b.pendingTxs {
+		if tx.Hash() == txHash {
+			return tx, true, nil
+		}
+	}
+	tx, _, _, _ := rawdb.ReadTransaction(b.database, txHash)
+	if tx != nil {
+		return tx, false, nil
+	}
+	return nil, false, ethereum.NotFound
+}
+
+// BlockByHash retrieves a block based on the block hash.
+func (b *SimulatedBackend) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	blk := b.blockchain.GetBlockByHash(hash)
+	if blk == nil {
+		return nil, errBlockDoesNotExist
+	}
+	return blk, nil
+}
+
+// BlockByNumber retrieves a block from the database by number, caching it
+// (associated with its hash) if found. The latest block is returned if
+// number is nil.
+func (b *SimulatedBackend) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if number == nil {
+		return b.blockchain.CurrentBlock(), nil
+	}
+	blk := b.blockchain.GetBlockByNumber(number.Uint64())
+	if blk == nil {
+		return nil, errBlockDoesNotExist
+	}
+	return blk, nil
+}
+
+// HeaderByHash returns a block header from the current canonical chain.
+func (b *SimulatedBackend) HeaderByHash(ctx context.Context, hash common.Hash) (*block.Header, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	header := b.blockchain.GetHeaderByHash(hash)
+	if header == nil {
+		return nil, errBlockDoesNotExist
+	}
+	return header, nil
+}
+
+// HeaderByNumber returns a block header from the current canonical chain. If
+// number is nil, the latest known header is returned.
+func (b *SimulatedBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*block.Header, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.headerByNumberNoLock(number)
+}
+
+// headerByNumberNoLock retrieves a header from the database by number without
+// acquiring the mutex.
+func (b *SimulatedBackend) headerByNumberNoLock(number *big.Int) (*block.Header, error) {
+	if number == nil {
+		return b.blockchain.CurrentHeader(), nil
+	}
+	header := b.blockchain.GetHeaderByNumber(number.Uint64())
+	if header == nil {
+		return nil, errBlockDoesNotExist
+	}
+	return header, nil
+}
+
+// PendingCodeAt returns the code associated with an account in the pending state.
+func (b *SimulatedBackend) PendingCodeAt(ctx context.Context, contract common.Address) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.pendingState.GetCode(contract), nil
+}
+
+// PendingBalanceAt returns the balance of an account in the pending state.
+func (b *SimulatedBackend) PendingBalanceAt(ctx context.Context, account common.Address) (*big.Int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.pendingState.GetBalance(account), nil
+}
+
+// PendingStorageAt returns the value of key in the storage of an account in
+// the pending state.
+func (b *SimulatedBackend) PendingStorageAt(ctx context.Context, account common.Address, key common.Hash) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	val := b.pendingState.GetState(account, key)
+	return val[:], nil
+}
+
+// PendingNonceAt implements PendingStateReader.PendingNonceAt, retrieving
+// the nonce currently pending for the account.
+func (b *SimulatedBackend) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.pendingState.GetNonce(account), nil
+}
+
+// PendingTransactionCount returns the number of transactions in the pending block.
+func (b *SimulatedBackend) PendingTransactionCount(ctx context.Context) (uint, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return uint(len(b.pendingTxs)), nil
+}
+
+// CallContract executes a contract call.
+func (b *SimulatedBackend) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if blockNumber != nil && blockNumber.Cmp(b.blockchain.CurrentBlock().Number()) != 0 {
+		return nil, errBlockNumberUnsupported
+	}
+	stateDB, err := b.blockchain.State()
+	if err != nil {
+		return nil, err
+	}
+	res, err := b.callContract(ctx, call, b.blockchain.CurrentHeader(), stateDB)
+	if err != nil {
+		return nil, err
+	}
+	// If the result contains a revert reason, try to unpack and return it.
+	if len(res.Revert()) > 0 {
+		return nil, newRevertError(res)
+	}
+	return res.Return(), res.VMErr
+}
+
+// PendingCallContract executes a contract call on the pending state.
+func (b *SimulatedBackend) PendingCallContract(ctx context.Context, call ethereum.CallMsg) ([]byte, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	defer b.pendingState.RevertToSnapshot(b.pendingState.Snapshot())
+
+	res, err := b.callContract(ctx, call, b.pendingHeader, b.pendingState)
+	if err != nil {
+		return nil, err
+	}
+	// If the result contains a revert reason, try to unpack and return it.
+	if len(res.Revert()) > 0 {
+		return nil, newRevertError(res)
+	}
+	return res.Return(), res.VMErr
+}
+
+// SuggestGasPrice implements ContractTransactor.SuggestGasPrice, returning the
+// minimum gas price of the tx pool, or the base fee of the pending block if
+// it is higher.
+func (b *SimulatedBackend) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	price := b.txPool.GasPrice()
+	if baseFee := b.pendingHeader.BaseFee(); baseFee != nil && baseFee.Cmp(price) > 0 {
+		return new(big.Int).Set(baseFee), nil
+	}
+	return price, nil
+}
+
+// EstimateGas executes the requested code against the currently pending block/state and
+// returns the used amount of gas.
+func (b *SimulatedBackend) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	// Determine the lowest and highest possible gas limits to binary search in between
+	var (
+		lo  uint64 = params.TxGas - 1
+		hi  uint64
+		cap uint64
+	)
+	if call.Gas >= params.TxGas {
+		hi = call.Gas
+	} else {
+		hi = b.pendingHeader.GasLimit()
+	}
+	// Recap the highest gas allowance with account's balance.
+	if call.GasPrice != nil && call.GasPrice.BitLen() != 0 {
+		balance := b.pendingState.GetBalance(call.From) // from can't be nil
+		available := new(big.Int).Set(balance)
+		if call.Value != nil {
+			if call.Value.Cmp(available) >= 0 {
+				return 0, core.ErrInsufficientFunds
+			}
+			available.Sub(available, call.Value)
+		}
+		allowance := new(big.Int).Div(available, call.GasPrice)
+		if allowance.IsUint64() && hi > allowance.Uint64() {
+			hi = allowance.Uint64()
+		}
+	}
+	cap = hi
+
+	// Create a helper to check if a gas allowance results in an executable transaction
+	executable := func(gas uint64) (bool, *core.ExecutionResult, error) {
+		call.Gas = gas
+
+		snapshot := b.pendingState.Snapshot()
+		res, err := b.callContract(ctx, call, b.pendingHeader, b.pendingState)
+		b.pendingState.RevertToSnapshot(snapshot)
+
+		if err != nil {
+			if errors.Is(err, core.ErrIntrinsicGas) {
+				return true, nil, nil // Special case, raise gas limit
+			}
+			return true, nil, err // Bail out
+		}
+		return res.Failed(), res, nil
+	}
+	// Execute the binary search and hone in on an executable gas limit
+	for lo+1 < hi {
+		mid := (hi + lo) / 2
+		failed, _, err := executable(mid)
+
+		// If the error is not nil(consensus error), it means the provided message
+		// call or transaction will never be accepted no matter how much gas it is
+		// assigned. Return the error directly, don't struggle any more
+		if err != nil {
+			return 0, err
+		}
+		if failed {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+	// Reject the transaction as invalid if it still fails at the highest allowance
+	if hi == cap {
+		failed, result, err := executable(hi)
+		if err != nil {
+			return 0, err
+		}
+		if failed {
+			if result != nil && result.VMErr != vm.ErrOutOfGas {
+				if len(result.Revert()) > 0 {
+					return 0, newRevertError(result)
+				}
+				return 0, result.VMErr
+			}
+			// Otherwise, the specified gas cap is too low
+			return 0, fmt.Errorf("gas required exceeds allowance (%d)", cap)
+		}
+	}
+	return hi, nil
+}
+
+// callContract implements common code between normal and pending contract calls.
+// state is modified during execution, make sure to copy it if necessary.
+func (b *SimulatedBackend) callContract(
+	ctx context.Context, call ethereum.CallMsg, header *block.Header, stateDB *state.DB,
+) (*core.ExecutionResult, error) {
+	// Ensure message is initialized properly.
+	if call.Gas == 0 {
+		call.Gas = callGasLimit
+	}
+	if call.GasPrice == nil {
+		call.GasPrice = new(big.Int)
+	}
+	if call.Value == nil {
+		call.Value = new(big.Int)
+	}
+	// Set infinite balance to the fake caller account.
+	stateDB.SetBalance(call.From, ethmath.MaxBig256)
+
+	msg := types.NewMessage(
+		call.From, call.To, stateDB.GetNonce(call.From), call.Value, call.Gas, call.GasPrice, call.Data, false,
+	)
+	// Create a new environment which holds all relevant information
+	// about the transaction and calling mechanisms. Calls are allowed
+	// to leave the fees unset.
+	evmContext := core.NewEVMContext(msg, header, b.blockchain, nil)
+	vmEnv := vm.NewEVM(evmContext, stateDB, b.config, vm.Config{NoBaseFee: true})
+	gasPool := new(core.GasPool).AddGas(math.MaxUint64)
+
+	res, err := core.ApplyMessage(vmEnv, msg, gasPool)
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+// SendTransaction updates the pending block to include the given transaction,
+// once admitted by the tx pool.
+func (b *SimulatedBackend) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.txPool.AddLocal(tx); err != nil {
+		return err
+	}
+
+	b.pendingState.Prepare(tx.Hash(), common.Hash{}, len(b.pendingTxs))
+	snapshot := b.pendingState.Snapshot()
+	gasPool := *b.pendingGasPool
+	gasUsed := b.pendingHeader.GasUsed()
+	coinbase := b.coinbase
+	receipt, _, _, _, err := core.ApplyTransaction(
+		b.blockchain, &coinbase, &gasPool, b.pendingState, b.pendingHeader, tx, &gasUsed, vm.Config{},
+	)
+	if err != nil {
+		// Drop the transaction from the pool, which holds the transactions of
+		// the pending block only
+		b.pendingState.RevertToSnapshot(snapshot)
+		b.resetTxPool()
+		for _, pending := range b.pendingTxs {
+			if err := b.txPool.AddLocal(pending); err != nil {
+				panic(fmt.Errorf("could not re-add pending transaction: %v", err))
+			}
+		}
+		return fmt.Errorf("could not apply transaction %s: %w", tx.Hash().Hex(), err)
+	}
+	*b.pendingGasPool = gasPool
+	b.pendingHeader.SetGasUsed(gasUsed)
+	b.pendingTxs = append(b.pendingTxs, tx)
+	b.pendingReceipts = append(b.pendingReceipts, receipt)
+	return nil
+}
+
+// FilterLogs executes a log filter operation, blocking during execution and
+// returning all the results in one batch.
+func (b *SimulatedBackend) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var headers []*block.Header
+	if query.BlockHash != nil {
+		header := b.blockchain.GetHeaderByHash(*query.BlockHash)
+		if header == nil {
+			return nil, errBlockDoesNotExist
+		}
+		headers = append(headers, header)
+	} else {
+		head := b.blockchain.CurrentBlock().NumberU64()
+		from, to := uint64(0), head
+		if query.FromBlock != nil && query.FromBlock.Sign() >= 0 {
+			from = query.FromBlock.Uint64()
+		}
+		if query.ToBlock != nil && query.ToBlock.Sign() >= 0 && query.ToBlock.Uint64() < head {
+			to = query.ToBlock.Uint64()
+		}
+		for number := from; number <= to; number++ {
+			header := b.blockchain.GetHeaderByNumber(number)
+			if header == nil {
+				return nil, errBlockDoesNotExist
+			}
+			headers = append(headers, header)
+		}
+	}
+
+	res := []types.Log{}
+	for _, header := range headers {
+		for _, receipt := range b.blockchain.GetReceiptsByHash(header.Hash()) {
+			for _, log := range receipt.Logs {
+				if matchLog(log, query) {
+					res = append(res, *log)
+				}
+			}
+		}
+	}
+	return res, nil
+}
+
+// SubscribeFilterLogs creates a background log filtering operation, returning a
+// subscription immediately, which can be used to stream the found events.
+func (b *SimulatedBackend) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	sink := make(chan []*types.Log)
+	sub := b.blockchain.SubscribeLogsEvent(sink)
+
+	// Since we're getting logs in batches, we need to flatten them into a plain stream
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case logs := <-sink:
+				for _, log := range logs {
+					if !matchLog(log, query) {
+						continue
+					}
+					select {
+					case ch <- *log:
+					case err := <-sub.Err():
+						return err
+					case <-quit:
+						return nil
+					}
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// matchLog returns whether the log matches the addresses and topics of the
+// query. The block range of the query is not checked.
+func matchLog(log *types.Log, query ethereum.FilterQuery) bool {
+	if len(query.Addresses) > 0 {
+		found := false
+		for _, addr := range query.Addresses {
+			if addr == log.Address {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(query.Topics) > len(log.Topics) {
+		return false
+	}
+	for i, sub := range query.Topics {
+		// empty rule set == wildcard
+		if len(sub) == 0 {
+			continue
+		}
+		found := false
+		for _, topic := range sub {
+			if log.Topics[i] == topic {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// AdjustTime adds a time shift to the simulated clock.
+// It can only be called on empty blocks.
+func (b *SimulatedBackend) AdjustTime(adjustment time.Duration) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if len(b.pendingTxs) != 0 {
+		return errors.New("could not adjust time on non-empty block")
+	}
+	parent := b.blockchain.CurrentBlock().Header()
+	blockTime := new(big.Int).Add(b.pendingHeader.Time(), big.NewInt(int64(adjustment.Seconds())))
+	return b.rollback(parent, blockTime)
+}
+
+// Blockchain returns the underlying blockchain.
+func (b *SimulatedBackend) Blockchain() core.BlockChain {
+	return b.blockchain
+}
+
+// newRevertError creates the error of a reverted call, with the revert reason
+// if it can be unpacked.
+func newRevertError(result *core.ExecutionResult) *revertError {
+	reason, errUnpack := abi.UnpackRevert(result.Revert())
+	err := errors.New("execution reverted")
+	if errUnpack == nil {
+		err = fmt.Errorf("execution reverted: %v", reason)
+	}
+	return &revertError{
+		error:  err,
+		reason: hexutil.Encode(result.Revert()),
+	}
+}
+
+// revertError is an API error that encompasses an EVM revert with JSON error
+// code and a binary data blob.
+type revertError struct {
+	error
+	reason string // revert reason hex encoded
+}
+
+// ErrorCode returns the JSON error code for a revert.
+// See: https://github.com/ethereum/wiki/wiki/JSON-RPC-Error-Codes-Improvement-Proposal
+func (e *revertError) ErrorCode() int {
+	return 3
+}
+
+// ErrorData returns the hex encoded revert reason.
+func (e *revertError) ErrorData() interface{} {
+	return e.reason
+}
@@ -0,0 +1,287 @@
+// Copyright 2019 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package backends
+
+import (
+	"bytes"
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/accounts/abi"
+	"github.com/harmony-one/harmony/accounts/abi/bind"
+	"github.com/harmony-one/harmony/common/denominations"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+var (
+	testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr   = crypto.PubkeyToAddress(testKey.PublicKey)
+	echoTopic  = crypto.Keccak256Hash([]byte("Echo(bytes)"))
+)
+
+const testGasLimit = 10000000
+
+func newTestBackend(t *testing.T) *SimulatedBackend {
+	t.Helper()
+	balance := new(big.Int).Mul(big.NewInt(denominations.One), big.NewInt(1000))
+	sim := NewSimulatedBackend(core.GenesisAlloc{testAddr: {Balance: balance}}, testGasLimit)
+	t.Cleanup(func() { sim.Close() })
+	return sim
+}
+
+func newTestTransactor(t *testing.T) *bind.TransactOpts {
+	t.Helper()
+	opts, err := bind.NewKeyedTransactorWithChainID(testKey, params.TestChainID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return opts
+}
+
+// echoCode returns the code of a contract which logs its call data with
+// echoTopic and returns it.
+func echoCode() []byte {
+	runtime := []byte{
+		0x36, 0x60, 0x00, 0x60, 0x00, 0x37, // CALLDATACOPY(0, 0, CALLDATASIZE)
+		0x7f, // PUSH32 echoTopic
+	}
+	runtime = append(runtime, echoTopic[:]...)
+	runtime = append(runtime,
+		0x36, 0x60, 0x00, 0xa1, // LOG1(0, CALLDATASIZE, echoTopic)
+		0x36, 0x60, 0x00, 0xf3, // RETURN(0, CALLDATASIZE)
+	)
+	initCode := []byte{
+		0x60, byte(len(runtime)), 0x80, 0x60, 0x0b, 0x60, 0x00, 0x39, // CODECOPY(0, 11, len(runtime))
+		0x60, 0x00, 0xf3, // RETURN(0, len(runtime))
+	}
+	return append(initCode, runtime...)
+}
+
+func deployEcho(t *testing.T, sim *SimulatedBackend) (common.Address, *bind.BoundContract) {
+	t.Helper()
+	parsed, err := abi.JSON(strings.NewReader("[]"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr, tx, contract, err := bind.DeployContract(newTestTransactor(t), parsed, echoCode(), sim)
+	if err != nil {
+		t.Fatalf("could not deploy contract: %v", err)
+	}
+	sim.Commit()
+	if _, err := bind.WaitDeployed(context.Background(), sim, tx); err != nil {
+		t.Fatalf("contract not deployed: %v", err)
+	}
+	return addr, contract
+}
+
+func TestSimulatedBackendContract(t *testing.T) {
+	sim := newTestBackend(t)
+	ctx := context.Background()
+	addr, contract := deployEcho(t, sim)
+
+	input := []byte("hello")
+	out, err := sim.CallContract(ctx, ethereum.CallMsg{From: testAddr, To: &addr, Data: input}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, input) {
+		t.Errorf("have output %x, want %x", out, input)
+	}
+
+	ch := make(chan types.Log, 1)
+	sub, err := sim.SubscribeFilterLogs(ctx, ethereum.FilterQuery{Addresses: []common.Address{addr}}, ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+
+	tx, err := contract.RawTransact(newTestTransactor(t), input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sim.TransactionReceipt(ctx, tx.Hash()); err != ethereum.NotFound {
+		t.Errorf("have error %v for pending receipt, want %v", err, ethereum.NotFound)
+	}
+	blockHash := sim.Commit()
+
+	receipt, err := sim.TransactionReceipt(ctx, tx.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful || len(receipt.Logs) != 1 {
+		t.Fatalf("unexpected receipt %+v", receipt)
+	}
+
+	logs, err := sim.FilterLogs(ctx, ethereum.FilterQuery{
+		Addresses: []common.Address{addr},
+		Topics:    [][]common.Hash{{echoTopic}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 || logs[0].TxHash != tx.Hash() || logs[0].BlockHash != blockHash ||
+		logs[0].BlockNumber != 2 || !bytes.Equal(logs[0].Data, input) {
+		t.Errorf("unexpected logs %+v", logs)
+	}
+	logs, err = sim.FilterLogs(ctx, ethereum.FilterQuery{Topics: [][]common.Hash{{common.Hash{}}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 0 {
+		t.Errorf("have %d logs for unknown topic, want none", len(logs))
+	}
+
+	select {
+	case log := <-ch:
+		if log.TxHash != tx.Hash() || !bytes.Equal(log.Data, input) {
+			t.Errorf("unexpected subscribed log %+v", log)
+		}
+	case err := <-sub.Err():
+		t.Fatal(err)
+	case <-time.After(time.Second):
+		t.Fatal("log not delivered to subscription")
+	}
+}
+
+func TestSimulatedBackendRollback(t *testing.T) {
+	sim := newTestBackend(t)
+	ctx := context.Background()
+
+	gasPrice, err := sim.SuggestGasPrice(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	to := common.HexToAddress("0x1234")
+	tx, err := types.SignTx(
+		types.NewTransaction(0, to, 0, big.NewInt(1), params.TxGas, gasPrice, nil),
+		types.NewEIP155Signer(params.TestChainID), testKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	if nonce, _ := sim.PendingNonceAt(ctx, testAddr); nonce != 1 {
+		t.Errorf("have pending nonce %d, want 1", nonce)
+	}
+	if err := sim.SendTransaction(ctx, tx); err == nil {
+		t.Error("expected error for duplicate transaction")
+	}
+	if err := sim.AdjustTime(time.Hour); err == nil {
+		t.Error("expected error adjusting time of non-empty block")
+	}
+
+	sim.Rollback()
+	if nonce, _ := sim.PendingNonceAt(ctx, testAddr); nonce != 0 {
+		t.Errorf("have pending nonce %d after rollback, want 0", nonce)
+	}
+	if _, _, err := sim.TransactionByHash(ctx, tx.Hash()); err != ethereum.NotFound {
+		t.Errorf("have error %v after rollback, want %v", err, ethereum.NotFound)
+	}
+	// The transaction can be sent again once rolled back
+	if err := sim.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+	if balance, _ := sim.BalanceAt(ctx, to, nil); balance.Cmp(big.NewInt(1)) != 0 {
+		t.Errorf("have balance %v, want 1", balance)
+	}
+}
+
+func TestSimulatedBackendAdjustTime(t *testing.T) {
+	sim := newTestBackend(t)
+	ctx := context.Background()
+
+	prev, err := sim.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.AdjustTime(time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+	head, err := sim.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := new(big.Int).Sub(head.Time(), prev.Time()); diff.Int64() != blockPeriod+3600 {
+		t.Errorf("have time difference %v, want %d", diff, blockPeriod+3600)
+	}
+}
+
+func TestSimulatedBackendPrecompiles(t *testing.T) {
+	sim := newTestBackend(t)
+	ctx := context.Background()
+	sim.Commit()
+
+	head, err := sim.HeaderByNumber(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	epochAddr := common.BytesToAddress([]byte{251})
+	out, err := sim.CallContract(ctx, ethereum.CallMsg{From: testAddr, To: &epochAddr}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, common.LeftPadBytes(head.Epoch().Bytes(), 32)) {
+		t.Errorf("have epoch %x, want %v", out, head.Epoch())
+	}
+
+	vrfAddr := common.BytesToAddress([]byte{255})
+	out, err = sim.CallContract(ctx, ethereum.CallMsg{
+		From: testAddr, To: &vrfAddr, Data: common.LeftPadBytes(head.Number().Bytes(), 32),
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(out, head.Vrf()[:32]) || bytes.Equal(out, make([]byte, 32)) {
+		t.Errorf("have vrf %x, want %x", out, head.Vrf()[:32])
+	}
+
+	// Delegating to a validator which does not exist fails in the staking
+	// precompile, while the same call to an account succeeds
+	stakingABI, err := abi.JSON(strings.NewReader(`[{"inputs":[
+		{"name":"delegatorAddress","type":"address"},
+		{"name":"validatorAddress","type":"address"},
+		{"name":"amount","type":"uint256"}],
+		"name":"Delegate","outputs":[],"stateMutability":"nonpayable","type":"function"}]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	amount := new(big.Int).Mul(big.NewInt(denominations.One), big.NewInt(100))
+	input, err := stakingABI.Pack("Delegate", testAddr, common.HexToAddress("0x1234"), amount)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stakingAddr := common.BytesToAddress([]byte{252})
+	if _, err := sim.EstimateGas(ctx, ethereum.CallMsg{From: testAddr, To: &stakingAddr, Data: input}); err == nil {
+		t.Error("expected error delegating to unknown validator")
+	}
+	otherAddr := common.HexToAddress("0x5678")
+	if _, err := sim.EstimateGas(ctx, ethereum.CallMsg{From: testAddr, To: &otherAddr, Data: input}); err != nil {
+		t.Errorf("unexpected error calling account: %v", err)
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright 2016 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package bind
+
+import (
+	"go/parser"
+	"go/token"
+	"strconv"
+	"testing"
+)
+
+const tokenABI = `[
+	{"type":"function","name":"balanceOf","constant":true,"inputs":[{"name":"owner","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"transfer","inputs":[{"name":"to","type":"address"},{"name":"value","type":"uint256"}],"outputs":[]},
+	{"type":"event","name":"Transfer","inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"value","type":"uint256","indexed":false}]}
+]`
+
+func TestBindGoImports(t *testing.T) {
+	code, err := Bind([]string{"Token"}, []string{tokenABI}, []string{"0x6060"}, nil, "token", LangGo, nil, nil)
+	if err != nil {
+		t.Fatalf("failed to generate binding: %v", err)
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), "token.go", code, parser.ImportsOnly)
+	if err != nil {
+		t.Fatalf("failed to parse binding: %v", err)
+	}
+	imports := make(map[string]bool)
+	for _, spec := range file.Imports {
+		path, _ := strconv.Unquote(spec.Path.Value)
+		imports[path] = true
+	}
+	for _, want := range []string{
+		"github.com/harmony-one/harmony/accounts/abi",
+		"github.com/harmony-one/harmony/accounts/abi/bind",
+		"github.com/harmony-one/harmony/core/types",
+	} {
+		if !imports[want] {
+			t.Errorf("binding does not import %s", want)
+		}
+	}
+	for _, unwanted := range []string{
+		"github.com/ethereum/go-ethereum/accounts/abi",
+		"github.com/ethereum/go-ethereum/accounts/abi/bind",
+		"github.com/ethereum/go-ethereum/core/types",
+	} {
+		if imports[unwanted] {
+			t.Errorf("binding imports %s", unwanted)
+		}
+	}
+}
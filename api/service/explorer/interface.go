@@ -6,6 +6,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/leveldb"
+	"github.com/harmony-one/harmony/core/types"
 	tikvCommon "github.com/harmony-one/harmony/internal/tikv/common"
 	"github.com/harmony-one/harmony/internal/tikv/prefix"
 	"github.com/harmony-one/harmony/internal/tikv/remote"
@@ -26,6 +27,7 @@ type databaseReader interface {
 	Get(key []byte) ([]byte, error)
 	Has(key []byte) (bool, error)
 	NewPrefixIterator(prefix []byte) iterator
+	NewReversePrefixIterator(prefix []byte) iterator
 	NewSizedIterator(start []byte, size int) iterator
 }
 
@@ -84,6 +86,74 @@ func (db *explorerDB) NewPrefixIterator(prefix []byte) iterator {
 	return it
 }
 
+// NewReversePrefixIterator returns an iterator over the entries with the
+// prefix in reverse key order. The entries of the stores without backward
+// iteration are read first, then iterated in reverse.
+func (db *explorerDB) NewReversePrefixIterator(prefix []byte) iterator {
+	it := db.db.NewIterator(prefix, nil)
+	if bit, ok := it.(backwardIterator); ok {
+		return &reverseIterator{it: bit}
+	}
+	defer it.Release()
+
+	rit := &bufferedReverseIterator{}
+	for it.Next() {
+		rit.keys = append(rit.keys, common.CopyBytes(it.Key()))
+		rit.values = append(rit.values, common.CopyBytes(it.Value()))
+	}
+	rit.err = it.Error()
+	rit.index = len(rit.keys)
+	return rit
+}
+
+// backwardIterator is an iterator able to move backward, such as the leveldb
+// iterators.
+type backwardIterator interface {
+	iterator
+	Last() bool
+	Prev() bool
+}
+
+// reverseIterator iterates a backwardIterator from its last entry.
+type reverseIterator struct {
+	it      backwardIterator
+	started bool
+}
+
+func (it *reverseIterator) Next() bool {
+	if !it.started {
+		it.started = true
+		return it.it.Last()
+	}
+	return it.it.Prev()
+}
+
+func (it *reverseIterator) Key() []byte   { return it.it.Key() }
+func (it *reverseIterator) Value() []byte { return it.it.Value() }
+func (it *reverseIterator) Release()      { it.it.Release() }
+func (it *reverseIterator) Error() error  { return it.it.Error() }
+
+// bufferedReverseIterator iterates the read entries of an iterator in
+// reverse.
+type bufferedReverseIterator struct {
+	keys, values [][]byte
+	index        int
+	err          error
+}
+
+func (it *bufferedReverseIterator) Next() bool {
+	if it.index <= 0 {
+		return false
+	}
+	it.index--
+	return true
+}
+
+func (it *bufferedReverseIterator) Key() []byte   { return it.keys[it.index] }
+func (it *bufferedReverseIterator) Value() []byte { return it.values[it.index] }
+func (it *bufferedReverseIterator) Release()      { it.keys, it.values = nil, nil }
+func (it *bufferedReverseIterator) Error() error  { return it.err }
+
 func (db *explorerDB) NewSizedIterator(start []byte, size int) iterator {
 	return db.newSizedIterator(start, size)
 }
@@ -124,8 +194,17 @@ type iterator interface {
 	Error() error
 }
 
-// blockChainTxIndexer is the interface to check the loop up entry for transaction.
+// blockChainTxIndexer is the interface to check the loop up entry for transaction,
+// and to read the receipts of a block.
 // Implemented by core.BlockChain
 type blockChainTxIndexer interface {
 	ReadTxLookupEntry(txID common.Hash) (common.Hash, uint64, uint64)
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+}
+
+// blockChainBlockReader is the interface to read the blocks for the migrations.
+// Implemented by core.BlockChain
+type blockChainBlockReader interface {
+	blockChainTxIndexer
+	GetBlockByNumber(number uint64) *types.Block
 }
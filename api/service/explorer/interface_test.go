@@ -12,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
 	common2 "github.com/harmony-one/harmony/internal/common"
 
 	"github.com/syndtr/goleveldb/leveldb"
@@ -71,6 +72,44 @@ func TestLevelDBPrefixIterator(t *testing.T) {
 	}
 }
 
+func TestLevelDBReversePrefixIterator(t *testing.T) {
+	db := newTestLevelDB(t, 0)
+	if err := prepareTestLvlDB(db); err != nil {
+		t.Fatal(err)
+	}
+	memDB := newMemDB()
+	if err := prepareTestLvlDB(memDB); err != nil {
+		t.Fatal(err)
+	}
+	// the leveldb iterator moves backward, the other stores are buffered
+	lvlDB := db.(*explorerDB)
+	bufDB := &explorerDB{db: memorydb.New()}
+	if err := prepareTestLvlDB(bufDB); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		prefix  string
+		expKeys []string
+	}{
+		{"00000", []string{"000005", "000004", "000003", "000002", "000001"}},
+		{"1", []string{"100002", "100001"}},
+		{"2", nil},
+	}
+	for i, test := range tests {
+		for _, db := range []databaseReader{lvlDB, bufDB, memDB} {
+			it := db.NewReversePrefixIterator([]byte(test.prefix))
+			var keys []string
+			for it.Next() {
+				keys = append(keys, string(it.Key()))
+			}
+			it.Release()
+			if strings.Join(keys, ",") != strings.Join(test.expKeys, ",") {
+				t.Errorf("Test %v: unexpected keys %v / %v", i, keys, test.expKeys)
+			}
+		}
+	}
+}
+
 func newTestLevelDB(t *testing.T, i int) database {
 	dbDir := tempTestDir(t, i)
 	db, err := newExplorerLvlDB(dbDir)
@@ -170,6 +209,15 @@ func (db *memDB) NewPrefixIterator(prefix []byte) iterator {
 	}
 }
 
+func (db *memDB) NewReversePrefixIterator(prefix []byte) iterator {
+	it := db.NewPrefixIterator(prefix).(*memPrefixIterator)
+	for i, j := 0, len(it.keys)-1; i < j; i, j = i+1, j-1 {
+		it.keys[i], it.keys[j] = it.keys[j], it.keys[i]
+		it.values[i], it.values[j] = it.values[j], it.values[i]
+	}
+	return it
+}
+
 // TODO: implement this and verify
 func (db *memDB) NewSizedIterator(start []byte, size int) iterator {
 	return nil
@@ -5,6 +5,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/abool"
@@ -309,3 +310,114 @@ func (m *migrationV100) checkMigratedTx(legTx *LegTxRecord) error {
 	}
 	return nil
 }
+
+func (s *storage) migrateToV110() error {
+	m := &migrationV110{
+		db:     s.db,
+		bc:     s.bc,
+		blocks: s.rb.Clone(),
+		btc:    s.db.NewBatch(),
+		log: utils.Logger().With().
+			Str("module", "explorer DB migration to 1.1.0").Logger(),
+		finishedC: make(chan struct{}),
+		closeC:    s.closeC,
+	}
+	return m.do()
+}
+
+// migrationV110 backfills the token transfer indexes of the blocks already
+// computed by explorer.
+type migrationV110 struct {
+	db     database
+	bc     blockChainBlockReader
+	blocks *roaring64.Bitmap
+	btc    batch
+
+	// progress
+	migratedNum uint64
+	totalNum    uint64
+
+	log       zerolog.Logger
+	finishedC chan struct{}
+	closeC    chan struct{}
+}
+
+func (m *migrationV110) do() error {
+	m.totalNum = m.blocks.GetCardinality()
+
+	go m.progressReportLoop()
+	defer close(m.finishedC)
+
+	m.log.Info().Str("progress", fmt.Sprintf("%v / %v", 0, m.totalNum)).
+		Msg("Start migration")
+	if err := m.doMigration(); err != nil {
+		if errors.Is(err, errInterrupted) {
+			return err
+		}
+		return errors.Wrap(err, "failed to migrate to V1.1.0")
+	}
+	m.log.Info().Msg("Finished migration. Start writing version")
+	if err := writeVersion(m.db, versionV110); err != nil {
+		return errors.Wrap(err, "write version")
+	}
+	m.log.Info().Msg("Finished migration")
+	return nil
+}
+
+func (m *migrationV110) progressReportLoop() {
+	t := time.NewTicker(2 * time.Second)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			migrated := atomic.LoadUint64(&m.migratedNum)
+			m.log.Info().Str("progress", fmt.Sprintf("%v / %v", migrated, m.totalNum)).
+				Msg("migration in progress")
+
+		case <-m.finishedC:
+			m.log.Info().Msg("migration to 1.1.0 finished")
+			return
+
+		case <-m.closeC:
+			m.log.Info().Msg("Migration interrupted")
+			return
+		}
+	}
+}
+
+func (m *migrationV110) doMigration() error {
+	it := m.blocks.Iterator()
+	for it.HasNext() {
+		select {
+		case <-m.closeC:
+			if err := m.btc.Write(); err != nil {
+				return err
+			}
+			return errInterrupted
+		default:
+		}
+		bn := it.Next()
+		b := m.bc.GetBlockByNumber(bn)
+		if b == nil {
+			m.log.Warn().Uint64("number", bn).Msg("block not found, skipping")
+			continue
+		}
+		writeBlockTokenTransfers(m.btc, b, m.bc.GetReceiptsByHash(b.Hash()))
+		atomic.AddUint64(&m.migratedNum, 1)
+
+		if err := m.flushDBIfBatchFull(); err != nil {
+			return err
+		}
+	}
+	return m.btc.Write()
+}
+
+func (m *migrationV110) flushDBIfBatchFull() error {
+	if m.btc.ValueSize() > writeThreshold {
+		if err := m.btc.Write(); err != nil {
+			return err
+		}
+		m.btc = m.db.NewBatch()
+	}
+	return nil
+}
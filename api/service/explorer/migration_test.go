@@ -2,15 +2,70 @@ package explorer
 
 import (
 	"fmt"
+	"math/big"
 	"os"
 	"testing"
 
+	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/abool"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/types"
 	"github.com/rs/zerolog"
 )
 
+func TestMigrationToV110(t *testing.T) {
+	db := newMemDB()
+	bc := &migrationBlockChain{
+		blocks:   make(map[uint64]*types.Block),
+		receipts: make(map[common.Hash]types.Receipts),
+	}
+	blocks := roaring64.NewBitmap()
+	for i := uint64(1); i <= 3; i++ {
+		header := blockfactory.NewTestHeader().With().Number(new(big.Int).SetUint64(i)).Header()
+		b := types.NewBlockWithHeader(header)
+		bc.blocks[i] = b
+		bc.receipts[b.Hash()] = types.Receipts{{
+			Logs: []*types.Log{{
+				Address: testToken,
+				Topics:  []common.Hash{transferTopic, addrTopic(testFrom), addrTopic(testTo)},
+				Data:    words(i),
+				Index:   0,
+			}},
+		}}
+		blocks.Add(i)
+	}
+	blocks.Add(4) // not found
+
+	m := &migrationV110{
+		db:        db,
+		bc:        bc,
+		blocks:    blocks,
+		btc:       db.NewBatch(),
+		log:       zerolog.Nop(),
+		finishedC: make(chan struct{}),
+	}
+	if err := m.do(); err != nil {
+		t.Fatal(err)
+	}
+	if is, err := isVersionV110(db); !is || err != nil {
+		t.Fatalf("unexpected version: %v, %v", is, err)
+	}
+	tts, _, err := getTokenTransfersByAccount(db, ethToOneAddress(testTo), 0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tts) != 3 {
+		t.Fatalf("unexpected token transfers size %v / %v", len(tts), 3)
+	}
+	for i, tt := range tts {
+		if tt.BlockNumber != uint64(i+1) || tt.Value.Uint64() != uint64(i+1) {
+			t.Errorf("unexpected token transfer %+v", tt)
+		}
+	}
+}
+
 func TestMigrationToV100(t *testing.T) {
 	t.Skip("skipping migration with high disk io")
 	fac := &migrationDBFactory{t: t}
@@ -75,9 +130,20 @@ func (f *migrationDBFactory) newLegTx() *LegTxRecord {
 	}
 }
 
-type migrationBlockChain struct{}
+type migrationBlockChain struct {
+	blocks   map[uint64]*types.Block
+	receipts map[common.Hash]types.Receipts
+}
 
 func (bc *migrationBlockChain) ReadTxLookupEntry(txID common.Hash) (common.Hash, uint64, uint64) {
 	index := txID.Big().Uint64()
 	return common.Hash{}, index / 100, index % 100
 }
+
+func (bc *migrationBlockChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
+	return bc.receipts[hash]
+}
+
+func (bc *migrationBlockChain) GetBlockByNumber(number uint64) *types.Block {
+	return bc.blocks[number]
+}
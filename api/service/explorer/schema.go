@@ -7,6 +7,7 @@ import (
 	"github.com/RoaringBitmap/roaring/roaring64"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/hmy/tracers"
 	goversion "github.com/hashicorp/go-version"
 	"github.com/pkg/errors"
 	"github.com/syndtr/goleveldb/leveldb"
@@ -73,10 +74,21 @@ func getTraceResult(db databaseReader, key []byte) ([]byte, error) {
 var (
 	versionKey     = []byte("version")
 	versionV100, _ = goversion.NewVersion("1.0.0")
+	versionV110, _ = goversion.NewVersion("1.1.0")
 )
 
 // isVersionV100 return whether the version is larger than or equal to 1.0.0
 func isVersionV100(db databaseReader) (bool, error) {
+	return isVersionAtLeast(db, versionV100)
+}
+
+// isVersionV110 return whether the version is larger than or equal to 1.1.0,
+// which has the token transfer indexes
+func isVersionV110(db databaseReader) (bool, error) {
+	return isVersionAtLeast(db, versionV110)
+}
+
+func isVersionAtLeast(db databaseReader, ver *goversion.Version) (bool, error) {
 	curVer, err := readVersion(db)
 	if err != nil {
 		if errors.Is(err, leveldb.ErrNotFound) {
@@ -84,7 +96,7 @@ func isVersionV100(db databaseReader) (bool, error) {
 		}
 		return false, err
 	}
-	return curVer.GreaterThanOrEqual(versionV100), nil
+	return curVer.GreaterThanOrEqual(ver), nil
 }
 
 func readVersion(db databaseReader) (*goversion.Version, error) {
@@ -107,6 +119,13 @@ var (
 	txnPrefix                 = []byte("tx")
 	addrNormalTxnIndexPrefix  = []byte("at")
 	addrStakingTxnIndexPrefix = []byte("stk")
+
+	tokenTransferPrefix          = []byte("tkt")
+	addrTokenTransferIndexPrefix = []byte("tka")
+	tokenRecipientIndexPrefix    = []byte("tkr")
+
+	internalTxnPrefix          = []byte("itx")
+	addrInternalTxnIndexPrefix = []byte("ita")
 )
 
 // bPool is the sync pool for reusing the memory for allocating db keys
@@ -340,6 +359,227 @@ func writeStakingTxnIndex(db databaseWriter, entry stakingTxnIndex, tt TxType) e
 	return db.Put(key, []byte{byte(tt)})
 }
 
+// accountRecords are the records indexed by the accounts sending and
+// receiving them, at their position in the chain: the token transfers and the
+// internal transactions. The position is the block number followed by two
+// indexes within the block.
+type accountRecords struct {
+	prefix      []byte // prefix of the records
+	indexPrefix []byte // prefix of the index entries of the accounts
+}
+
+var (
+	tokenTransferRecords = accountRecords{prefix: tokenTransferPrefix, indexPrefix: addrTokenTransferIndexPrefix}
+	internalTxnRecords   = accountRecords{prefix: internalTxnPrefix, indexPrefix: addrInternalTxnIndexPrefix}
+)
+
+// recordPosLen is the byte size of the position of a record in the db keys
+const recordPosLen = 8 + 8 + 8
+
+func writeRecordPos(b *buffer.Buffer, blockNumber, index, subIndex uint64) {
+	_ = binary.Write(b, binary.BigEndian, blockNumber)
+	_ = binary.Write(b, binary.BigEndian, index)
+	_ = binary.Write(b, binary.BigEndian, subIndex)
+}
+
+// getKey return the key of a record. It's the prefix of the records with the
+// position of the record.
+func (r accountRecords) getKey(blockNumber, index, subIndex uint64) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(r.prefix)
+	writeRecordPos(b, blockNumber, index, subIndex)
+	return b.Bytes()
+}
+
+// getIndexKey return the key of the index entry of a record for an account.
+// It's a combination of the index prefix, the account address and the
+// position of the record.
+func (r accountRecords) getIndexKey(addr oneAddress, blockNumber, index, subIndex uint64) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(r.indexPrefix)
+	_, _ = b.Write([]byte(addr))
+	writeRecordPos(b, blockNumber, index, subIndex)
+	return b.Bytes()
+}
+
+func (r accountRecords) indexPrefixByAddr(addr oneAddress) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(r.indexPrefix)
+	_, _ = b.Write([]byte(addr))
+	return b.Bytes()
+}
+
+func (r accountRecords) keyFromIndexKey(key []byte) ([]byte, error) {
+	posStart := len(r.indexPrefix) + oneAddrByteLen
+	expSize := posStart + recordPosLen
+	if len(key) < expSize {
+		return nil, errors.New("unexpected key size")
+	}
+	recordKey := make([]byte, 0, len(r.prefix)+recordPosLen)
+	recordKey = append(recordKey, r.prefix...)
+	return append(recordKey, key[posStart:expSize]...), nil
+}
+
+// writeIndex writes the index entries of a record for its sender and its
+// receiver. The zero address, sender of the mints and receiver of the burns,
+// is not indexed.
+func (r accountRecords) writeIndex(db databaseWriter, from, to common.Address, blockNumber, index, subIndex uint64) error {
+	for _, entry := range []struct {
+		addr common.Address
+		tt   TxType
+	}{{from, txSent}, {to, txReceived}} {
+		if entry.addr == (common.Address{}) {
+			continue
+		}
+		key := r.getIndexKey(ethToOneAddress(entry.addr), blockNumber, index, subIndex)
+		if err := db.Put(key, []byte{byte(entry.tt)}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getByAccount calls f with the key and the type of the records of the
+// account whose type is accepted by match, in chain order or in reverse chain
+// order if desc, skipping the first offset ones and stopping after limit.
+// Only the index entries are iterated to find the range, f is only called for
+// the records in it.
+func (r accountRecords) getByAccount(db databaseReader, addr oneAddress, match func(TxType) bool, desc bool, offset, limit int, f func(key []byte, tt TxType) error) error {
+	prefix := r.indexPrefixByAddr(addr)
+	var it iterator
+	if desc {
+		it = db.NewReversePrefixIterator(prefix)
+	} else {
+		it = db.NewPrefixIterator(prefix)
+	}
+	defer it.Release()
+
+	for n := 0; n < offset+limit && it.Next(); {
+		val := it.Value()
+		if len(val) < 1 {
+			return errors.New("val size not expected")
+		}
+		tt := TxType(val[0])
+		if match != nil && !match(tt) {
+			continue
+		}
+		if n++; n <= offset {
+			continue
+		}
+		key, err := r.keyFromIndexKey(it.Key())
+		if err != nil {
+			return err
+		}
+		if err := f(key, tt); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+func readTokenTransferByKey(db databaseReader, key []byte) (*TokenTransfer, error) {
+	b, err := db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	var tt *TokenTransfer
+	if err := rlp.DecodeBytes(b, &tt); err != nil {
+		return nil, err
+	}
+	return tt, nil
+}
+
+// writeTokenTransfer writes the token transfer, its index for the sender and
+// the receiver, and the receiver as a recipient of the token. The recipients
+// are not holders: their balance of the token is not tracked.
+func writeTokenTransfer(db databaseWriter, tt *TokenTransfer) error {
+	key := tokenTransferRecords.getKey(tt.BlockNumber, tt.LogIndex, tt.BatchIndex)
+	bs, err := rlp.EncodeToBytes(tt)
+	if err != nil {
+		return err
+	}
+	if err := db.Put(key, bs); err != nil {
+		return err
+	}
+	if err := tokenTransferRecords.writeIndex(db, tt.From, tt.To, tt.BlockNumber, tt.LogIndex, tt.BatchIndex); err != nil {
+		return err
+	}
+	if tt.To != (common.Address{}) {
+		recipientKey := getTokenRecipientKey(ethToOneAddress(tt.Token), ethToOneAddress(tt.To))
+		if err := db.Put(recipientKey, []byte{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// getTokenTransfersByAccount returns the token transfers of the account in
+// chain order, skipping the first offset ones and returning at most limit.
+func getTokenTransfersByAccount(db databaseReader, addr oneAddress, offset, limit int) ([]*TokenTransfer, []TxType, error) {
+	var (
+		tts     []*TokenTransfer
+		ttTypes []TxType
+	)
+	err := tokenTransferRecords.getByAccount(db, addr, nil, false, offset, limit, func(key []byte, ttType TxType) error {
+		tt, err := readTokenTransferByKey(db, key)
+		if err != nil {
+			return errors.Wrapf(err, "read token transfer")
+		}
+		tts = append(tts, tt)
+		ttTypes = append(ttTypes, ttType)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return tts, ttTypes, nil
+}
+
+// getTokenRecipientKey return the key of a token recipient. It's a combination
+// of tokenRecipientIndexPrefix, the token address and the recipient address.
+func getTokenRecipientKey(token, recipient oneAddress) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(tokenRecipientIndexPrefix)
+	_, _ = b.Write([]byte(token))
+	_, _ = b.Write([]byte(recipient))
+	return b.Bytes()
+}
+
+func tokenRecipientPrefixByToken(token oneAddress) []byte {
+	b := bPool.Get()
+	defer b.Free()
+
+	_, _ = b.Write(tokenRecipientIndexPrefix)
+	_, _ = b.Write([]byte(token))
+	return b.Bytes()
+}
+
+// getTokenRecipients returns the addresses which received the token, in address
+// order, skipping the first offset ones and returning at most limit.
+func getTokenRecipients(db databaseReader, token oneAddress, offset, limit int) ([]oneAddress, error) {
+	var recipients []oneAddress
+	prefix := tokenRecipientPrefixByToken(token)
+	err := forEachAtPrefixInRange(db, prefix, offset, limit, func(key, val []byte) error {
+		if len(key) < len(prefix)+oneAddrByteLen {
+			return errors.New("token recipient key size unexpected")
+		}
+		recipients = append(recipients, oneAddress(key[len(prefix):len(prefix)+oneAddrByteLen]))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return recipients, nil
+}
+
 func forEachAtPrefix(db databaseReader, prefix []byte, f func(key, val []byte) error) error {
 	it := db.NewPrefixIterator(prefix)
 	defer it.Release()
@@ -353,6 +593,72 @@ func forEachAtPrefix(db databaseReader, prefix []byte, f func(key, val []byte) e
 	return it.Error()
 }
 
+func readInternalTxnByKey(db databaseReader, key []byte) (*tracers.InternalTransaction, error) {
+	b, err := db.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	var itx *tracers.InternalTransaction
+	if err := rlp.DecodeBytes(b, &itx); err != nil {
+		return nil, err
+	}
+	return itx, nil
+}
+
+// writeInternalTxn writes the internal transaction and its index for the sender
+// and the receiver.
+func writeInternalTxn(db databaseWriter, itx *tracers.InternalTransaction) error {
+	key := internalTxnRecords.getKey(itx.BlockNumber, itx.TxIndex, itx.Index)
+	bs, err := rlp.EncodeToBytes(itx)
+	if err != nil {
+		return err
+	}
+	if err := db.Put(key, bs); err != nil {
+		return err
+	}
+	return internalTxnRecords.writeIndex(db, itx.From, itx.To, itx.BlockNumber, itx.TxIndex, itx.Index)
+}
+
+// getInternalTxnsByAccount returns the internal transactions of the account
+// whose type is accepted by match, in chain order or in reverse chain order if
+// desc, skipping the first offset ones and returning at most limit.
+func getInternalTxnsByAccount(db databaseReader, addr oneAddress, match func(TxType) bool, desc bool, offset, limit int) ([]*tracers.InternalTransaction, []TxType, error) {
+	var (
+		itxs []*tracers.InternalTransaction
+		tts  []TxType
+	)
+	err := internalTxnRecords.getByAccount(db, addr, match, desc, offset, limit, func(key []byte, tt TxType) error {
+		itx, err := readInternalTxnByKey(db, key)
+		if err != nil {
+			return errors.Wrapf(err, "read internal transaction")
+		}
+		itxs = append(itxs, itx)
+		tts = append(tts, tt)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return itxs, tts, nil
+}
+
+// forEachAtPrefixInRange is forEachAtPrefix skipping the first offset entries
+// and stopping after limit entries.
+func forEachAtPrefixInRange(db databaseReader, prefix []byte, offset, limit int, f func(key, val []byte) error) error {
+	it := db.NewPrefixIterator(prefix)
+	defer it.Release()
+
+	for i := 0; i < offset+limit && it.Next(); i++ {
+		if i < offset {
+			continue
+		}
+		if err := f(it.Key(), it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
 // Legacy Schema
 
 // LegGetAddressKey ...
@@ -2,11 +2,15 @@ package explorer
 
 import (
 	"bytes"
+	"math/big"
 	"reflect"
 	"sort"
 	"testing"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/hmy/tracers"
+	common2 "github.com/harmony-one/harmony/internal/common"
 	goversion "github.com/hashicorp/go-version"
 )
 
@@ -212,6 +216,139 @@ func TestGetAllAddresses(t *testing.T) {
 	}
 }
 
+func TestGetTokenTransfersByAccount(t *testing.T) {
+	db := newMemDB()
+	for i := 0; i != 10; i++ {
+		tt := makeTestTokenTransfer(TokenHRC20, 0, int64(i), uint64(i), 0)
+		tt.BlockNumber = uint64(10 - i)
+		if i == 0 {
+			tt.From = common.Address{} // mint
+		}
+		if err := writeTokenTransfer(db, tt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tests := []struct {
+		addr          common.Address
+		offset, limit int
+		expBlocks     []uint64
+		expType       TxType
+	}{
+		{testFrom, 0, 3, []uint64{1, 2, 3}, txSent},
+		{testFrom, 7, 3, []uint64{8, 9}, txSent},
+		{testTo, 8, 5, []uint64{9, 10}, txReceived},
+		{testTo, 10, 5, nil, txReceived},
+		{testOp, 0, 5, nil, txReceived},
+	}
+	for i, test := range tests {
+		tts, ttTypes, err := getTokenTransfersByAccount(db, ethToOneAddress(test.addr), test.offset, test.limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tts) != len(test.expBlocks) || len(ttTypes) != len(test.expBlocks) {
+			t.Fatalf("Test %v: unexpected size %v / %v", i, len(tts), len(test.expBlocks))
+		}
+		for j, tt := range tts {
+			if tt.BlockNumber != test.expBlocks[j] || tt.Value.Uint64() != 10-tt.BlockNumber {
+				t.Errorf("Test %v: unexpected transfer %+v", i, tt)
+			}
+			if ttTypes[j] != test.expType {
+				t.Errorf("Test %v: unexpected type %v", i, ttTypes[j])
+			}
+		}
+	}
+}
+
+func TestGetTokenRecipients(t *testing.T) {
+	db := newMemDB()
+	addrs := makeAddresses(11)[1:] // skip the zero address
+	for i := range addrs {
+		tt := makeTestTokenTransfer(TokenHRC721, int64(i), 1, uint64(i), 0)
+		tt.To = common2.MustBech32ToAddress(string(addrs[i]))
+		if err := writeTokenTransfer(db, tt); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sort.SliceStable(addrs, func(i, j int) bool {
+		return bytes.Compare([]byte(addrs[i]), []byte(addrs[j])) < 0
+	})
+
+	token := ethToOneAddress(testToken)
+	recipients, err := getTokenRecipients(db, token, 4, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(recipients, addrs[4:7]) {
+		t.Errorf("unexpected recipients %v / %v", recipients, addrs[4:7])
+	}
+	recipients, err = getTokenRecipients(db, ethToOneAddress(testOp), 0, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recipients) != 0 {
+		t.Errorf("unexpected recipients of other token %v", recipients)
+	}
+}
+
+func TestGetInternalTxnsByAccount(t *testing.T) {
+	db := newMemDB()
+	for i := 0; i != 5; i++ {
+		itx := &tracers.InternalTransaction{
+			BlockNumber:  uint64(5 - i),
+			TxHash:       makeTestTxHash(i),
+			Index:        1,
+			TraceAddress: []uint64{0},
+			Type:         "call",
+			From:         testFrom,
+			To:           testTo,
+			Value:        big.NewInt(int64(i)),
+		}
+		if i%2 == 0 {
+			itx.From = testOp
+		}
+		if err := writeInternalTxn(db, itx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	isSent := func(tt TxType) bool { return tt == txSent }
+	tests := []struct {
+		addr          common.Address
+		match         func(TxType) bool
+		desc          bool
+		offset, limit int
+		expBlocks     []uint64
+		expType       TxType
+	}{
+		{testFrom, nil, false, 0, 10, []uint64{2, 4}, txSent},
+		{testOp, nil, false, 0, 10, []uint64{1, 3, 5}, txSent},
+		{testTo, nil, false, 0, 10, []uint64{1, 2, 3, 4, 5}, txReceived},
+		{testToken, nil, false, 0, 10, nil, txReceived},
+		{testTo, nil, false, 1, 2, []uint64{2, 3}, txReceived},
+		{testTo, nil, true, 1, 2, []uint64{4, 3}, txReceived},
+		{testTo, nil, true, 4, 2, []uint64{1}, txReceived},
+		{testTo, nil, false, 5, 2, nil, txReceived},
+		{testTo, isSent, false, 0, 10, nil, txReceived},
+		{testOp, isSent, true, 0, 2, []uint64{5, 3}, txSent},
+	}
+	for i, test := range tests {
+		itxs, tts, err := getInternalTxnsByAccount(db, ethToOneAddress(test.addr), test.match, test.desc, test.offset, test.limit)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(itxs) != len(test.expBlocks) || len(tts) != len(test.expBlocks) {
+			t.Fatalf("Test %v: unexpected size %v / %v", i, len(itxs), len(test.expBlocks))
+		}
+		for j, itx := range itxs {
+			if itx.BlockNumber != test.expBlocks[j] || itx.TxHash != makeTestTxHash(5-int(itx.BlockNumber)) {
+				t.Errorf("Test %v: unexpected internal transaction %+v", i, itx)
+			}
+			if tts[j] != test.expType {
+				t.Errorf("Test %v: unexpected type %v", i, tts[j])
+			}
+		}
+	}
+}
+
 func makeAddresses(size int) []oneAddress {
 	var addrs []oneAddress
 	for i := 0; i != size; i++ {
@@ -22,6 +22,7 @@ import (
 	"github.com/harmony-one/harmony/hmy"
 	"github.com/harmony-one/harmony/hmy/tracers"
 	"github.com/harmony-one/harmony/internal/chain"
+	common2 "github.com/harmony-one/harmony/internal/common"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/numeric"
@@ -34,6 +35,7 @@ const (
 	explorerPortDifference = 4000
 	defaultPageSize        = "1000"
 	maxAddresses           = 100000
+	maxTokenPageSize       = 10000
 	nodeSyncTolerance      = 5
 )
 
@@ -120,6 +122,14 @@ func (s *Service) Run() *http.Server {
 	s.router.Path("/addresses").HandlerFunc(s.GetAddresses)
 	s.router.Path("/height").HandlerFunc(s.GetHeight)
 
+	// Set up router for token transfers and recipients.
+	// Fetch token transfers request, accepts parameter address: the account,
+	// parameter page and size: the page of the transfers
+	s.router.Path("/token-transfers").HandlerFunc(s.GetTokenTransfers).Methods("GET")
+	// Fetch token recipients request, accepts parameter token: the token contract,
+	// parameter page and size: the page of the recipients
+	s.router.Path("/token-recipients").HandlerFunc(s.GetTokenRecipients).Methods("GET")
+
 	// Set up router for supply info
 	s.router.Path("/burn-addresses").Queries().HandlerFunc(s.GetInaccessibleAddressInfo).Methods("GET")
 	s.router.Path("/burn-addresses").HandlerFunc(s.GetInaccessibleAddressInfo)
@@ -179,6 +189,94 @@ func (s *Service) GetAddresses(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// AddressTokenTransfer is a token transfer of an address, sent or received.
+type AddressTokenTransfer struct {
+	*TokenTransfer
+	Type string `json:"type"`
+}
+
+// GetTokenTransfers serves end-point /token-transfers, returns a page of the token
+// transfers of address, in chain order.
+func (s *Service) GetTokenTransfers(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	display := make([]*AddressTokenTransfer, 0)
+	defer func() {
+		if err := json.NewEncoder(w).Encode(display); err != nil {
+			utils.Logger().Warn().Err(err).Msg("cannot JSON-encode token transfers")
+		}
+	}()
+
+	addr, err := common2.ParseAddr(r.FormValue("address"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	page, size, err := parsePage(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	tts, ttTypes, err := s.storage.GetTokenTransfersByAddress(string(ethToOneAddress(addr)), page, size)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		utils.Logger().Warn().Err(err).Msg("wasn't able to fetch token transfers from storage")
+		return
+	}
+	for i, tt := range tts {
+		display = append(display, &AddressTokenTransfer{tt, ttTypes[i].String()})
+	}
+}
+
+// GetTokenRecipients serves end-point /token-recipients, returns a page of the
+// addresses which ever received token, whatever their current balance.
+func (s *Service) GetTokenRecipients(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	recipients := make([]string, 0)
+	defer func() {
+		if err := json.NewEncoder(w).Encode(recipients); err != nil {
+			utils.Logger().Warn().Err(err).Msg("cannot JSON-encode token recipients")
+		}
+	}()
+
+	token, err := common2.ParseAddr(r.FormValue("token"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	page, size, err := parsePage(r)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	res, err := s.storage.GetTokenRecipients(string(ethToOneAddress(token)), page, size)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		utils.Logger().Warn().Err(err).Msg("wasn't able to fetch token recipients from storage")
+		return
+	}
+	recipients = append(recipients, res...)
+}
+
+// parsePage parses the page and size parameters of the paginated end-points.
+func parsePage(r *http.Request) (int, int, error) {
+	pageStr, sizeStr := r.FormValue("page"), r.FormValue("size")
+	if pageStr == "" {
+		pageStr = "0"
+	}
+	if sizeStr == "" {
+		sizeStr = defaultPageSize
+	}
+	page, err := strconv.Atoi(pageStr)
+	if err != nil || page < 0 {
+		return 0, 0, fmt.Errorf("invalid page %q", pageStr)
+	}
+	size, err := strconv.Atoi(sizeStr)
+	if err != nil || size <= 0 || size > maxTokenPageSize {
+		return 0, 0, fmt.Errorf("invalid size %q", sizeStr)
+	}
+	return page, size, nil
+}
+
 type HeightResponse struct {
 	S0 uint64 `json:"0,omitempty"`
 	S1 uint64 `json:"1,omitempty"`
@@ -221,6 +319,12 @@ func (s *Service) GetStakingTxHashesByAccount(address string) ([]ethCommon.Hash,
 	return s.storage.GetStakingTxsByAddress(address)
 }
 
+// GetInternalTxsByAccount get a page of the internal transactions by account,
+// of the types accepted by match and in reverse chain order if desc
+func (s *Service) GetInternalTxsByAccount(address string, match func(TxType) bool, desc bool, page, size int) ([]*tracers.InternalTransaction, []TxType, error) {
+	return s.storage.GetInternalTxsByAddress(address, match, desc, page, size)
+}
+
 func (s *Service) GetTraceResultByHash(hash ethCommon.Hash) (json.RawMessage, error) {
 	return s.storage.GetTraceResultByHash(hash)
 }
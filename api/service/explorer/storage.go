@@ -199,6 +199,35 @@ func (s *storage) GetStakingTxsByAddress(addr string) ([]common.Hash, []TxType,
 	return getStakingTxnHashesByAccount(s.db, oneAddress(addr))
 }
 
+func (s *storage) GetInternalTxsByAddress(addr string, match func(TxType) bool, desc bool, page, size int) ([]*tracers.InternalTransaction, []TxType, error) {
+	if !s.available.IsSet() {
+		return nil, nil, ErrExplorerNotReady
+	}
+	return getInternalTxnsByAccount(s.db, oneAddress(addr), match, desc, page*size, size)
+}
+
+func (s *storage) GetTokenTransfersByAddress(addr string, page, size int) ([]*TokenTransfer, []TxType, error) {
+	if !s.available.IsSet() {
+		return nil, nil, ErrExplorerNotReady
+	}
+	return getTokenTransfersByAccount(s.db, oneAddress(addr), page*size, size)
+}
+
+func (s *storage) GetTokenRecipients(token string, page, size int) ([]string, error) {
+	if !s.available.IsSet() {
+		return nil, ErrExplorerNotReady
+	}
+	recipients, err := getTokenRecipients(s.db, oneAddress(token), page*size, size)
+	if err != nil {
+		return nil, err
+	}
+	display := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		display = append(display, string(recipient))
+	}
+	return display, nil
+}
+
 func (s *storage) GetTraceResultByHash(hash common.Hash) (json.RawMessage, error) {
 	if !s.available.IsSet() {
 		return nil, ErrExplorerNotReady
@@ -225,6 +254,18 @@ func (s *storage) run() {
 			os.Exit(1)
 		}
 	}
+	if is, err := isVersionV110(s.db); !is || err != nil {
+		s.available.UnSet()
+		err := s.migrateToV110()
+		if errors.Is(err, errInterrupted) {
+			return
+		}
+		if err != nil {
+			s.log.Error().Err(err).Msg("Failed to migrate explorer DB!")
+			fmt.Println("Failed to migrate explorer DB:", err)
+			os.Exit(1)
+		}
+	}
 	s.available.Set()
 	go s.loop()
 }
@@ -410,6 +451,7 @@ LOOP:
 					_ = writeTraceResult(traceResult.btc, key, value)
 				}
 			})
+			bc.computeInternalTxs(traceResult.btc, traceResult.data)
 			select {
 			case bc.resultT <- traceResult:
 			case <-bc.closeC:
@@ -433,6 +475,7 @@ func (bc *blockComputer) computeBlock(b *types.Block) (*blockResult, error) {
 	for _, stk := range b.StakingTransactions() {
 		bc.computeStakingTx(btc, b, stk)
 	}
+	writeBlockTokenTransfers(btc, b, bc.bc.GetReceiptsByHash(b.Hash()))
 	bc.tm.markBlockDone(btc, b.NumberU64())
 	return &blockResult{
 		btc: btc,
@@ -500,6 +543,18 @@ func (bc *blockComputer) computeStakingTx(btc batch, b *types.Block, tx *staking
 	}, txReceived)
 }
 
+func (bc *blockComputer) computeInternalTxs(btc batch, data *tracers.TraceBlockStorage) {
+	itxs, err := data.InternalTransactions()
+	if err != nil {
+		bc.log.Error().Err(err).Str("hash", data.Hash.String()).
+			Msg("explorer failed to decode internal transactions")
+		return
+	}
+	for _, itx := range itxs {
+		_ = writeInternalTxn(btc, itx)
+	}
+}
+
 func ethToOneAddress(ethAddr common.Address) oneAddress {
 	raw, _ := common2.AddressToBech32(ethAddr)
 	return oneAddress(raw)
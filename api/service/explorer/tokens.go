@@ -0,0 +1,177 @@
+package explorer
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+var (
+	// Transfer(address,address,uint256) of HRC20, and of HRC721 with the
+	// token id indexed
+	transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	// TransferSingle(address,address,address,uint256,uint256) of HRC1155
+	transferSingleTopic = crypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+	// TransferBatch(address,address,address,uint256[],uint256[]) of HRC1155
+	transferBatchTopic = crypto.Keccak256Hash([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+)
+
+const wordSize = 32
+
+// TokenStandard is the standard of a token contract.
+type TokenStandard byte
+
+const (
+	tokenUnknown TokenStandard = iota
+	TokenHRC20
+	TokenHRC721
+	TokenHRC1155
+)
+
+func (ts TokenStandard) String() string {
+	switch ts {
+	case TokenHRC20:
+		return "HRC20"
+	case TokenHRC721:
+		return "HRC721"
+	case TokenHRC1155:
+		return "HRC1155"
+	}
+	return "UNKNOWN"
+}
+
+// MarshalText encodes the token standard as its name in JSON
+func (ts TokenStandard) MarshalText() ([]byte, error) {
+	return []byte(ts.String()), nil
+}
+
+// TokenTransfer is the data structure stored in explorer db for a token transfer
+// decoded from the logs of a block. A log of HRC1155 TransferBatch is decoded to
+// one TokenTransfer for each of its token ids, in BatchIndex order.
+type TokenTransfer struct {
+	Standard    TokenStandard  `json:"standard"`
+	Token       common.Address `json:"token"`
+	From        common.Address `json:"from"`
+	To          common.Address `json:"to"`
+	TokenID     *big.Int       `json:"tokenID"`
+	Value       *big.Int       `json:"value"`
+	TxHash      common.Hash    `json:"txHash"`
+	BlockNumber uint64         `json:"blockNumber"`
+	LogIndex    uint64         `json:"logIndex"`
+	BatchIndex  uint64         `json:"batchIndex"`
+	Timestamp   uint64         `json:"timestamp"`
+}
+
+// writeBlockTokenTransfers decodes the token transfers from the receipts of the
+// block and writes them with their indexes to db.
+func writeBlockTokenTransfers(db databaseWriter, b *types.Block, receipts types.Receipts) {
+	for _, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			for _, tt := range decodeTokenTransfers(log) {
+				tt.BlockNumber = b.NumberU64()
+				tt.Timestamp = b.Time().Uint64()
+				_ = writeTokenTransfer(db, tt)
+			}
+		}
+	}
+}
+
+// decodeTokenTransfers returns the token transfers of the log, nil if the log
+// is not a well formed HRC20, HRC721 or HRC1155 transfer event.
+func decodeTokenTransfers(log *types.Log) []*TokenTransfer {
+	if len(log.Topics) == 0 {
+		return nil
+	}
+	newTransfer := func(standard TokenStandard, from, to common.Hash, id, value *big.Int) *TokenTransfer {
+		return &TokenTransfer{
+			Standard: standard,
+			Token:    log.Address,
+			From:     common.BytesToAddress(from[:]),
+			To:       common.BytesToAddress(to[:]),
+			TokenID:  id,
+			Value:    value,
+			TxHash:   log.TxHash,
+			LogIndex: uint64(log.Index),
+		}
+	}
+
+	switch log.Topics[0] {
+	case transferTopic:
+		// HRC20 and HRC721 share the event signature, they differ on whether
+		// the last argument is indexed
+		switch {
+		case len(log.Topics) == 3 && len(log.Data) == wordSize:
+			value := new(big.Int).SetBytes(log.Data)
+			return []*TokenTransfer{newTransfer(TokenHRC20, log.Topics[1], log.Topics[2], new(big.Int), value)}
+		case len(log.Topics) == 4 && len(log.Data) == 0:
+			id := log.Topics[3].Big()
+			return []*TokenTransfer{newTransfer(TokenHRC721, log.Topics[1], log.Topics[2], id, big.NewInt(1))}
+		}
+
+	case transferSingleTopic:
+		if len(log.Topics) != 4 || len(log.Data) != 2*wordSize {
+			return nil
+		}
+		id := new(big.Int).SetBytes(log.Data[:wordSize])
+		value := new(big.Int).SetBytes(log.Data[wordSize:])
+		return []*TokenTransfer{newTransfer(TokenHRC1155, log.Topics[2], log.Topics[3], id, value)}
+
+	case transferBatchTopic:
+		if len(log.Topics) != 4 {
+			return nil
+		}
+		ids, ok := decodeUint256Array(log.Data, 0)
+		if !ok {
+			return nil
+		}
+		values, ok := decodeUint256Array(log.Data, 1)
+		if !ok || len(ids) != len(values) {
+			return nil
+		}
+		tts := make([]*TokenTransfer, 0, len(ids))
+		for i := range ids {
+			tt := newTransfer(TokenHRC1155, log.Topics[2], log.Topics[3], ids[i], values[i])
+			tt.BatchIndex = uint64(i)
+			tts = append(tts, tt)
+		}
+		return tts
+	}
+	return nil
+}
+
+// decodeUint256Array decodes the ABI encoded uint256[] which is the argument
+// at position arg of data.
+func decodeUint256Array(data []byte, arg int) ([]*big.Int, bool) {
+	offset, ok := readWord(data, uint64(arg*wordSize))
+	if !ok {
+		return nil, false
+	}
+	size, ok := readWord(data, offset)
+	if !ok || size > uint64(len(data))/wordSize {
+		return nil, false
+	}
+	start := offset + wordSize
+	if start+size*wordSize > uint64(len(data)) {
+		return nil, false
+	}
+	values := make([]*big.Int, 0, size)
+	for i := uint64(0); i < size; i++ {
+		pos := start + i*wordSize
+		values = append(values, new(big.Int).SetBytes(data[pos:pos+wordSize]))
+	}
+	return values, true
+}
+
+// readWord reads the 32 bytes word at pos of data as an uint64.
+func readWord(data []byte, pos uint64) (uint64, bool) {
+	if pos > uint64(len(data)) || uint64(len(data))-pos < wordSize {
+		return 0, false
+	}
+	word := new(big.Int).SetBytes(data[pos : pos+wordSize])
+	if !word.IsUint64() {
+		return 0, false
+	}
+	return word.Uint64(), true
+}
@@ -0,0 +1,146 @@
+package explorer
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+var (
+	testToken = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testFrom  = common.HexToAddress("0x2222222222222222222222222222222222222222")
+	testTo    = common.HexToAddress("0x3333333333333333333333333333333333333333")
+	testOp    = common.HexToAddress("0x4444444444444444444444444444444444444444")
+)
+
+func TestDecodeTokenTransfers(t *testing.T) {
+	tests := []struct {
+		log *types.Log
+		exp []*TokenTransfer
+	}{
+		{
+			// HRC20
+			log: &types.Log{
+				Address: testToken,
+				Topics:  []common.Hash{transferTopic, addrTopic(testFrom), addrTopic(testTo)},
+				Data:    words(100),
+				Index:   3,
+			},
+			exp: []*TokenTransfer{
+				makeTestTokenTransfer(TokenHRC20, 0, 100, 3, 0),
+			},
+		},
+		{
+			// HRC721
+			log: &types.Log{
+				Address: testToken,
+				Topics:  []common.Hash{transferTopic, addrTopic(testFrom), addrTopic(testTo), common.BigToHash(big.NewInt(7))},
+				Index:   4,
+			},
+			exp: []*TokenTransfer{
+				makeTestTokenTransfer(TokenHRC721, 7, 1, 4, 0),
+			},
+		},
+		{
+			// HRC1155 single
+			log: &types.Log{
+				Address: testToken,
+				Topics:  []common.Hash{transferSingleTopic, addrTopic(testOp), addrTopic(testFrom), addrTopic(testTo)},
+				Data:    words(7, 20),
+				Index:   5,
+			},
+			exp: []*TokenTransfer{
+				makeTestTokenTransfer(TokenHRC1155, 7, 20, 5, 0),
+			},
+		},
+		{
+			// HRC1155 batch
+			log: &types.Log{
+				Address: testToken,
+				Topics:  []common.Hash{transferBatchTopic, addrTopic(testOp), addrTopic(testFrom), addrTopic(testTo)},
+				Data:    words(64, 160, 2, 7, 8, 2, 20, 30),
+				Index:   6,
+			},
+			exp: []*TokenTransfer{
+				makeTestTokenTransfer(TokenHRC1155, 7, 20, 6, 0),
+				makeTestTokenTransfer(TokenHRC1155, 8, 30, 6, 1),
+			},
+		},
+		{
+			// HRC1155 batch with different array sizes
+			log: &types.Log{
+				Address: testToken,
+				Topics:  []common.Hash{transferBatchTopic, addrTopic(testOp), addrTopic(testFrom), addrTopic(testTo)},
+				Data:    words(64, 160, 2, 7, 8, 1, 20),
+			},
+			exp: nil,
+		},
+		{
+			// HRC1155 batch with out of range array
+			log: &types.Log{
+				Address: testToken,
+				Topics:  []common.Hash{transferBatchTopic, addrTopic(testOp), addrTopic(testFrom), addrTopic(testTo)},
+				Data:    words(64, 1<<40, 2, 7, 8),
+			},
+			exp: nil,
+		},
+		{
+			// HRC20 with unexpected data
+			log: &types.Log{
+				Address: testToken,
+				Topics:  []common.Hash{transferTopic, addrTopic(testFrom), addrTopic(testTo)},
+				Data:    words(1, 2),
+			},
+			exp: nil,
+		},
+		{
+			// other event
+			log: &types.Log{
+				Address: testToken,
+				Topics:  []common.Hash{common.HexToHash("0x01"), addrTopic(testFrom), addrTopic(testTo)},
+				Data:    words(100),
+			},
+			exp: nil,
+		},
+		{
+			// anonymous event
+			log: &types.Log{Address: testToken},
+			exp: nil,
+		},
+	}
+	for i, test := range tests {
+		got := decodeTokenTransfers(test.log)
+		if !reflect.DeepEqual(got, test.exp) {
+			t.Errorf("Test %v: unexpected token transfers %+v / %+v", i, got, test.exp)
+		}
+	}
+}
+
+func makeTestTokenTransfer(standard TokenStandard, id, value int64, logIndex, batchIndex uint64) *TokenTransfer {
+	return &TokenTransfer{
+		Standard:   standard,
+		Token:      testToken,
+		From:       testFrom,
+		To:         testTo,
+		TokenID:    big.NewInt(id),
+		Value:      big.NewInt(value),
+		LogIndex:   logIndex,
+		BatchIndex: batchIndex,
+	}
+}
+
+func addrTopic(addr common.Address) common.Hash {
+	return common.BytesToHash(addr[:])
+}
+
+func words(values ...uint64) []byte {
+	data := make([]byte, 0, len(values)*wordSize)
+	for _, v := range values {
+		h := common.BigToHash(new(big.Int).SetUint64(v))
+		data = append(data, h[:]...)
+	}
+	return data
+}
@@ -18,7 +18,7 @@ func initStagesOrder(syncMode SyncMode) {
 	switch syncMode {
 	case FullSync:
 		initFullSyncStagesOrder()
-	case FastSync:
+	case FastSync, SnapSync:
 		initFastSyncStagesOrder()
 	default:
 		panic("not supported sync mode")
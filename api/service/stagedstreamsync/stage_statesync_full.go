@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/internal/utils"
 	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
 	"github.com/harmony-one/harmony/shard"
@@ -29,6 +30,9 @@ type StageFullStateSyncCfg struct {
 	protocol    syncProtocol
 	logger      zerolog.Logger
 	logProgress bool
+	// rebuildSnapshot generates the state snapshot of the pivot from the
+	// downloaded flat states once the state sync is completed (snap sync)
+	rebuildSnapshot bool
 }
 
 func NewStageFullStateSync(cfg StageFullStateSyncCfg) *StageFullStateSync {
@@ -41,16 +45,18 @@ func NewStageFullStateSyncCfg(bc core.BlockChain,
 	db kv.RwDB,
 	concurrency int,
 	protocol syncProtocol,
+	rebuildSnapshot bool,
 	logger zerolog.Logger,
 	logProgress bool) StageFullStateSyncCfg {
 
 	return StageFullStateSyncCfg{
-		bc:          bc,
-		db:          db,
-		concurrency: concurrency,
-		protocol:    protocol,
-		logger:      logger,
-		logProgress: logProgress,
+		bc:              bc,
+		db:              db,
+		concurrency:     concurrency,
+		protocol:        protocol,
+		logger:          logger,
+		logProgress:     logProgress,
+		rebuildSnapshot: rebuildSnapshot,
 	}
 }
 
@@ -130,12 +136,18 @@ func (sss *StageFullStateSync) Exec(ctx context.Context, bool, invalidBlockRever
 	}
 	wg.Wait()
 
-	// insert block
-	if err := sss.configs.bc.WriteHeadBlock(s.state.status.pivotBlock); err != nil {
-		sss.configs.logger.Warn().Err(err).
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	// workers give up on request failures, so the next cycle resumes the download
+	if !sdm.Completed() {
+		sss.configs.logger.Warn().
 			Uint64("pivot block number", s.state.status.pivotBlock.NumberU64()).
-			Msg(WrapStagedSyncMsg("insert pivot block failed"))
-		// TODO: panic("pivot block is failed to insert in chain.")
+			Msg(WrapStagedSyncMsg("state sync is not completed yet"))
+		return ErrStateSyncIncomplete
+	}
+
+	if err := sss.completeStateSync(s.state.status.pivotBlock); err != nil {
 		return err
 	}
 
@@ -178,6 +190,29 @@ func (sss *StageFullStateSync) Exec(ctx context.Context, bool, invalidBlockRever
 	return nil
 }
 
+// completeStateSync inserts the pivot block, whose state is fully downloaded,
+// as the head block and rebuilds the state snapshot of it if configured.
+func (sss *StageFullStateSync) completeStateSync(pivot *types.Block) error {
+	// insert block
+	if err := sss.configs.bc.WriteHeadBlock(pivot); err != nil {
+		sss.configs.logger.Warn().Err(err).
+			Uint64("pivot block number", pivot.NumberU64()).
+			Msg(WrapStagedSyncMsg("insert pivot block failed"))
+		// TODO: panic("pivot block is failed to insert in chain.")
+		return err
+	}
+
+	// The downloaded flat states are the snapshot disk layer of the pivot. The
+	// generator verifies them against the healed trie and only regenerates the
+	// ranges which don't match.
+	if sss.configs.rebuildSnapshot {
+		if snaps := sss.configs.bc.Snapshots(); snaps != nil {
+			snaps.Rebuild(pivot.Root())
+		}
+	}
+	return nil
+}
+
 // runStateWorkerLoop creates a work loop for download states
 func (sss *StageFullStateSync) runStateWorkerLoop(ctx context.Context, sdm *FullStateDownloadManager, wg *sync.WaitGroup, loopID int, startTime time.Time, s *StageState) {
 
@@ -0,0 +1,185 @@
+package stagedstreamsync
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/block"
+	headerV3 "github.com/harmony-one/harmony/block/v3"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/state/snapshot"
+	"github.com/harmony-one/harmony/core/types"
+	syncproto "github.com/harmony-one/harmony/p2p/stream/protocols/sync"
+	sttypes "github.com/harmony-one/harmony/p2p/stream/types"
+	"github.com/rs/zerolog"
+)
+
+func TestFullStateDownloadManager_Completed(t *testing.T) {
+	tests := []struct {
+		root   common.Hash
+		expect bool
+	}{
+		// the empty trie has nothing to heal
+		{root: ethtypes.EmptyRootHash, expect: true},
+		// the missing root is still pending in the scheduler
+		{root: common.HexToHash("0x01"), expect: false},
+	}
+	for i, test := range tests {
+		sdm := newFullStateDownloadManager(rawdb.NewMemoryDatabase(), rawdb.HashScheme, nil, nil, 1, zerolog.Nop())
+		sdm.setRootHash(test.root)
+		if sdm.Completed() {
+			t.Errorf("Test %v: completed with %v account tasks left", i, len(sdm.tasks.accountTasks))
+		}
+		for id := range sdm.tasks.accountTasks {
+			sdm.tasks.deleteAccountTask(id)
+		}
+		if completed := sdm.Completed(); completed != test.expect {
+			t.Errorf("Test %v: unexpected completed: %v / %v", i, completed, test.expect)
+		}
+	}
+}
+
+func TestInitStagesOrder_SnapSync(t *testing.T) {
+	initStagesOrder(FastSync)
+	fastOrder := StagesForwardOrder
+
+	initStagesOrder(SnapSync)
+	if len(StagesForwardOrder) != len(fastOrder) {
+		t.Fatalf("unexpected snap sync stages: %v / %v", StagesForwardOrder, fastOrder)
+	}
+	for i, id := range StagesForwardOrder {
+		if id != fastOrder[i] {
+			t.Errorf("unexpected stage %v: %v / %v", i, id, fastOrder[i])
+		}
+	}
+	if !hasStage(StagesRevertOrder, FullStateSync) || !hasStage(StagesCleanUpOrder, FullStateSync) {
+		t.Errorf("snap sync should revert and clean up the full state sync stage")
+	}
+}
+
+func TestStageFullStateSync_CompleteStateSync(t *testing.T) {
+	for _, rebuild := range []bool{false, true} {
+		bc, root := newTestStateBlockChain(t)
+		pivot := makeTestBlockWithRoot(10, root)
+		stg := NewStageFullStateSync(NewStageFullStateSyncCfg(bc, nil, 1, nil, rebuild, zerolog.Nop(), false))
+
+		if err := stg.completeStateSync(pivot); err != nil {
+			t.Fatalf("rebuild %v: complete state sync: %v", rebuild, err)
+		}
+		if bc.head != pivot {
+			t.Errorf("rebuild %v: pivot is not the head block", rebuild)
+		}
+		if rebuilt := bc.snaps.Snapshot(root) != nil; rebuilt != rebuild {
+			t.Errorf("rebuild %v: unexpected snapshot of the pivot: %v", rebuild, rebuilt)
+		}
+	}
+}
+
+func TestCheckPivot_SyncMode(t *testing.T) {
+	estimatedHeight := MaxPivotDistanceToHead + 100
+	for _, mode := range []SyncMode{FastSync, SnapSync} {
+		bc := &testBlockChain{db: rawdb.NewMemoryDatabase(), fastBlock: makeTestBlock(0)}
+		s := &StagedStreamSync{
+			bc:       bc,
+			protocol: &testSyncProtocol{},
+			config:   Config{SyncMode: mode, Concurrency: 2},
+			logger:   zerolog.Nop(),
+			status:   &status{},
+		}
+		pivot, cycleMode, err := s.checkPivot(context.Background(), estimatedHeight, true)
+		if err != nil {
+			t.Fatalf("mode %v: check pivot: %v", mode, err)
+		}
+		if cycleMode != mode {
+			t.Errorf("mode %v: unexpected cycle sync mode %v", mode, cycleMode)
+		}
+		if pivot == nil || pivot.NumberU64() != estimatedHeight-MinPivotDistanceToHead {
+			t.Fatalf("mode %v: unexpected pivot %v", mode, pivot)
+		}
+		if s.status.pivotBlock != pivot {
+			t.Errorf("mode %v: pivot is not set in the status", mode)
+		}
+		if n := rawdb.ReadLastPivotNumber(bc.db); n == nil || *n != pivot.NumberU64() {
+			t.Errorf("mode %v: unexpected stored pivot number %v", mode, n)
+		}
+	}
+
+	// the chain at early stage is fully synced
+	s := &StagedStreamSync{config: Config{SyncMode: SnapSync}, status: &status{}}
+	if pivot, cycleMode, err := s.checkPivot(context.Background(), MaxPivotDistanceToHead-1, true); pivot != nil || cycleMode != FullSync || err != nil {
+		t.Errorf("unexpected early stage pivot: %v, %v, %v", pivot, cycleMode, err)
+	}
+}
+
+type testBlockChain struct {
+	core.Stub
+	db        ethdb.Database
+	snaps     *snapshot.Tree
+	fastBlock *types.Block
+	head      *types.Block
+}
+
+func (bc *testBlockChain) ChainDb() ethdb.Database             { return bc.db }
+func (bc *testBlockChain) Snapshots() *snapshot.Tree           { return bc.snaps }
+func (bc *testBlockChain) CurrentFastBlock() *types.Block      { return bc.fastBlock }
+func (bc *testBlockChain) WriteHeadBlock(b *types.Block) error { bc.head = b; return nil }
+
+// newTestStateBlockChain returns a chain with the snapshot of the empty state
+// and the trie of a state holding one account, whose root is returned.
+func newTestStateBlockChain(t *testing.T) (*testBlockChain, common.Hash) {
+	db := rawdb.NewMemoryDatabase()
+	sdb := state.NewDatabase(db)
+	snaps, err := snapshot.New(snapshot.Config{CacheSize: 1}, db, sdb.TrieDB(), ethtypes.EmptyRootHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statedb, err := state.New(common.Hash{}, sdb, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statedb.SetBalance(common.HexToAddress("0x01"), big.NewInt(1))
+	root, err := statedb.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sdb.TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	return &testBlockChain{db: db, snaps: snaps}, root
+}
+
+type testSyncProtocol struct {
+	syncProtocol
+}
+
+func (p *testSyncProtocol) GetBlocksByNumber(ctx context.Context, bns []uint64, opts ...syncproto.Option) ([]*types.Block, sttypes.StreamID, error) {
+	blocks := make([]*types.Block, 0, len(bns))
+	for _, bn := range bns {
+		blocks = append(blocks, makeTestBlock(bn))
+	}
+	return blocks, "test", nil
+}
+
+func (p *testSyncProtocol) StreamFailed(stID sttypes.StreamID, reason string) {}
+
+func makeTestBlockWithRoot(bn uint64, root common.Hash) *types.Block {
+	testHeader := &block.Header{Header: headerV3.NewHeader()}
+	testHeader.SetNumber(big.NewInt(int64(bn)))
+	testHeader.SetRoot(root)
+	return types.NewBlockWithHeader(testHeader)
+}
+
+func hasStage(order []SyncStageID, id SyncStageID) bool {
+	for _, stage := range order {
+		if stage == id {
+			return true
+		}
+	}
+	return false
+}
@@ -575,6 +575,15 @@ func (s *FullStateDownloadManager) SyncCompleted() {
 	utils.Logger().Debug().Interface("elapsed", elapsed).Msg("Snapshot sync already completed")
 }
 
+// Completed returns whether all account ranges are downloaded and the state
+// trie is fully healed.
+func (s *FullStateDownloadManager) Completed() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return len(s.tasks.accountTasks) == 0 && s.scheduler.Pending() == 0
+}
+
 // getNextBatch returns objects with a maximum of n state download
 // tasks to send to the remote peer.
 func (s *FullStateDownloadManager) GetNextBatch() (accounts []*accountTask,
@@ -90,7 +90,7 @@ func CreateStagedSync(ctx context.Context,
 	stageBodiesCfg := NewStageBodiesCfg(bc, mainDB, dbs, config.Concurrency, protocol, isBeaconNode, extractReceiptHashes, config.LogProgress)
 	stageStatesCfg := NewStageStatesCfg(bc, mainDB, dbs, config.Concurrency, logger, config.LogProgress)
 	stageStateSyncCfg := NewStageStateSyncCfg(bc, mainDB, config.Concurrency, protocol, logger, config.LogProgress)
-	stageFullStateSyncCfg := NewStageFullStateSyncCfg(bc, mainDB, config.Concurrency, protocol, logger, config.LogProgress)
+	stageFullStateSyncCfg := NewStageFullStateSyncCfg(bc, mainDB, config.Concurrency, protocol, config.SyncMode == SnapSync, logger, config.LogProgress)
 	stageReceiptsCfg := NewStageReceiptsCfg(bc, mainDB, dbs, config.Concurrency, protocol, isBeaconNode, config.LogProgress)
 	lastMileCfg := NewStageLastMileCfg(ctx, bc, mainDB)
 	stageFinishCfg := NewStageFinishCfg(mainDB)
@@ -250,7 +250,7 @@ func (s *StagedStreamSync) checkPivot(ctx context.Context, estimatedHeight uint6
 			}
 		}
 	} else {
-		if head := s.CurrentBlockNumber(); s.config.SyncMode == FastSync && head <= 1 {
+		if head := s.CurrentBlockNumber(); s.config.SyncMode != FullSync && head <= 1 {
 			pivotBlockNumber = estimatedHeight - MinPivotDistanceToHead
 			if err := rawdb.WriteLastPivotNumber(s.bc.ChainDb(), pivotBlockNumber); err != nil {
 				s.logger.Warn().Err(err).
@@ -264,14 +264,14 @@ func (s *StagedStreamSync) checkPivot(ctx context.Context, estimatedHeight uint6
 			s.logger.Error().Err(err).
 				Uint64("pivot", pivotBlockNumber).
 				Msg(WrapStagedSyncMsg("query peers for pivot block failed"))
-			return block, FastSync, err
+			return block, s.config.SyncMode, err
 		} else {
 			if curPivot == nil || pivotBlockNumber != *curPivot {
 				if err := rawdb.WriteLastPivotNumber(s.bc.ChainDb(), pivotBlockNumber); err != nil {
 					s.logger.Warn().Err(err).
 						Uint64("new pivot number", pivotBlockNumber).
 						Msg(WrapStagedSyncMsg("update pivot number failed"))
-					return block, FastSync, err
+					return block, s.config.SyncMode, err
 				}
 			}
 			s.status.pivotBlock = block
@@ -279,7 +279,7 @@ func (s *StagedStreamSync) checkPivot(ctx context.Context, estimatedHeight uint6
 				Uint64("estimatedHeight", estimatedHeight).
 				Uint64("pivot number", pivotBlockNumber).
 				Msg(WrapStagedSyncMsg("fast/snap sync mode, pivot is set successfully"))
-			return block, FastSync, nil
+			return block, s.config.SyncMode, nil
 		}
 	}
 	return nil, FullSync, nil
@@ -473,10 +473,8 @@ func (s *StagedStreamSync) CurrentBlockNumber() uint64 {
 	switch s.config.SyncMode {
 	case FullSync:
 		current = s.bc.CurrentBlock().NumberU64()
-	case FastSync:
+	case FastSync, SnapSync:
 		current = s.bc.CurrentFastBlock().NumberU64()
-	case SnapSync:
-		current = s.bc.CurrentHeader().Number().Uint64()
 	}
 	return current
 }
@@ -485,10 +483,8 @@ func (s *StagedStreamSync) stateSyncStage() bool {
 	switch s.config.SyncMode {
 	case FullSync:
 		return false
-	case FastSync:
+	case FastSync, SnapSync:
 		return s.status.pivotBlock != nil && s.bc.CurrentFastBlock().NumberU64() == s.status.pivotBlock.NumberU64()-1
-	case SnapSync:
-		return false
 	}
 	return false
 }
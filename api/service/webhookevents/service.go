@@ -0,0 +1,227 @@
+// Package webhookevents is the service sending the chain events to the
+// webhooks: the new epochs, the committee changes, the validators elected
+// and booted, the large transfers and the stalled sync.
+package webhookevents
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/harmony-one/harmony/shard"
+	"github.com/harmony-one/harmony/staking/effective"
+	"github.com/harmony-one/harmony/webhooks"
+)
+
+const stallCheckInterval = 30 * time.Second
+
+type syncStatus interface {
+	SyncStatus(shardID uint32) (bool, uint64, uint64)
+}
+
+// Service sends the events of the chain to the webhooks of the dispatcher.
+type Service struct {
+	dispatcher *webhooks.Dispatcher
+	node       syncStatus
+	bc         core.BlockChain
+	ch         chan core.ChainHeadEvent
+	sub        event.Subscription
+	closeCh    chan struct{}
+	beacon     bool
+
+	epoch    *big.Int
+	head     uint64
+	headTime time.Time
+	stalled  bool
+}
+
+// New returns the Service sending the events of bc to the dispatcher.
+func New(dispatcher *webhooks.Dispatcher, node syncStatus, bc core.BlockChain) *Service {
+	return &Service{
+		dispatcher: dispatcher,
+		node:       node,
+		bc:         bc,
+		ch:         make(chan core.ChainHeadEvent, 16),
+		closeCh:    make(chan struct{}),
+		beacon:     bc.ShardID() == shard.BeaconChainShardID,
+	}
+}
+
+// Start starts service.
+func (s *Service) Start() error {
+	header := s.bc.CurrentHeader()
+	s.epoch, s.head, s.headTime = header.Epoch(), header.Number().Uint64(), time.Now()
+	s.dispatcher.Start()
+	s.sub = s.bc.SubscribeChainHeadEvent(s.ch)
+	go s.run()
+	return nil
+}
+
+// Stop stops service.
+func (s *Service) Stop() error {
+	close(s.closeCh)
+	if s.sub != nil {
+		s.sub.Unsubscribe()
+	}
+	s.dispatcher.Stop()
+	return nil
+}
+
+func (s *Service) run() {
+	ticker := time.NewTicker(stallCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case ev := <-s.ch:
+			s.onHead(ev.Block)
+		case <-ticker.C:
+			s.checkStall()
+		case <-s.closeCh:
+			return
+		}
+	}
+}
+
+func (s *Service) onHead(block *types.Block) {
+	if block == nil {
+		return
+	}
+	s.head, s.headTime, s.stalled = block.NumberU64(), time.Now(), false
+
+	if block.Epoch().Cmp(s.epoch) > 0 {
+		s.epoch = block.Epoch()
+		s.dispatcher.Emit(webhooks.EventNewEpoch, map[string]interface{}{
+			"shardID":     s.bc.ShardID(),
+			"epoch":       block.Epoch(),
+			"blockNumber": block.NumberU64(),
+		})
+	}
+	if len(block.Header().ShardState()) > 0 {
+		s.onCommitteeChange(block)
+	}
+	s.checkLargeTransfers(block)
+}
+
+// onCommitteeChange handles the last block of an epoch, which carries the
+// committees of the next epoch.
+func (s *Service) onCommitteeChange(block *types.Block) {
+	next, err := shard.DecodeWrapper(block.Header().ShardState())
+	if err != nil {
+		utils.Logger().Warn().Err(err).Uint64("blockNumber", block.NumberU64()).
+			Msg("[WebHooks] cannot decode the shard state")
+		return
+	}
+	if s.dispatcher.Subscribed(webhooks.EventCommitteeChange) {
+		if committee, err := next.FindCommitteeByID(s.bc.ShardID()); err == nil {
+			slots := make([]map[string]interface{}, 0, len(committee.Slots))
+			for _, slot := range committee.Slots {
+				slots = append(slots, map[string]interface{}{
+					"address": slot.EcdsaAddress,
+					"blsKey":  slot.BLSPublicKey.Hex(),
+				})
+			}
+			s.dispatcher.Emit(webhooks.EventCommitteeChange, map[string]interface{}{
+				"shardID":     s.bc.ShardID(),
+				"epoch":       next.Epoch,
+				"blockNumber": block.NumberU64(),
+				"slots":       slots,
+			})
+		}
+	}
+
+	// the elections are only known by the beacon chain
+	if !s.beacon || !(s.dispatcher.Subscribed(webhooks.EventValidatorElected) ||
+		s.dispatcher.Subscribed(webhooks.EventValidatorBooted)) {
+		return
+	}
+	current, err := s.bc.ReadShardState(block.Epoch())
+	if err != nil {
+		utils.Logger().Warn().Err(err).Msg("[WebHooks] cannot read the shard state")
+		return
+	}
+	before, after := validators(current), validators(next)
+	for addr := range after {
+		if _, ok := before[addr]; !ok {
+			s.dispatcher.Emit(webhooks.EventValidatorElected, map[string]interface{}{
+				"address": addr,
+				"epoch":   next.Epoch,
+			})
+		}
+	}
+	for addr := range before {
+		if _, ok := after[addr]; ok {
+			continue
+		}
+		wrapper, err := s.bc.ReadValidatorInformation(addr)
+		if err != nil {
+			continue
+		}
+		if status := wrapper.Status; status == effective.Inactive || status == effective.Banned {
+			s.dispatcher.Emit(webhooks.EventValidatorBooted, map[string]interface{}{
+				"address": addr,
+				"epoch":   next.Epoch,
+				"status":  status.String(),
+			})
+		}
+	}
+}
+
+// validators returns the addresses of the external validators of the state.
+func validators(state *shard.State) map[common.Address]struct{} {
+	addrs := make(map[common.Address]struct{})
+	for _, committee := range state.Shards {
+		for _, slot := range committee.Slots {
+			if slot.EffectiveStake != nil {
+				addrs[slot.EcdsaAddress] = struct{}{}
+			}
+		}
+	}
+	return addrs
+}
+
+func (s *Service) checkLargeTransfers(block *types.Block) {
+	if !s.dispatcher.Subscribed(webhooks.EventLargeTransfer) {
+		return
+	}
+	threshold := s.dispatcher.LargeTransferThreshold()
+	if threshold.Sign() <= 0 {
+		return
+	}
+	for _, tx := range block.Transactions() {
+		if tx.Value().Cmp(threshold) < 0 {
+			continue
+		}
+		from, err := tx.SenderAddress()
+		if err != nil {
+			continue
+		}
+		s.dispatcher.Emit(webhooks.EventLargeTransfer, map[string]interface{}{
+			"hash":        tx.Hash(),
+			"from":        from,
+			"to":          tx.To(),
+			"value":       tx.Value(),
+			"shardID":     tx.ShardID(),
+			"toShardID":   tx.ToShardID(),
+			"blockNumber": block.NumberU64(),
+		})
+	}
+}
+
+func (s *Service) checkStall() {
+	if s.stalled || time.Since(s.headTime) < s.dispatcher.SyncStallTimeout() {
+		return
+	}
+	s.stalled = true
+	_, target, diff := s.node.SyncStatus(s.bc.ShardID())
+	s.dispatcher.Emit(webhooks.EventSyncStalled, map[string]interface{}{
+		"shardID":      s.bc.ShardID(),
+		"blockNumber":  s.head,
+		"since":        s.headTime.Unix(),
+		"peerHeight":   target,
+		"blocksBehind": diff,
+	})
+}
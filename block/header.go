@@ -15,6 +15,7 @@ import (
 	v1 "github.com/harmony-one/harmony/block/v1"
 	v2 "github.com/harmony-one/harmony/block/v2"
 	v3 "github.com/harmony-one/harmony/block/v3"
+	v4 "github.com/harmony-one/harmony/block/v4"
 	"github.com/harmony-one/harmony/crypto/hash"
 	"github.com/harmony-one/taggedrlp"
 	"github.com/pkg/errors"
@@ -57,9 +58,10 @@ func (h Header) MarshalJSON() ([]byte, error) {
 		MixDigest   common.Hash      `json:"mixHash"`
 		Hash        common.Hash      `json:"hash"`
 		// Additional Fields
-		ViewID  *big.Int `json:"viewID"`
-		Epoch   *big.Int `json:"epoch"`
-		ShardID uint32   `json:"shardID"`
+		ViewID  *big.Int     `json:"viewID"`
+		Epoch   *big.Int     `json:"epoch"`
+		ShardID uint32       `json:"shardID"`
+		BaseFee *hexutil.Big `json:"baseFeePerGas,omitempty"`
 	}{
 		h.ParentHash(),
 		common.Hash{},
@@ -80,6 +82,7 @@ func (h Header) MarshalJSON() ([]byte, error) {
 		h.Header.ViewID(),
 		h.Header.Epoch(),
 		h.Header.ShardID(),
+		(*hexutil.Big)(h.Header.BaseFee()),
 	})
 }
 
@@ -170,4 +173,6 @@ func init() {
 	HeaderRegistry.MustAddFactory(func() interface{} { return v2.NewHeader() })
 	HeaderRegistry.MustRegister("v3", v3.NewHeader())
 	HeaderRegistry.MustAddFactory(func() interface{} { return v3.NewHeader() })
+	HeaderRegistry.MustRegister("v4", v4.NewHeader())
+	HeaderRegistry.MustAddFactory(func() interface{} { return v4.NewHeader() })
 }
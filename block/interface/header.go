@@ -236,4 +236,15 @@ type Header interface {
 	// SetSlashes sets the RLP-encoded form of slashes
 	// It stores a copy; the caller may freely modify the original.
 	SetSlashes(newSlashes []byte)
+
+	// BaseFee is the EIP-1559 base fee per gas of this block, nil for header
+	// versions before the London fork.
+	//
+	// The returned instance is a copy; the caller may do anything with it.
+	BaseFee() *big.Int
+
+	// SetBaseFee sets the EIP-1559 base fee per gas of this block.
+	//
+	// It stores a copy; the caller may freely modify the original.
+	SetBaseFee(newBaseFee *big.Int)
 }
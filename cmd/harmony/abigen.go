@@ -0,0 +1,206 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/ethereum/go-ethereum/common/compiler"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/accounts/abi/bind"
+	"github.com/harmony-one/harmony/internal/cli"
+)
+
+var (
+	abiFlag = cli.StringFlag{
+		Name:     "abi",
+		Usage:    "path to the contract ABI json to bind, - for STDIN",
+		DefValue: "",
+	}
+	binFlag = cli.StringFlag{
+		Name:     "bin",
+		Usage:    "path to the contract bytecode (generate deploy method)",
+		DefValue: "",
+	}
+	typeFlag = cli.StringFlag{
+		Name:     "type",
+		Usage:    "struct name for the binding (default = package name)",
+		DefValue: "",
+	}
+	combinedJSONFlag = cli.StringFlag{
+		Name:     "combined_json",
+		Usage:    "path to the solc --combined-json output, - for STDIN",
+		DefValue: "",
+	}
+	excFlag = cli.StringSliceFlag{
+		Name:     "exc",
+		Usage:    "fully qualified contract names to exclude from the combined json",
+		DefValue: []string{},
+	}
+	pkgFlag = cli.StringFlag{
+		Name:     "pkg",
+		Usage:    "package name to generate the binding into",
+		DefValue: "",
+	}
+	outFlag = cli.StringFlag{
+		Name:     "out",
+		Usage:    "output file for the generated binding (default = STDOUT)",
+		DefValue: "",
+	}
+	langFlag = cli.StringFlag{
+		Name:     "lang",
+		Usage:    "destination language for the bindings (go, java)",
+		DefValue: "go",
+	}
+	aliasFlag = cli.StringSliceFlag{
+		Name:     "alias",
+		Usage:    "renamings of contract methods, events and errors (e.g. original1=alias1)",
+		DefValue: []string{},
+	}
+)
+
+var abigenCmd = &cobra.Command{
+	Use:   "abigen",
+	Short: "generate contract bindings.",
+	Long:  "generate Go or Java contract bindings against the harmony bind and types packages.",
+	Example: "harmony abigen --abi token.abi --bin token.bin --pkg token --out token.go\n" +
+		"harmony abigen --combined_json contracts.json --pkg contracts --out contracts.go",
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := abigen(cmd); err != nil {
+			fmt.Fprintln(os.Stderr, "abigen error:", err)
+			os.Exit(-1)
+		}
+		os.Exit(0)
+	},
+}
+
+func registerAbigenFlags() error {
+	return cli.RegisterFlags(abigenCmd, []cli.Flag{
+		abiFlag, binFlag, typeFlag, combinedJSONFlag, excFlag,
+		pkgFlag, outFlag, langFlag, aliasFlag,
+	})
+}
+
+// abigen collects the contracts selected by the command flags and writes
+// their bindings to the output file.
+func abigen(cmd *cobra.Command) error {
+	pkg := cli.GetStringFlagValue(cmd, pkgFlag)
+	if pkg == "" {
+		return errors.New("no destination package specified (--pkg)")
+	}
+	var lang bind.Lang
+	switch cli.GetStringFlagValue(cmd, langFlag) {
+	case "go":
+		lang = bind.LangGo
+	case "java":
+		lang = bind.LangJava
+	default:
+		return fmt.Errorf("unsupported destination language %q (--lang)", cli.GetStringFlagValue(cmd, langFlag))
+	}
+
+	var (
+		types, abis, bins []string
+		sigs              []map[string]string
+		libs              = make(map[string]string)
+		aliases           = make(map[string]string)
+	)
+	switch abiPath, jsonPath := cli.GetStringFlagValue(cmd, abiFlag), cli.GetStringFlagValue(cmd, combinedJSONFlag); {
+	case abiPath != "" && jsonPath != "":
+		return errors.New("--abi and --combined_json are mutually exclusive")
+
+	case abiPath != "":
+		abi, err := readInput(abiPath)
+		if err != nil {
+			return fmt.Errorf("failed to read input ABI: %v", err)
+		}
+		abis = append(abis, string(abi))
+
+		var bin []byte
+		if binPath := cli.GetStringFlagValue(cmd, binFlag); binPath != "" {
+			if bin, err = os.ReadFile(binPath); err != nil {
+				return fmt.Errorf("failed to read input bytecode: %v", err)
+			}
+			if strings.Contains(string(bin), "//") {
+				return errors.New("contract has additional library references, please use --combined_json instead")
+			}
+		}
+		bins = append(bins, string(bin))
+
+		kind := cli.GetStringFlagValue(cmd, typeFlag)
+		if kind == "" {
+			kind = pkg
+		}
+		types = append(types, kind)
+
+	case jsonPath != "":
+		data, err := readInput(jsonPath)
+		if err != nil {
+			return fmt.Errorf("failed to read combined json: %v", err)
+		}
+		contracts, err := compiler.ParseCombinedJSON(data, "", "", "", "")
+		if err != nil {
+			return fmt.Errorf("failed to parse combined json: %v", err)
+		}
+		exclude := make(map[string]bool)
+		for _, name := range cli.GetStringSliceFlagValue(cmd, excFlag) {
+			exclude[strings.ToLower(name)] = true
+		}
+		for name, contract := range contracts {
+			// Linked libraries are referenced by a placeholder derived from
+			// the fully qualified name, so they are recorded even if excluded
+			nameParts := strings.Split(name, ":")
+			typeName := nameParts[len(nameParts)-1]
+			libs[crypto.Keccak256Hash([]byte(name)).String()[2:36]] = typeName
+			if exclude[strings.ToLower(name)] {
+				continue
+			}
+			abi, err := json.Marshal(contract.Info.AbiDefinition)
+			if err != nil {
+				return fmt.Errorf("failed to parse ABI of %s: %v", name, err)
+			}
+			abis = append(abis, string(abi))
+			bins = append(bins, contract.Code)
+			sigs = append(sigs, contract.Hashes)
+			types = append(types, typeName)
+		}
+
+	default:
+		return errors.New("either --abi or --combined_json must be specified")
+	}
+
+	for _, alias := range cli.GetStringSliceFlagValue(cmd, aliasFlag) {
+		parts := strings.Split(alias, "=")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid alias %q, expected original=alias", alias)
+		}
+		aliases[parts[0]] = parts[1]
+	}
+
+	code, err := bind.Bind(types, abis, bins, sigs, pkg, lang, libs, aliases)
+	if err != nil {
+		return fmt.Errorf("failed to generate binding: %v", err)
+	}
+	out := cli.GetStringFlagValue(cmd, outFlag)
+	if out == "" {
+		fmt.Print(code)
+		return nil
+	}
+	if err := os.WriteFile(out, []byte(code), 0600); err != nil {
+		return fmt.Errorf("failed to write binding: %v", err)
+	}
+	return nil
+}
+
+// readInput reads the file at path, or the standard input if path is "-".
+func readInput(path string) ([]byte, error) {
+	if path == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(path)
+}
@@ -0,0 +1,311 @@
+package main
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/chain"
+	"github.com/harmony-one/harmony/internal/cli"
+	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/harmony-one/harmony/internal/shardchain"
+	"github.com/harmony-one/harmony/shard"
+)
+
+// chainReportInterval is the interval between progress reports of export and import.
+const chainReportInterval = 8 * time.Second
+
+var (
+	chainShardFlag = cli.IntFlag{
+		Name:     "shard",
+		Usage:    "shard ID of the chain",
+		DefValue: 0,
+	}
+	exportStartFlag = cli.Uint64Flag{
+		Name:     "start",
+		Usage:    "number of the first block to export",
+		DefValue: 0,
+	}
+	exportEndFlag = cli.Int64Flag{
+		Name:     "end",
+		Usage:    "number of the last block to export (-1 = current head)",
+		DefValue: -1,
+	}
+	importTrustedFlag = cli.BoolFlag{
+		Name:     "trusted",
+		Usage:    "insert blocks without verifying headers and commit signatures",
+		DefValue: false,
+	}
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export file",
+	Short: "export a shard chain to an RLP file.",
+	Long: "export the blocks of a shard chain together with their commit signatures to an RLP file. " +
+		"The file is gzipped if its name ends with .gz.",
+	Example: "harmony export --datadir ./ --shard 1 --start 0 --end 1000 shard1.rlp.gz",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := exportChain(cmd, args[0]); err != nil {
+			fmt.Fprintln(os.Stderr, "export error:", err)
+			os.Exit(-1)
+		}
+		os.Exit(0)
+	},
+}
+
+var importCmd = &cobra.Command{
+	Use:   "import file",
+	Short: "import a shard chain from an RLP file.",
+	Long: "import the blocks of an RLP file written by export into a shard chain. " +
+		"The file is gunzipped if its name ends with .gz.",
+	Example: "harmony import --datadir ./ --shard 1 shard1.rlp.gz",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := importChain(cmd, args[0]); err != nil {
+			fmt.Fprintln(os.Stderr, "import error:", err)
+			os.Exit(-1)
+		}
+		os.Exit(0)
+	},
+}
+
+func registerChainFlags() error {
+	chainFlags := []cli.Flag{configFlag, networkTypeFlag, dataDirFlag, chainShardFlag}
+	if err := cli.RegisterFlags(exportCmd, append(chainFlags, exportStartFlag, exportEndFlag)); err != nil {
+		return err
+	}
+	return cli.RegisterFlags(importCmd, append(chainFlags, importTrustedFlag))
+}
+
+// exportedBlock is the record written to an export file for each block.
+type exportedBlock struct {
+	Block     *types.Block
+	CommitSig []byte // commit signature and bitmap signed on the block
+}
+
+// openShardChain opens the chain of the given shard in the data directory of
+// the config, along with the beacon chain it depends on.
+func openShardChain(hc harmonyconfig.HarmonyConfig, shardID uint32) (*shardchain.CollectionImpl, core.BlockChain, error) {
+	if hc.General.RunElasticMode {
+		return nil, nil, errors.New("elastic mode is not supported")
+	}
+	nodeconfigSetShardSchedule(hc)
+	nodeconfig.SetShardingSchedule(shard.Schedule)
+
+	dbFactory, err := newChainDBFactory(hc)
+	if err != nil {
+		return nil, nil, err
+	}
+	networkType := nodeconfig.NetworkType(hc.Network.NetworkType)
+	chainConfig := networkType.ChainConfig()
+	collection := shardchain.NewCollection(
+		&hc, dbFactory, &core.GenesisInitializer{NetworkType: networkType}, chain.NewEngine(), &chainConfig,
+	)
+	if shardID != shard.BeaconChainShardID {
+		if _, err := collection.ShardChain(shard.BeaconChainShardID, core.Options{EpochChain: true}); err != nil {
+			collection.Close()
+			return nil, nil, err
+		}
+	}
+	bc, err := collection.ShardChain(shardID)
+	if err != nil {
+		collection.Close()
+		return nil, nil, err
+	}
+	return collection, bc, nil
+}
+
+// getChainConfig returns the node config of the command with the data
+// directory and shard ID of the chain subcommands.
+func getChainConfig(cmd *cobra.Command) (harmonyconfig.HarmonyConfig, uint32, error) {
+	hc, err := getHarmonyConfig(cmd)
+	if err != nil {
+		return harmonyconfig.HarmonyConfig{}, 0, err
+	}
+	shardID := cli.GetIntFlagValue(cmd, chainShardFlag)
+	if shardID < 0 {
+		return harmonyconfig.HarmonyConfig{}, 0, errors.Errorf("invalid shard ID %d", shardID)
+	}
+	return hc, uint32(shardID), nil
+}
+
+func exportChain(cmd *cobra.Command, fn string) error {
+	hc, shardID, err := getChainConfig(cmd)
+	if err != nil {
+		return err
+	}
+	collection, bc, err := openShardChain(hc, shardID)
+	if err != nil {
+		return err
+	}
+	defer collection.Close()
+
+	head := bc.CurrentBlock().NumberU64()
+	first, last := cli.GetUint64FlagValue(cmd, exportStartFlag), head
+	if end := cli.GetInt64FlagValue(cmd, exportEndFlag); end >= 0 {
+		last = uint64(end)
+	}
+	if first > last {
+		return errors.Errorf("first block %d is greater than last block %d", first, last)
+	}
+	if last > head {
+		return errors.Errorf("last block %d is beyond the current head %d", last, head)
+	}
+	fmt.Printf("exporting blocks %d-%d of shard %d to %s\n", first, last, shardID, fn)
+	return exportBlocks(bc, fn, first, last)
+}
+
+// exportBlocks writes the blocks first to last of the chain with their commit
+// signatures to the file fn, gzipped if its name ends with .gz.
+func exportBlocks(bc core.BlockChain, fn string, first, last uint64) error {
+	fh, err := os.OpenFile(fn, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	defer fh.Close()
+	var (
+		w  io.Writer = fh
+		gz *gzip.Writer
+	)
+	if strings.HasSuffix(fn, ".gz") {
+		gz = gzip.NewWriter(fh)
+		w = gz
+	}
+
+	start, reported := time.Now(), time.Now()
+	for nr := first; nr <= last; nr++ {
+		block := bc.GetBlockByNumber(nr)
+		if block == nil {
+			return errors.Errorf("block %d not found", nr)
+		}
+		// The commit signature of a block is carried by its child, except
+		// for the head block whose signature is only kept in the database
+		var commitSig []byte
+		if next := bc.GetHeaderByNumber(nr + 1); next != nil {
+			sig := next.LastCommitSignature()
+			commitSig = append(sig[:], next.LastCommitBitmap()...)
+		} else if commitSig, err = bc.ReadCommitSig(nr); err != nil {
+			fmt.Printf("commit signature of block %d not found, it can only be imported as trusted\n", nr)
+			commitSig = nil
+		}
+		if err := rlp.Encode(w, &exportedBlock{Block: block, CommitSig: commitSig}); err != nil {
+			return err
+		}
+		if time.Since(reported) >= chainReportInterval {
+			fmt.Printf("exported %d blocks, elapsed %v\n", nr-first+1, time.Since(start))
+			reported = time.Now()
+		}
+	}
+	if gz != nil {
+		if err := gz.Close(); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("exported %d blocks in %v\n", last-first+1, time.Since(start))
+	return nil
+}
+
+func importChain(cmd *cobra.Command, fn string) error {
+	hc, shardID, err := getChainConfig(cmd)
+	if err != nil {
+		return err
+	}
+	trusted := cli.GetBoolFlagValue(cmd, importTrustedFlag)
+
+	collection, bc, err := openShardChain(hc, shardID)
+	if err != nil {
+		return err
+	}
+	defer collection.Close()
+
+	fmt.Printf("importing blocks of shard %d from %s\n", shardID, fn)
+	_, _, err = importBlocks(bc, fn, trusted)
+	return err
+}
+
+// importBlocks inserts the blocks of the file fn written by exportBlocks into
+// the chain, skipping the blocks already known. It returns the number of
+// imported and skipped blocks.
+func importBlocks(bc core.BlockChain, fn string, trusted bool) (int, int, error) {
+	fh, err := os.Open(fn)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer fh.Close()
+	var r io.Reader = fh
+	if strings.HasSuffix(fn, ".gz") {
+		gz, err := gzip.NewReader(fh)
+		if err != nil {
+			return 0, 0, err
+		}
+		defer gz.Close()
+		r = gz
+	}
+
+	var (
+		stream            = rlp.NewStream(r, 0)
+		start, reported   = time.Now(), time.Now()
+		imported, skipped int
+	)
+	for {
+		var eb exportedBlock
+		if err := stream.Decode(&eb); err == io.EOF {
+			break
+		} else if err != nil {
+			return imported, skipped, errors.Wrapf(err, "failed to decode block %d of file", imported+skipped)
+		}
+		block := eb.Block
+		if block.ShardID() != bc.ShardID() {
+			return imported, skipped, errors.Errorf("block %d belongs to shard %d", block.NumberU64(), block.ShardID())
+		}
+		if bc.HasBlock(block.Hash(), block.NumberU64()) {
+			skipped++
+			continue
+		}
+		if err := importBlock(bc, block, eb.CommitSig, trusted); err != nil {
+			return imported, skipped, errors.Wrapf(err, "failed to import block %d", block.NumberU64())
+		}
+		imported++
+		if time.Since(reported) >= chainReportInterval {
+			fmt.Printf("imported %d blocks, head %d, elapsed %v\n",
+				imported, bc.CurrentBlock().NumberU64(), time.Since(start))
+			reported = time.Now()
+		}
+	}
+	fmt.Printf("imported %d blocks, skipped %d known blocks, head %d, in %v\n",
+		imported, skipped, bc.CurrentBlock().NumberU64(), time.Since(start))
+	return imported, skipped, nil
+}
+
+// importBlock inserts a single block into the chain and stores its commit
+// signature. Unless trusted, the commit signature and header are verified
+// first, in the same way as blocks received by sync.
+func importBlock(bc core.BlockChain, block *types.Block, commitSig []byte, trusted bool) error {
+	if !trusted {
+		sig, bitmap, err := chain.ParseCommitSigAndBitmap(commitSig)
+		if err != nil {
+			return errors.Wrap(err, "parse commit signature")
+		}
+		if err := bc.Engine().VerifyHeaderSignature(bc, block.Header(), sig, bitmap); err != nil {
+			return errors.Wrap(err, "verify commit signature")
+		}
+	}
+	if _, err := bc.InsertChain(types.Blocks{block}, !trusted); err != nil {
+		return err
+	}
+	if len(commitSig) == 0 {
+		return nil
+	}
+	return bc.WriteCommitSig(block.NumberU64(), commitSig)
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"bytes"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	bls_core "github.com/harmony-one/bls/ffi/go/bls"
+	"github.com/harmony-one/harmony/block"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	headerV3 "github.com/harmony-one/harmony/block/v3"
+	consensus_sig "github.com/harmony-one/harmony/consensus/signature"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/crypto/hash"
+	"github.com/harmony-one/harmony/internal/chain"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/harmony-one/harmony/shard"
+)
+
+const testChainLength = 5
+
+func TestExportImportChain(t *testing.T) {
+	src := newTestChain(t)
+	generateTestBlocks(t, src, testChainLength)
+	dir := t.TempDir()
+
+	for _, name := range []string{"chain.rlp", "chain.rlp.gz"} {
+		fn := filepath.Join(dir, name)
+		if err := exportBlocks(src, fn, 0, testChainLength); err != nil {
+			t.Fatalf("%s: export: %v", name, err)
+		}
+		for _, trusted := range []bool{false, true} {
+			dst := newTestChain(t)
+			imported, skipped, err := importBlocks(dst, fn, trusted)
+			if err != nil {
+				t.Fatalf("%s trusted %v: import: %v", name, trusted, err)
+			}
+			// the genesis block is known by the fresh chain
+			if imported != testChainLength || skipped != 1 {
+				t.Errorf("%s trusted %v: unexpected imported / skipped blocks %v / %v", name, trusted, imported, skipped)
+			}
+			if have, want := dst.CurrentBlock().Hash(), src.CurrentBlock().Hash(); have != want {
+				t.Errorf("%s trusted %v: unexpected head %x / %x", name, trusted, have, want)
+			}
+			for nr := uint64(1); nr <= testChainLength; nr++ {
+				have, err := dst.ReadCommitSig(nr)
+				if err != nil {
+					t.Fatalf("%s trusted %v: commit signature of block %v: %v", name, trusted, nr, err)
+				}
+				want, _ := src.ReadCommitSig(nr)
+				if !bytes.Equal(have, want) {
+					t.Errorf("%s trusted %v: unexpected commit signature of block %v", name, trusted, nr)
+				}
+			}
+
+			// all the blocks are known on a second import
+			imported, skipped, err = importBlocks(dst, fn, trusted)
+			if err != nil || imported != 0 || skipped != testChainLength+1 {
+				t.Errorf("%s trusted %v: unexpected reimport %v / %v, %v", name, trusted, imported, skipped, err)
+			}
+		}
+	}
+}
+
+func TestImportChain_WrongShard(t *testing.T) {
+	header := &block.Header{Header: headerV3.NewHeader()}
+	header.SetNumber(big.NewInt(1))
+	header.SetShardID(1)
+	data, err := rlp.EncodeToBytes(&exportedBlock{Block: types.NewBlockWithHeader(header)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	fn := filepath.Join(t.TempDir(), "chain.rlp")
+	if err := os.WriteFile(fn, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	bc := newTestChain(t)
+	_, _, err = importBlocks(bc, fn, true)
+	if err == nil || !strings.Contains(err.Error(), "belongs to shard 1") {
+		t.Errorf("unexpected error %v", err)
+	}
+	if bc.CurrentBlock().NumberU64() != 0 {
+		t.Errorf("unexpected head %v", bc.CurrentBlock().NumberU64())
+	}
+}
+
+// testChainKey is the key of the single validator signing the test chains.
+// The key is the same for all the chains since the voting power of a
+// committee is cached by epoch and shard.
+var testChainKey = func() *bls_core.SecretKey {
+	var key bls_core.SecretKey
+	if err := key.SetLittleEndian(hash.Keccak256([]byte("chain test"))); err != nil {
+		panic(err)
+	}
+	return &key
+}()
+
+// newTestChain returns a beacon chain at its genesis block, whose committee
+// is the validator of testChainKey.
+func newTestChain(t *testing.T) core.BlockChain {
+	config := *params.TestChainConfig
+	// the rewards of the aggregated reward epoch are computed over blocks
+	// missing from short chains
+	config.AggregatedRewardEpoch = params.EpochTBD
+
+	var pub bls.SerializedPublicKey
+	if err := pub.FromLibBLSPublicKey(testChainKey.GetPublicKey()); err != nil {
+		t.Fatal(err)
+	}
+	committee := shard.Committee{
+		ShardID: shard.BeaconChainShardID,
+		Slots:   shard.SlotList{{EcdsaAddress: utils.GetAddressFromBLSPubKeyBytes(pub[:]), BLSPublicKey: pub}},
+	}
+	genesis := core.Genesis{
+		Config:     &config,
+		Factory:    blockfactory.NewFactory(&config),
+		ShardID:    shard.BeaconChainShardID,
+		GasLimit:   params.TestGenesisGasLimit,
+		ShardState: shard.State{Epoch: big.NewInt(core.GenesisEpoch), Shards: []shard.Committee{committee}},
+	}
+	db := rawdb.NewMemoryDatabase()
+	genesis.MustCommit(db)
+
+	cacheConfig := &core.CacheConfig{Disabled: true, TrieCleanLimit: 256, TrieDirtyLimit: 256, TrieTimeLimit: 5 * time.Minute}
+	bc, err := core.NewBlockChain(db, nil, nil, cacheConfig, &config, chain.NewEngine(), vm.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(bc.Stop)
+	return bc
+}
+
+// generateTestBlocks inserts n empty blocks signed by testChainKey into the
+// chain, storing their commit signatures as a node does.
+func generateTestBlocks(t *testing.T, bc core.BlockChain, n int) {
+	factory := blockfactory.NewFactory(bc.Config())
+	bitmap := []byte{0x01}
+	for i := 0; i < n; i++ {
+		parent := bc.CurrentBlock().Header()
+		var sig [96]byte
+		copy(sig[:], signTestBlock(bc, parent))
+		if i > 0 {
+			if err := bc.WriteCommitSig(parent.Number().Uint64(), append(sig[:], bitmap...)); err != nil {
+				t.Fatal(err)
+			}
+		}
+		header := factory.NewHeader(parent.Epoch()).With().
+			ParentHash(parent.Hash()).
+			Coinbase(parent.Coinbase()).
+			Number(new(big.Int).Add(parent.Number(), big.NewInt(1))).
+			GasLimit(parent.GasLimit()).
+			Time(new(big.Int).Add(parent.Time(), big.NewInt(10))).
+			ShardID(parent.ShardID()).
+			LastCommitSignature(sig).
+			LastCommitBitmap(bitmap).
+			Header()
+		statedb, err := bc.StateAt(parent.Root())
+		if err != nil {
+			t.Fatal(err)
+		}
+		sigsReady := make(chan bool, 1)
+		sigsReady <- true
+		blk, _, err := bc.Engine().Finalize(
+			bc, bc, header, statedb, nil, nil, nil, nil, nil, nil, sigsReady,
+			func() uint64 { return header.ViewID().Uint64() },
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := bc.InsertChain(types.Blocks{blk}, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	head := bc.CurrentBlock().Header()
+	if err := bc.WriteCommitSig(head.Number().Uint64(), append(signTestBlock(bc, head), bitmap...)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// signTestBlock returns the commit signature of testChainKey on the header.
+func signTestBlock(bc core.BlockChain, header *block.Header) []byte {
+	payload := consensus_sig.ConstructCommitPayload(bc.Config(), header.Epoch(), header.Hash(),
+		header.Number().Uint64(), header.ViewID().Uint64())
+	return testChainKey.SignHash(payload).Serialize()
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/internal/cli"
 	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
@@ -42,6 +43,13 @@ func validateHarmonyConfig(config harmonyconfig.HarmonyConfig) error {
 		return err
 	}
 
+	if dbEngine := config.General.DBEngine; dbEngine != "" {
+		accepts = []string{rawdb.DBLeveldb, rawdb.DBPebble}
+		if err := checkStringAccepted("--db.engine", dbEngine, accepts); err != nil {
+			return err
+		}
+	}
+
 	if config.General.NodeType == nodeTypeExplorer && config.General.ShardID < 0 {
 		return errors.New("flag --run.shard must be specified for explorer node")
 	}
@@ -416,6 +416,66 @@ func init() {
 		return confTree
 	}
 
+	migrations["2.6.1"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("HTTP.AuthJWTSecretFile") == nil {
+			confTree.Set("HTTP.AuthJWTSecretFile", defaultConfig.HTTP.AuthJWTSecretFile)
+		}
+		if confTree.Get("WS.AuthJWTSecretFile") == nil {
+			confTree.Set("WS.AuthJWTSecretFile", defaultConfig.WS.AuthJWTSecretFile)
+		}
+		confTree.Set("Version", "2.6.2")
+		return confTree
+	}
+
+	migrations["2.6.2"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("RPCOpt.ClientRequestsPerSecond") == nil {
+			confTree.Set("RPCOpt.ClientRequestsPerSecond", defaultConfig.RPCOpt.ClientRequestsPerSecond)
+		}
+		if confTree.Get("RPCOpt.APIKeyRequestsPerSecond") == nil {
+			confTree.Set("RPCOpt.APIKeyRequestsPerSecond", defaultConfig.RPCOpt.APIKeyRequestsPerSecond)
+		}
+		if confTree.Get("RPCOpt.BatchItemLimit") == nil {
+			confTree.Set("RPCOpt.BatchItemLimit", defaultConfig.RPCOpt.BatchItemLimit)
+		}
+		if confTree.Get("RPCOpt.BatchResponseMaxSize") == nil {
+			confTree.Set("RPCOpt.BatchResponseMaxSize", defaultConfig.RPCOpt.BatchResponseMaxSize)
+		}
+		confTree.Set("Version", "2.6.3")
+		return confTree
+	}
+
+	migrations["2.6.3"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("HTTP.GraphQLEnabled") == nil {
+			confTree.Set("HTTP.GraphQLEnabled", defaultConfig.HTTP.GraphQLEnabled)
+		}
+		confTree.Set("Version", "2.6.4")
+		return confTree
+	}
+
+	migrations["2.6.4"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("Health") == nil {
+			confTree.Set("Health", defaultConfig.Health)
+		}
+		confTree.Set("Version", "2.6.5")
+		return confTree
+	}
+
+	migrations["2.6.5"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("Ancient") == nil {
+			confTree.Set("Ancient", defaultConfig.Ancient)
+		}
+		confTree.Set("Version", "2.6.6")
+		return confTree
+	}
+
+	migrations["2.6.6"] = func(confTree *toml.Tree) *toml.Tree {
+		if confTree.Get("General.DBEngine") == nil {
+			confTree.Set("General.DBEngine", defaultConfig.General.DBEngine)
+		}
+		confTree.Set("Version", "2.6.7")
+		return confTree
+	}
+
 	// check that the latest version here is the same as in default.go
 	largestKey := getNextVersion(migrations)
 	if largestKey != tomlConfigVersion {
@@ -0,0 +1,365 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/harmony-one/harmony/core/dbverify"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state/pruner"
+	"github.com/harmony-one/harmony/internal/cli"
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+	"github.com/harmony-one/harmony/internal/shardchain"
+)
+
+var (
+	bloomSizeFlag = cli.Uint64Flag{
+		Name:     "bloomfilter.size",
+		Usage:    "megabytes of memory allocated to the bloom filter of live state",
+		DefValue: 2048,
+	}
+	convertKeepFlag = cli.BoolFlag{
+		Name:     "keep",
+		Usage:    "keep the LevelDB database next to the converted one, with a .leveldb suffix",
+		DefValue: false,
+	}
+	verifyFromFlag = cli.Uint64Flag{
+		Name:     "from",
+		Usage:    "number of the first block to verify",
+		DefValue: 0,
+	}
+	verifyToFlag = cli.Int64Flag{
+		Name:     "to",
+		Usage:    "number of the last block to verify (-1 = current head)",
+		DefValue: -1,
+	}
+	verifyStateRootsFlag = cli.Uint64Flag{
+		Name:     "state.recent",
+		Usage:    "number of recent state roots checked for availability",
+		DefValue: dbverify.DefaultStateRoots,
+	}
+	verifyRepairFlag = cli.BoolFlag{
+		Name:     "repair",
+		Usage:    "repair the inconsistencies which can be derived from the canonical blocks",
+		DefValue: false,
+	}
+)
+
+var dbCmd = &cobra.Command{
+	Use:   "db",
+	Short: "low level chain database operations.",
+	Long:  "low level operations on the chain database of a shard. The node must be stopped.",
+}
+
+var pruneStateCmd = &cobra.Command{
+	Use:   "prune-state [root]",
+	Short: "prune stale state from a chain database.",
+	Long: "prune all state which does not belong to the given state root, or to the bottom-most " +
+		"snapshot layer if none is given, from the chain database of a shard. The snapshot of the " +
+		"shard must be enabled and complete. An interrupted pruning is resumed by running " +
+		"prune-state again or by starting the node.",
+	Example: "harmony db prune-state --datadir ./ --shard 0",
+	Args:    cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := pruneState(cmd, args); err != nil {
+			fmt.Fprintln(os.Stderr, "prune-state error:", err)
+			os.Exit(-1)
+		}
+		os.Exit(0)
+	},
+}
+
+var convertCmd = &cobra.Command{
+	Use:   "convert",
+	Short: "convert a chain database from LevelDB to Pebble.",
+	Long: "copy the LevelDB chain database of a shard into a new Pebble database which then replaces it. " +
+		"The ancient store of the shard, if any, is moved along. The databases of all the shards " +
+		"in the data directory must be converted before the node is started.",
+	Example: "harmony db convert --datadir ./ --shard 0",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := convertDB(cmd); err != nil {
+			fmt.Fprintln(os.Stderr, "convert error:", err)
+			os.Exit(-1)
+		}
+		os.Exit(0)
+	},
+}
+
+var verifyCmd = &cobra.Command{
+	Use:   "verify",
+	Short: "check the consistency of a chain database.",
+	Long: "walk the canonical blocks of the chain database of a shard and check their headers, bodies, " +
+		"receipts, transaction lookup entries and commit signatures, the shard states, crosslinks and " +
+		"validator snapshots, and the availability of the recent state roots. A JSON summary of the " +
+		"inconsistencies is printed, and the command exits with 1 if any is left unrepaired.",
+	Example: "harmony db verify --datadir ./ --shard 0 --repair",
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		summary, err := verifyDB(cmd)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "verify error:", err)
+			os.Exit(-1)
+		}
+		if summary.Unrepaired() > 0 {
+			os.Exit(1)
+		}
+		os.Exit(0)
+	},
+}
+
+func registerDBFlags() error {
+	dbFlags := []cli.Flag{configFlag, networkTypeFlag, dataDirFlag, chainShardFlag}
+	if err := cli.RegisterFlags(pruneStateCmd, append(dbFlags, bloomSizeFlag)); err != nil {
+		return err
+	}
+	if err := cli.RegisterFlags(convertCmd, append(dbFlags, convertKeepFlag)); err != nil {
+		return err
+	}
+	return cli.RegisterFlags(verifyCmd, append(dbFlags, verifyFromFlag, verifyToFlag, verifyStateRootsFlag, verifyRepairFlag))
+}
+
+// openDB opens the database in dir with the given engine, or with the engine
+// of the existing database if empty, along with the ancient store inside it
+// if there is one.
+func openDB(dir, engine string) (ethdb.Database, error) {
+	o := rawdb.OpenOptions{
+		Type:      engine,
+		Directory: dir,
+		Cache:     LEVELDB_CACHE_SIZE,
+		Handles:   LEVELDB_HANDLES,
+	}
+	if ancient := filepath.Join(dir, shardchain.AncientDirName); common.FileExist(ancient) {
+		o.AncientsDirectory = ancient
+	}
+	return rawdb.Open(o)
+}
+
+func pruneState(cmd *cobra.Command, args []string) error {
+	var root common.Hash
+	if len(args) > 0 {
+		b, err := hexutil.Decode(args[0])
+		if err != nil || len(b) != common.HashLength {
+			return errors.Errorf("invalid state root %q", args[0])
+		}
+		root = common.BytesToHash(b)
+	}
+	hc, shardID, err := getChainConfig(cmd)
+	if err != nil {
+		return err
+	}
+	dbFactory, err := newChainDBFactory(hc)
+	if err != nil {
+		return err
+	}
+	db, err := dbFactory.NewChainDB(shardID)
+	if err != nil {
+		return errors.Wrap(err, "cannot open chain database")
+	}
+	defer db.Close()
+
+	// The state bloom filter is kept apart from the chain database so that
+	// pruning can be resumed from it after an interruption.
+	bloomDir := pruneStateDir(hc.General.DataDir, shardID)
+	if err := os.MkdirAll(bloomDir, 0755); err != nil {
+		return err
+	}
+	p, err := pruner.NewPruner(db, pruner.Config{
+		Datadir:   bloomDir,
+		BloomSize: cli.GetUint64FlagValue(cmd, bloomSizeFlag),
+	})
+	if err != nil {
+		return err
+	}
+	if err := p.Prune(root); err != nil {
+		return err
+	}
+	fmt.Printf("pruned state of shard %d\n", shardID)
+	return nil
+}
+
+// pruneStateDir returns the directory of the state bloom filter of the
+// pruning of the given shard in dataDir.
+func pruneStateDir(dataDir string, shardID uint32) string {
+	return filepath.Join(dataDir, fmt.Sprintf("prune_state_%d", shardID))
+}
+
+// recoverPruningFactory resumes the interrupted pruning of the state of a
+// shard when its database is opened, since part of its live state may be
+// deleted until the pruning completes.
+type recoverPruningFactory struct {
+	shardchain.DBFactory
+	dataDir string
+}
+
+// NewChainDB returns the database of the given shard, once its pruning is
+// complete.
+func (f *recoverPruningFactory) NewChainDB(shardID uint32) (ethdb.Database, error) {
+	db, err := f.DBFactory.NewChainDB(shardID)
+	if err != nil {
+		return nil, err
+	}
+	if err := pruner.RecoverPruning(pruneStateDir(f.dataDir, shardID), db); err != nil {
+		db.Close()
+		return nil, errors.Wrapf(err, "cannot resume the state pruning of shard %d", shardID)
+	}
+	return db, nil
+}
+
+func convertDB(cmd *cobra.Command) error {
+	if !rawdb.PebbleEnabled {
+		return errors.New("pebble is not supported on this platform")
+	}
+	hc, shardID, err := getChainConfig(cmd)
+	if err != nil {
+		return err
+	}
+	dir := shardchain.ChainDBDir(hc.General.DataDir, shardID)
+	fmt.Printf("converting %s to pebble\n", dir)
+	copied, err := convertChainDB(dir, cli.GetBoolFlagValue(cmd, convertKeepFlag))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("converted %d entries of shard %d to pebble\n", copied, shardID)
+	if hc.General.DBEngine == rawdb.DBLeveldb {
+		fmt.Println("set General.DBEngine to pebble or leave it empty before starting the node")
+	}
+	return nil
+}
+
+// convertChainDB replaces the LevelDB database in dir by a Pebble copy, and
+// returns the number of copied entries. The LevelDB database is kept next to
+// it with a .leveldb suffix if keep is set.
+func convertChainDB(dir string, keep bool) (uint64, error) {
+	if engine := rawdb.PreexistingDatabase(dir); engine != rawdb.DBLeveldb {
+		return 0, errors.Errorf("no LevelDB database found in %s", dir)
+	}
+	// The Pebble database is built next to the LevelDB one and only replaces
+	// it once complete, a leftover of an interrupted conversion is discarded.
+	tmpDir, oldDir := dir+".pebble", dir+".leveldb"
+	if err := os.RemoveAll(tmpDir); err != nil {
+		return 0, err
+	}
+	if common.FileExist(oldDir) {
+		return 0, errors.Errorf("%s already exists", oldDir)
+	}
+
+	copied, err := copyDB(dir, tmpDir)
+	if err != nil {
+		return 0, err
+	}
+	if ancient := filepath.Join(dir, shardchain.AncientDirName); common.FileExist(ancient) {
+		if err := os.Rename(ancient, filepath.Join(tmpDir, shardchain.AncientDirName)); err != nil {
+			return 0, err
+		}
+	}
+	if err := os.Rename(dir, oldDir); err != nil {
+		return 0, err
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return 0, err
+	}
+	if !keep {
+		if err := os.RemoveAll(oldDir); err != nil {
+			return 0, err
+		}
+	}
+	return copied, nil
+}
+
+// copyDB copies all the key-value entries of the LevelDB database in src to
+// a new Pebble database in dst, and returns the number of copied entries.
+func copyDB(src, dst string) (uint64, error) {
+	srcDB, err := rawdb.NewLevelDBDatabase(src, LEVELDB_CACHE_SIZE, LEVELDB_HANDLES, "", true)
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot open LevelDB database")
+	}
+	defer srcDB.Close()
+	dstDB, err := rawdb.NewPebbleDBDatabase(dst, LEVELDB_CACHE_SIZE, LEVELDB_HANDLES, "", false)
+	if err != nil {
+		return 0, errors.Wrap(err, "cannot create Pebble database")
+	}
+	defer dstDB.Close()
+
+	var (
+		it              = srcDB.NewIterator(nil, nil)
+		batch           = dstDB.NewBatch()
+		copied          uint64
+		start, reported = time.Now(), time.Now()
+	)
+	defer it.Release()
+	for it.Next() {
+		if err := batch.Put(it.Key(), it.Value()); err != nil {
+			return 0, err
+		}
+		copied++
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return 0, err
+			}
+			batch.Reset()
+		}
+		if time.Since(reported) >= chainReportInterval {
+			fmt.Printf("copied %d entries, elapsed %v\n", copied, time.Since(start))
+			reported = time.Now()
+		}
+	}
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+	return copied, nil
+}
+
+func verifyDB(cmd *cobra.Command) (*dbverify.Summary, error) {
+	hc, shardID, err := getChainConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+	dbFactory, err := newChainDBFactory(hc)
+	if err != nil {
+		return nil, err
+	}
+	db, err := dbFactory.NewChainDB(shardID)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot open chain database")
+	}
+	defer db.Close()
+
+	chainConfig := nodeconfig.NetworkType(hc.Network.NetworkType).ChainConfig()
+	config := dbverify.Config{
+		From:        cli.GetUint64FlagValue(cmd, verifyFromFlag),
+		To:          math.MaxUint64,
+		StateRoots:  cli.GetUint64FlagValue(cmd, verifyStateRootsFlag),
+		Repair:      cli.GetBoolFlagValue(cmd, verifyRepairFlag),
+		ChainConfig: &chainConfig,
+	}
+	if to := cli.GetInt64FlagValue(cmd, verifyToFlag); to >= 0 {
+		config.To = uint64(to)
+	}
+	fmt.Fprintf(os.Stderr, "verifying chain database of shard %d\n", shardID)
+	summary, err := dbverify.Verify(db, config)
+	if err != nil {
+		return nil, err
+	}
+	out, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	fmt.Println(string(out))
+	fmt.Fprintf(os.Stderr, "verified blocks %d-%d of shard %d, %d issues found, %d repaired\n",
+		summary.From, summary.To, shardID, len(summary.Issues), summary.Repaired)
+	return summary, nil
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/internal/shardchain"
+)
+
+func TestConvertDetectDBEngine(t *testing.T) {
+	if !rawdb.PebbleEnabled {
+		t.Skip("pebble is not supported on this platform")
+	}
+	root := t.TempDir()
+	dir := shardchain.ChainDBDir(root, 0)
+	db, err := rawdb.NewLevelDBDatabase(dir, LEVELDB_CACHE_SIZE, LEVELDB_HANDLES, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.Put([]byte("key"), []byte("value")); err != nil {
+		t.Fatal(err)
+	}
+	db.Close()
+
+	// the leftover of an interrupted conversion is not a shard database
+	tmp, err := rawdb.NewPebbleDBDatabase(dir+".pebble", LEVELDB_CACHE_SIZE, LEVELDB_HANDLES, "", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmp.Close()
+	if engine, err := shardchain.DetectDBEngine(root); err != nil || engine != rawdb.DBLeveldb {
+		t.Fatalf("detected %q before conversion, %v", engine, err)
+	}
+
+	// nor is the LevelDB database kept by the conversion
+	copied, err := convertChainDB(dir, true)
+	if err != nil || copied != 1 {
+		t.Fatalf("converted %d entries, %v", copied, err)
+	}
+	if rawdb.PreexistingDatabase(dir+".leveldb") != rawdb.DBLeveldb {
+		t.Fatal("LevelDB database not kept")
+	}
+	if engine, err := shardchain.DetectDBEngine(root); err != nil || engine != rawdb.DBPebble {
+		t.Fatalf("detected %q after conversion, %v", engine, err)
+	}
+
+	db, err = openDB(dir, rawdb.DBPebble)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	if value, err := db.Get([]byte("key")); err != nil || string(value) != "value" {
+		t.Errorf("unexpected value %q, %v", value, err)
+	}
+}
@@ -9,7 +9,7 @@ import (
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 )
 
-const tomlConfigVersion = "2.6.1"
+const tomlConfigVersion = "2.6.7"
 
 const (
 	defNetworkType = nodeconfig.Mainnet
@@ -25,6 +25,7 @@ var defaultConfig = harmonyconfig.HarmonyConfig{
 		IsBeaconArchival: false,
 		IsOffline:        false,
 		DataDir:          "./",
+		DBEngine:         "",
 		TraceEnable:      false,
 	},
 	Network: getDefaultNetworkConfig(defNetworkType),
@@ -58,15 +59,17 @@ var defaultConfig = harmonyconfig.HarmonyConfig{
 		AuthPort: nodeconfig.DefaultAuthWSPort,
 	},
 	RPCOpt: harmonyconfig.RpcOptConfig{
-		DebugEnabled:       false,
-		EthRPCsEnabled:     true,
-		StakingRPCsEnabled: true,
-		LegacyRPCsEnabled:  true,
-		RpcFilterFile:      "./.hmy/rpc_filter.txt",
-		RateLimterEnabled:  true,
-		RequestsPerSecond:  nodeconfig.DefaultRPCRateLimit,
-		EvmCallTimeout:     nodeconfig.DefaultEvmCallTimeout,
-		PreimagesEnabled:   false,
+		DebugEnabled:         false,
+		EthRPCsEnabled:       true,
+		StakingRPCsEnabled:   true,
+		LegacyRPCsEnabled:    true,
+		RpcFilterFile:        "./.hmy/rpc_filter.txt",
+		RateLimterEnabled:    true,
+		RequestsPerSecond:    nodeconfig.DefaultRPCRateLimit,
+		EvmCallTimeout:       nodeconfig.DefaultEvmCallTimeout,
+		PreimagesEnabled:     false,
+		BatchItemLimit:       nodeconfig.DefaultRPCBatchItemLimit,
+		BatchResponseMaxSize: nodeconfig.DefaultRPCBatchResponseMaxSize,
 	},
 	BLSKeys: harmonyconfig.BlsConfig{
 		KeyDir:   "./.hmy/blskeys",
@@ -132,7 +135,9 @@ var defaultConfig = harmonyconfig.HarmonyConfig{
 		LowUsageThreshold: hmy.DefaultGPOConfig.LowUsageThreshold,
 		BlockGasLimit:     hmy.DefaultGPOConfig.BlockGasLimit,
 	},
-	Cache: getDefaultCacheConfig(defNetworkType),
+	Cache:   getDefaultCacheConfig(defNetworkType),
+	Health:  defaultHealthConfig,
+	Ancient: defaultAncientConfig,
 }
 
 var defaultSysConfig = harmonyconfig.SysConfig{
@@ -273,6 +278,21 @@ var (
 	}
 )
 
+var defaultHealthConfig = harmonyconfig.HealthConfig{
+	Enabled:         false,
+	MaxHeadAge:      nodeconfig.DefaultHealthMaxHeadAge,
+	MaxBlocksBehind: 10,
+	MinPeers:        3,
+	Consensus:       true,
+	DBWritable:      true,
+}
+
+var defaultAncientConfig = harmonyconfig.AncientConfig{
+	Enabled:   false,
+	Dir:       "",
+	Threshold: 90000,
+}
+
 var defaultCacheConfig = harmonyconfig.CacheConfig{
 	Disabled:        false,
 	TrieNodeLimit:   256,
@@ -53,7 +53,7 @@ var dumpDBCmd = &cobra.Command{
 		}
 		snapdbInfo.NetworkType = networkType
 		fmt.Println(srcDBDir, destDBDir, batchLimitMB)
-		dumpMain(srcDBDir, destDBDir, batchLimitMB*MB)
+		dumpMain(srcDBDir, destDBDir, cli.GetStringFlagValue(cmd, dbEngineFlag), batchLimitMB*MB)
 		os.Exit(0)
 	},
 }
@@ -77,7 +77,7 @@ func getShardSchedule(networkType nodeconfig.NetworkType) shardingconfig.Schedul
 }
 
 func registerDumpDBFlags() error {
-	return cli.RegisterFlags(dumpDBCmd, []cli.Flag{batchFlag, networkTypeFlag})
+	return cli.RegisterFlags(dumpDBCmd, []cli.Flag{batchFlag, networkTypeFlag, dbEngineFlag})
 }
 
 type KakashiDB struct {
@@ -365,14 +365,14 @@ func (db *KakashiDB) stateDataDump(block *types.Block) {
 	db.flush()
 }
 
-func dumpMain(srcDBDir, destDBDir string, batchLimit int) {
+func dumpMain(srcDBDir, destDBDir, destEngine string, batchLimit int) {
 	fmt.Println("===dumpMain===")
-	srcDB, err := rawdb.NewLevelDBDatabase(srcDBDir, LEVELDB_CACHE_SIZE, LEVELDB_HANDLES, "", false)
+	srcDB, err := openDB(srcDBDir, "")
 	if err != nil {
 		fmt.Println("open src db error:", err)
 		os.Exit(-1)
 	}
-	destDB, err := rawdb.NewLevelDBDatabase(destDBDir, LEVELDB_CACHE_SIZE, LEVELDB_HANDLES, "", false)
+	destDB, err := openDB(destDBDir, destEngine)
 	if err != nil {
 		fmt.Println("open dest db error:", err)
 		os.Exit(-1)
@@ -24,6 +24,7 @@ var (
 		isBeaconArchiveFlag,
 		isOfflineFlag,
 		dataDirFlag,
+		dbEngineFlag,
 
 		legacyNodeTypeFlag,
 		legacyIsStakingFlag,
@@ -74,10 +75,12 @@ var (
 		httpIPFlag,
 		httpPortFlag,
 		httpAuthPortFlag,
+		httpAuthJWTSecretFlag,
 		httpRosettaPortFlag,
 		httpReadTimeoutFlag,
 		httpWriteTimeoutFlag,
 		httpIdleTimeoutFlag,
+		httpGraphQLEnabledFlag,
 	}
 
 	wsFlags = []cli.Flag{
@@ -85,6 +88,7 @@ var (
 		wsIPFlag,
 		wsPortFlag,
 		wsAuthPortFlag,
+		wsAuthJWTSecretFlag,
 	}
 
 	rpcOptFlags = []cli.Flag{
@@ -97,6 +101,10 @@ var (
 		rpcRateLimiterEnabledFlag,
 		rpcRateLimitFlag,
 		rpcEvmCallTimeoutFlag,
+		rpcClientRateLimitFlag,
+		rpcAPIKeyRateLimitFlag,
+		rpcBatchItemLimitFlag,
+		rpcBatchResponseMaxSizeFlag,
 	}
 
 	blsFlags = append(newBLSFlags, legacyBLSFlags...)
@@ -277,6 +285,21 @@ var (
 		cacheSnapshotWait,
 	}
 
+	healthFlags = []cli.Flag{
+		healthEnabledFlag,
+		healthMaxHeadAgeFlag,
+		healthMaxBlocksBehindFlag,
+		healthMinPeersFlag,
+		healthConsensusFlag,
+		healthDBWritableFlag,
+	}
+
+	ancientFlags = []cli.Flag{
+		ancientEnabledFlag,
+		ancientDirFlag,
+		ancientThresholdFlag,
+	}
+
 	metricsFlags = []cli.Flag{
 		metricsETHFlag,
 		metricsExpensiveETHFlag,
@@ -325,6 +348,11 @@ var (
 		Usage:    "directory of chain database",
 		DefValue: defaultConfig.General.DataDir,
 	}
+	dbEngineFlag = cli.StringFlag{
+		Name:     "db.engine",
+		Usage:    "engine of the chain databases (leveldb, pebble), the engine of the existing databases if empty",
+		DefValue: defaultConfig.General.DBEngine,
+	}
 	legacyNodeTypeFlag = cli.StringFlag{
 		Name:       "node_type",
 		Usage:      "run node type (validator, explorer)",
@@ -389,6 +417,8 @@ func getRootFlags() []cli.Flag {
 	flags = append(flags, syncFlags...)
 	flags = append(flags, shardDataFlags...)
 	flags = append(flags, gpoFlags...)
+	flags = append(flags, healthFlags...)
+	flags = append(flags, ancientFlags...)
 	flags = append(flags, metricsFlags...)
 
 	return flags
@@ -429,6 +459,10 @@ func applyGeneralFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig)
 		config.General.DataDir = cli.GetStringFlagValue(cmd, legacyDataDirFlag)
 	}
 
+	if cli.IsFlagChanged(cmd, dbEngineFlag) {
+		config.General.DBEngine = cli.GetStringFlagValue(cmd, dbEngineFlag)
+	}
+
 	if cli.IsFlagChanged(cmd, isOfflineFlag) {
 		config.General.IsOffline = cli.GetBoolFlagValue(cmd, isOfflineFlag)
 	}
@@ -712,6 +746,11 @@ var (
 		Usage:    "rpc port to listen for auth HTTP requests",
 		DefValue: defaultConfig.HTTP.AuthPort,
 	}
+	httpAuthJWTSecretFlag = cli.StringFlag{
+		Name:     "http.auth-jwtsecret",
+		Usage:    "path to the hex encoded JWT secret required by the auth HTTP port, generated if missing",
+		DefValue: defaultConfig.HTTP.AuthJWTSecretFile,
+	}
 	httpRosettaEnabledFlag = cli.BoolFlag{
 		Name:     "http.rosetta",
 		Usage:    "enable HTTP / Rosetta requests",
@@ -737,6 +776,11 @@ var (
 		Usage:    "maximum amount of time to wait for the next request when keep-alives are enabled",
 		DefValue: defaultConfig.HTTP.IdleTimeout,
 	}
+	httpGraphQLEnabledFlag = cli.BoolFlag{
+		Name:     "http.graphql",
+		Usage:    "enable GraphQL queries at /graphql of the HTTP RPC port",
+		DefValue: defaultConfig.HTTP.GraphQLEnabled,
+	}
 )
 
 func applyHTTPFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
@@ -757,6 +801,10 @@ func applyHTTPFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 		isRPCSpecified = true
 	}
 
+	if cli.IsFlagChanged(cmd, httpAuthJWTSecretFlag) {
+		config.HTTP.AuthJWTSecretFile = cli.GetStringFlagValue(cmd, httpAuthJWTSecretFlag)
+	}
+
 	if cli.IsFlagChanged(cmd, httpRosettaPortFlag) {
 		config.HTTP.RosettaPort = cli.GetIntFlagValue(cmd, httpRosettaPortFlag)
 		isRosettaSpecified = true
@@ -783,6 +831,9 @@ func applyHTTPFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	if cli.IsFlagChanged(cmd, httpIdleTimeoutFlag) {
 		config.HTTP.IdleTimeout = cli.GetStringFlagValue(cmd, httpIdleTimeoutFlag)
 	}
+	if cli.IsFlagChanged(cmd, httpGraphQLEnabledFlag) {
+		config.HTTP.GraphQLEnabled = cli.GetBoolFlagValue(cmd, httpGraphQLEnabledFlag)
+	}
 
 }
 
@@ -808,6 +859,11 @@ var (
 		Usage:    "port for websocket auth endpoint",
 		DefValue: defaultConfig.WS.AuthPort,
 	}
+	wsAuthJWTSecretFlag = cli.StringFlag{
+		Name:     "ws.auth-jwtsecret",
+		Usage:    "path to the hex encoded JWT secret required by the websocket auth endpoint, generated if missing",
+		DefValue: defaultConfig.WS.AuthJWTSecretFile,
+	}
 )
 
 func applyWSFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
@@ -823,6 +879,9 @@ func applyWSFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	if cli.IsFlagChanged(cmd, wsAuthPortFlag) {
 		config.WS.AuthPort = cli.GetIntFlagValue(cmd, wsAuthPortFlag)
 	}
+	if cli.IsFlagChanged(cmd, wsAuthJWTSecretFlag) {
+		config.WS.AuthJWTSecretFile = cli.GetStringFlagValue(cmd, wsAuthJWTSecretFlag)
+	}
 }
 
 // rpc opt flags
@@ -880,6 +939,30 @@ var (
 		DefValue: defaultConfig.RPCOpt.RequestsPerSecond,
 	}
 
+	rpcClientRateLimitFlag = cli.IntFlag{
+		Name:     "rpc.ratelimit.client",
+		Usage:    "the number of requests per second of each client IP for RPCs, 0 to disable",
+		DefValue: defaultConfig.RPCOpt.ClientRequestsPerSecond,
+	}
+
+	rpcAPIKeyRateLimitFlag = cli.IntFlag{
+		Name:     "rpc.ratelimit.apikey",
+		Usage:    "the number of requests per second of each API key for RPCs, 0 to disable",
+		DefValue: defaultConfig.RPCOpt.APIKeyRequestsPerSecond,
+	}
+
+	rpcBatchItemLimitFlag = cli.IntFlag{
+		Name:     "rpc.batch.items",
+		Usage:    "maximum number of requests in a RPC batch, 0 for no limit",
+		DefValue: defaultConfig.RPCOpt.BatchItemLimit,
+	}
+
+	rpcBatchResponseMaxSizeFlag = cli.IntFlag{
+		Name:     "rpc.batch.response-size",
+		Usage:    "maximum size in bytes of a RPC batch response, 0 for no limit",
+		DefValue: defaultConfig.RPCOpt.BatchResponseMaxSize,
+	}
+
 	rpcEvmCallTimeoutFlag = cli.StringFlag{
 		Name:     "rpc.evm-call-timeout",
 		Usage:    "timeout for evm execution (eth_call); 0 means infinite timeout",
@@ -915,6 +998,18 @@ func applyRPCOptFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	if cli.IsFlagChanged(cmd, rpcEvmCallTimeoutFlag) {
 		config.RPCOpt.EvmCallTimeout = cli.GetStringFlagValue(cmd, rpcEvmCallTimeoutFlag)
 	}
+	if cli.IsFlagChanged(cmd, rpcClientRateLimitFlag) {
+		config.RPCOpt.ClientRequestsPerSecond = cli.GetIntFlagValue(cmd, rpcClientRateLimitFlag)
+	}
+	if cli.IsFlagChanged(cmd, rpcAPIKeyRateLimitFlag) {
+		config.RPCOpt.APIKeyRequestsPerSecond = cli.GetIntFlagValue(cmd, rpcAPIKeyRateLimitFlag)
+	}
+	if cli.IsFlagChanged(cmd, rpcBatchItemLimitFlag) {
+		config.RPCOpt.BatchItemLimit = cli.GetIntFlagValue(cmd, rpcBatchItemLimitFlag)
+	}
+	if cli.IsFlagChanged(cmd, rpcBatchResponseMaxSizeFlag) {
+		config.RPCOpt.BatchResponseMaxSize = cli.GetIntFlagValue(cmd, rpcBatchResponseMaxSizeFlag)
+	}
 }
 
 // bls flags
@@ -2178,3 +2273,89 @@ func applyCacheFlags(cmd *cobra.Command, cfg *harmonyconfig.HarmonyConfig) {
 		cfg.Cache.SnapshotWait = cli.GetBoolFlagValue(cmd, cacheSnapshotWait)
 	}
 }
+
+// health flags
+var (
+	healthEnabledFlag = cli.BoolFlag{
+		Name:     "health",
+		Usage:    "enable the /health and /ready checks at the HTTP RPC port",
+		DefValue: defaultHealthConfig.Enabled,
+	}
+	healthMaxHeadAgeFlag = cli.StringFlag{
+		Name:     "health.max-head-age",
+		Usage:    "max age of the head block for the node to be ready, 0s to disable",
+		DefValue: defaultHealthConfig.MaxHeadAge,
+	}
+	healthMaxBlocksBehindFlag = cli.Uint64Flag{
+		Name:     "health.max-blocks-behind",
+		Usage:    "max blocks behind the peers of the shard and beacon chains for the node to be ready",
+		DefValue: defaultHealthConfig.MaxBlocksBehind,
+	}
+	healthMinPeersFlag = cli.IntFlag{
+		Name:     "health.min-peers",
+		Usage:    "min connected peers for the node to be ready, 0 to disable",
+		DefValue: defaultHealthConfig.MinPeers,
+	}
+	healthConsensusFlag = cli.BoolFlag{
+		Name:     "health.consensus",
+		Usage:    "require validators to have signed the latest block to be ready",
+		DefValue: defaultHealthConfig.Consensus,
+	}
+	healthDBWritableFlag = cli.BoolFlag{
+		Name:     "health.db-writable",
+		Usage:    "require the chain database to be writable to be healthy",
+		DefValue: defaultHealthConfig.DBWritable,
+	}
+)
+
+func applyHealthFlags(cmd *cobra.Command, cfg *harmonyconfig.HarmonyConfig) {
+	if cli.IsFlagChanged(cmd, healthEnabledFlag) {
+		cfg.Health.Enabled = cli.GetBoolFlagValue(cmd, healthEnabledFlag)
+	}
+	if cli.IsFlagChanged(cmd, healthMaxHeadAgeFlag) {
+		cfg.Health.MaxHeadAge = cli.GetStringFlagValue(cmd, healthMaxHeadAgeFlag)
+	}
+	if cli.IsFlagChanged(cmd, healthMaxBlocksBehindFlag) {
+		cfg.Health.MaxBlocksBehind = cli.GetUint64FlagValue(cmd, healthMaxBlocksBehindFlag)
+	}
+	if cli.IsFlagChanged(cmd, healthMinPeersFlag) {
+		cfg.Health.MinPeers = cli.GetIntFlagValue(cmd, healthMinPeersFlag)
+	}
+	if cli.IsFlagChanged(cmd, healthConsensusFlag) {
+		cfg.Health.Consensus = cli.GetBoolFlagValue(cmd, healthConsensusFlag)
+	}
+	if cli.IsFlagChanged(cmd, healthDBWritableFlag) {
+		cfg.Health.DBWritable = cli.GetBoolFlagValue(cmd, healthDBWritableFlag)
+	}
+}
+
+// ancient flags
+var (
+	ancientEnabledFlag = cli.BoolFlag{
+		Name:     "ancient",
+		Usage:    "move old blocks, receipts and commit signatures from leveldb into an append-only ancient store",
+		DefValue: defaultAncientConfig.Enabled,
+	}
+	ancientDirFlag = cli.StringFlag{
+		Name:     "ancient.dir",
+		Usage:    "root directory of the shard ancient stores (default = inside the shard databases)",
+		DefValue: defaultAncientConfig.Dir,
+	}
+	ancientThresholdFlag = cli.Uint64Flag{
+		Name:     "ancient.threshold",
+		Usage:    "number of recent blocks kept in leveldb before being moved into the ancient store",
+		DefValue: defaultAncientConfig.Threshold,
+	}
+)
+
+func applyAncientFlags(cmd *cobra.Command, cfg *harmonyconfig.HarmonyConfig) {
+	if cli.IsFlagChanged(cmd, ancientEnabledFlag) {
+		cfg.Ancient.Enabled = cli.GetBoolFlagValue(cmd, ancientEnabledFlag)
+	}
+	if cli.IsFlagChanged(cmd, ancientDirFlag) {
+		cfg.Ancient.Dir = cli.GetStringFlagValue(cmd, ancientDirFlag)
+	}
+	if cli.IsFlagChanged(cmd, ancientThresholdFlag) {
+		cfg.Ancient.Threshold = cli.GetUint64FlagValue(cmd, ancientThresholdFlag)
+	}
+}
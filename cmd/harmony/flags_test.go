@@ -83,15 +83,17 @@ func TestHarmonyFlags(t *testing.T) {
 					IdleTimeout:    defaultConfig.HTTP.IdleTimeout,
 				},
 				RPCOpt: harmonyconfig.RpcOptConfig{
-					DebugEnabled:       false,
-					EthRPCsEnabled:     true,
-					StakingRPCsEnabled: true,
-					LegacyRPCsEnabled:  true,
-					RpcFilterFile:      "./.hmy/rpc_filter.txt",
-					RateLimterEnabled:  true,
-					RequestsPerSecond:  1000,
-					EvmCallTimeout:     defaultConfig.RPCOpt.EvmCallTimeout,
-					PreimagesEnabled:   defaultConfig.RPCOpt.PreimagesEnabled,
+					DebugEnabled:         false,
+					EthRPCsEnabled:       true,
+					StakingRPCsEnabled:   true,
+					LegacyRPCsEnabled:    true,
+					RpcFilterFile:        "./.hmy/rpc_filter.txt",
+					RateLimterEnabled:    true,
+					RequestsPerSecond:    1000,
+					EvmCallTimeout:       defaultConfig.RPCOpt.EvmCallTimeout,
+					PreimagesEnabled:     defaultConfig.RPCOpt.PreimagesEnabled,
+					BatchItemLimit:       defaultConfig.RPCOpt.BatchItemLimit,
+					BatchResponseMaxSize: defaultConfig.RPCOpt.BatchResponseMaxSize,
 				},
 				WS: harmonyconfig.WsConfig{
 					Enabled:  true,
@@ -192,6 +194,8 @@ func TestHarmonyFlags(t *testing.T) {
 					Preimages:       defaultConfig.Cache.Preimages,
 					SnapshotNoBuild: defaultConfig.Cache.SnapshotNoBuild,
 				},
+				Health:  defaultHealthConfig,
+				Ancient: defaultAncientConfig,
 			},
 		},
 	}
@@ -276,6 +280,17 @@ func TestGeneralFlags(t *testing.T) {
 				DataDir:    "./",
 			},
 		},
+		{
+			args: []string{"--db.engine", "pebble"},
+			expConfig: harmonyconfig.GeneralConfig{
+				NodeType:   "validator",
+				NoStaking:  false,
+				ShardID:    -1,
+				IsArchival: false,
+				DataDir:    "./",
+				DBEngine:   "pebble",
+			},
+		},
 	}
 	for i, test := range tests {
 		ts := newFlagTestSuite(t, generalFlags, applyGeneralFlags)
@@ -577,6 +592,36 @@ func TestRPCFlags(t *testing.T) {
 				IdleTimeout:    defaultConfig.HTTP.IdleTimeout,
 			},
 		},
+		{
+			args: []string{"--http.auth-port", "9001", "--http.auth-jwtsecret", "./.hmy/jwt.hex"},
+			expConfig: harmonyconfig.HttpConfig{
+				Enabled:           true,
+				RosettaEnabled:    false,
+				IP:                defaultConfig.HTTP.IP,
+				Port:              defaultConfig.HTTP.Port,
+				AuthPort:          9001,
+				AuthJWTSecretFile: "./.hmy/jwt.hex",
+				RosettaPort:       defaultConfig.HTTP.RosettaPort,
+				ReadTimeout:       defaultConfig.HTTP.ReadTimeout,
+				WriteTimeout:      defaultConfig.HTTP.WriteTimeout,
+				IdleTimeout:       defaultConfig.HTTP.IdleTimeout,
+			},
+		},
+		{
+			args: []string{"--http.graphql"},
+			expConfig: harmonyconfig.HttpConfig{
+				Enabled:        true,
+				RosettaEnabled: false,
+				IP:             defaultConfig.HTTP.IP,
+				Port:           defaultConfig.HTTP.Port,
+				AuthPort:       defaultConfig.HTTP.AuthPort,
+				RosettaPort:    defaultConfig.HTTP.RosettaPort,
+				ReadTimeout:    defaultConfig.HTTP.ReadTimeout,
+				WriteTimeout:   defaultConfig.HTTP.WriteTimeout,
+				IdleTimeout:    defaultConfig.HTTP.IdleTimeout,
+				GraphQLEnabled: true,
+			},
+		},
 		{
 			args: []string{"--http.ip", "8.8.8.8", "--http.port", "9001", "--http.rosetta.port", "10001"},
 			expConfig: harmonyconfig.HttpConfig{
@@ -695,6 +740,16 @@ func TestWSFlags(t *testing.T) {
 				AuthPort: 9001,
 			},
 		},
+		{
+			args: []string{"--ws.auth-jwtsecret", "./.hmy/jwt.hex"},
+			expConfig: harmonyconfig.WsConfig{
+				Enabled:           defaultConfig.WS.Enabled,
+				IP:                defaultConfig.WS.IP,
+				Port:              defaultConfig.WS.Port,
+				AuthPort:          defaultConfig.WS.AuthPort,
+				AuthJWTSecretFile: "./.hmy/jwt.hex",
+			},
+		},
 		{
 			args: []string{"--ip", "8.8.8.8", "--port", "9001", "--public_rpc"},
 			expConfig: harmonyconfig.WsConfig{
@@ -737,150 +792,189 @@ func TestRPCOptFlags(t *testing.T) {
 		{
 			args: []string{"--rpc.debug"},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       true,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimterEnabled:  true,
-				RequestsPerSecond:  1000,
-				EvmCallTimeout:     defaultConfig.RPCOpt.EvmCallTimeout,
-				PreimagesEnabled:   defaultConfig.RPCOpt.PreimagesEnabled,
+				DebugEnabled:         true,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    1000,
+				EvmCallTimeout:       defaultConfig.RPCOpt.EvmCallTimeout,
+				PreimagesEnabled:     defaultConfig.RPCOpt.PreimagesEnabled,
+				BatchItemLimit:       defaultConfig.RPCOpt.BatchItemLimit,
+				BatchResponseMaxSize: defaultConfig.RPCOpt.BatchResponseMaxSize,
 			},
 		},
 
 		{
 			args: []string{"--rpc.eth=false"},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       false,
-				EthRPCsEnabled:     false,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimterEnabled:  true,
-				RequestsPerSecond:  1000,
-				EvmCallTimeout:     defaultConfig.RPCOpt.EvmCallTimeout,
-				PreimagesEnabled:   defaultConfig.RPCOpt.PreimagesEnabled,
+				DebugEnabled:         false,
+				EthRPCsEnabled:       false,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    1000,
+				EvmCallTimeout:       defaultConfig.RPCOpt.EvmCallTimeout,
+				PreimagesEnabled:     defaultConfig.RPCOpt.PreimagesEnabled,
+				BatchItemLimit:       defaultConfig.RPCOpt.BatchItemLimit,
+				BatchResponseMaxSize: defaultConfig.RPCOpt.BatchResponseMaxSize,
 			},
 		},
 
 		{
 			args: []string{"--rpc.staking=false"},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       false,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: false,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimterEnabled:  true,
-				RequestsPerSecond:  1000,
-				EvmCallTimeout:     defaultConfig.RPCOpt.EvmCallTimeout,
-				PreimagesEnabled:   defaultConfig.RPCOpt.PreimagesEnabled,
+				DebugEnabled:         false,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   false,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    1000,
+				EvmCallTimeout:       defaultConfig.RPCOpt.EvmCallTimeout,
+				PreimagesEnabled:     defaultConfig.RPCOpt.PreimagesEnabled,
+				BatchItemLimit:       defaultConfig.RPCOpt.BatchItemLimit,
+				BatchResponseMaxSize: defaultConfig.RPCOpt.BatchResponseMaxSize,
 			},
 		},
 
 		{
 			args: []string{"--rpc.legacy=false"},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       false,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  false,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimterEnabled:  true,
-				RequestsPerSecond:  1000,
-				EvmCallTimeout:     defaultConfig.RPCOpt.EvmCallTimeout,
-				PreimagesEnabled:   defaultConfig.RPCOpt.PreimagesEnabled,
+				DebugEnabled:         false,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    false,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    1000,
+				EvmCallTimeout:       defaultConfig.RPCOpt.EvmCallTimeout,
+				PreimagesEnabled:     defaultConfig.RPCOpt.PreimagesEnabled,
+				BatchItemLimit:       defaultConfig.RPCOpt.BatchItemLimit,
+				BatchResponseMaxSize: defaultConfig.RPCOpt.BatchResponseMaxSize,
 			},
 		},
 
 		{
 			args: []string{"--rpc.filterspath=./rmf.toml"},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       false,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./rmf.toml",
-				RateLimterEnabled:  true,
-				RequestsPerSecond:  1000,
-				EvmCallTimeout:     defaultConfig.RPCOpt.EvmCallTimeout,
-				PreimagesEnabled:   defaultConfig.RPCOpt.PreimagesEnabled,
+				DebugEnabled:         false,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./rmf.toml",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    1000,
+				EvmCallTimeout:       defaultConfig.RPCOpt.EvmCallTimeout,
+				PreimagesEnabled:     defaultConfig.RPCOpt.PreimagesEnabled,
+				BatchItemLimit:       defaultConfig.RPCOpt.BatchItemLimit,
+				BatchResponseMaxSize: defaultConfig.RPCOpt.BatchResponseMaxSize,
 			},
 		},
 
 		{
 			args: []string{},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       false,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimterEnabled:  true,
-				RequestsPerSecond:  1000,
-				EvmCallTimeout:     defaultConfig.RPCOpt.EvmCallTimeout,
-				PreimagesEnabled:   defaultConfig.RPCOpt.PreimagesEnabled,
+				DebugEnabled:         false,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    1000,
+				EvmCallTimeout:       defaultConfig.RPCOpt.EvmCallTimeout,
+				PreimagesEnabled:     defaultConfig.RPCOpt.PreimagesEnabled,
+				BatchItemLimit:       defaultConfig.RPCOpt.BatchItemLimit,
+				BatchResponseMaxSize: defaultConfig.RPCOpt.BatchResponseMaxSize,
 			},
 		},
 
 		{
 			args: []string{"--rpc.ratelimiter", "--rpc.ratelimit", "2000"},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       false,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimterEnabled:  true,
-				RequestsPerSecond:  2000,
-				EvmCallTimeout:     defaultConfig.RPCOpt.EvmCallTimeout,
-				PreimagesEnabled:   defaultConfig.RPCOpt.PreimagesEnabled,
+				DebugEnabled:         false,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    2000,
+				EvmCallTimeout:       defaultConfig.RPCOpt.EvmCallTimeout,
+				PreimagesEnabled:     defaultConfig.RPCOpt.PreimagesEnabled,
+				BatchItemLimit:       defaultConfig.RPCOpt.BatchItemLimit,
+				BatchResponseMaxSize: defaultConfig.RPCOpt.BatchResponseMaxSize,
 			},
 		},
 
 		{
 			args: []string{"--rpc.ratelimiter=false", "--rpc.ratelimit", "2000"},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       false,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimterEnabled:  false,
-				RequestsPerSecond:  2000,
-				EvmCallTimeout:     defaultConfig.RPCOpt.EvmCallTimeout,
-				PreimagesEnabled:   defaultConfig.RPCOpt.PreimagesEnabled,
+				DebugEnabled:         false,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    false,
+				RequestsPerSecond:    2000,
+				EvmCallTimeout:       defaultConfig.RPCOpt.EvmCallTimeout,
+				PreimagesEnabled:     defaultConfig.RPCOpt.PreimagesEnabled,
+				BatchItemLimit:       defaultConfig.RPCOpt.BatchItemLimit,
+				BatchResponseMaxSize: defaultConfig.RPCOpt.BatchResponseMaxSize,
 			},
 		},
 
 		{
 			args: []string{"--rpc.evm-call-timeout", "10s"},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       false,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimterEnabled:  true,
-				RequestsPerSecond:  1000,
-				EvmCallTimeout:     "10s",
-				PreimagesEnabled:   defaultConfig.RPCOpt.PreimagesEnabled,
+				DebugEnabled:         false,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    1000,
+				EvmCallTimeout:       "10s",
+				PreimagesEnabled:     defaultConfig.RPCOpt.PreimagesEnabled,
+				BatchItemLimit:       defaultConfig.RPCOpt.BatchItemLimit,
+				BatchResponseMaxSize: defaultConfig.RPCOpt.BatchResponseMaxSize,
 			},
 		},
 
 		{
 			args: []string{"--rpc.preimages"},
 			expConfig: harmonyconfig.RpcOptConfig{
-				DebugEnabled:       false,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimterEnabled:  true,
-				RequestsPerSecond:  1000,
-				EvmCallTimeout:     defaultConfig.RPCOpt.EvmCallTimeout,
-				PreimagesEnabled:   true,
+				DebugEnabled:         false,
+				EthRPCsEnabled:       true,
+				StakingRPCsEnabled:   true,
+				LegacyRPCsEnabled:    true,
+				RpcFilterFile:        "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:    true,
+				RequestsPerSecond:    1000,
+				EvmCallTimeout:       defaultConfig.RPCOpt.EvmCallTimeout,
+				PreimagesEnabled:     true,
+				BatchItemLimit:       defaultConfig.RPCOpt.BatchItemLimit,
+				BatchResponseMaxSize: defaultConfig.RPCOpt.BatchResponseMaxSize,
+			},
+		},
+
+		{
+			args: []string{"--rpc.ratelimit.client", "10", "--rpc.ratelimit.apikey", "100", "--rpc.batch.items", "50", "--rpc.batch.response-size", "1000000"},
+			expConfig: harmonyconfig.RpcOptConfig{
+				DebugEnabled:            false,
+				EthRPCsEnabled:          true,
+				StakingRPCsEnabled:      true,
+				LegacyRPCsEnabled:       true,
+				RpcFilterFile:           "./.hmy/rpc_filter.txt",
+				RateLimterEnabled:       true,
+				RequestsPerSecond:       1000,
+				EvmCallTimeout:          defaultConfig.RPCOpt.EvmCallTimeout,
+				PreimagesEnabled:        defaultConfig.RPCOpt.PreimagesEnabled,
+				ClientRequestsPerSecond: 10,
+				APIKeyRequestsPerSecond: 100,
+				BatchItemLimit:          50,
+				BatchResponseMaxSize:    1000000,
 			},
 		},
 	}
@@ -1479,6 +1573,84 @@ func TestCacheFlags(t *testing.T) {
 	}
 }
 
+func TestHealthFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig harmonyconfig.HealthConfig
+		expErr    error
+	}{
+		{
+			args:      []string{},
+			expConfig: defaultHealthConfig,
+		},
+		{
+			args: []string{"--health", "--health.max-head-age", "30s", "--health.max-blocks-behind", "5",
+				"--health.min-peers", "0", "--health.consensus=false", "--health.db-writable=false"},
+			expConfig: harmonyconfig.HealthConfig{
+				Enabled:         true,
+				MaxHeadAge:      "30s",
+				MaxBlocksBehind: 5,
+				MinPeers:        0,
+				Consensus:       false,
+				DBWritable:      false,
+			},
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, healthFlags, applyHealthFlags)
+		hc, err := ts.run(test.args)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Fatalf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+
+		if !reflect.DeepEqual(hc.Health, test.expConfig) {
+			t.Errorf("Test %v:\n\t%+v\n\t%+v", i, hc.Health, test.expConfig)
+		}
+		ts.tearDown()
+	}
+}
+
+func TestAncientFlags(t *testing.T) {
+	tests := []struct {
+		args      []string
+		expConfig harmonyconfig.AncientConfig
+		expErr    error
+	}{
+		{
+			args:      []string{},
+			expConfig: defaultAncientConfig,
+		},
+		{
+			args: []string{"--ancient", "--ancient.dir", "/mnt/ancient", "--ancient.threshold", "1000"},
+			expConfig: harmonyconfig.AncientConfig{
+				Enabled:   true,
+				Dir:       "/mnt/ancient",
+				Threshold: 1000,
+			},
+		},
+	}
+	for i, test := range tests {
+		ts := newFlagTestSuite(t, ancientFlags, applyAncientFlags)
+		hc, err := ts.run(test.args)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Fatalf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+
+		if !reflect.DeepEqual(hc.Ancient, test.expConfig) {
+			t.Errorf("Test %v:\n\t%+v\n\t%+v", i, hc.Ancient, test.expConfig)
+		}
+		ts.tearDown()
+	}
+}
+
 func TestDevnetFlags(t *testing.T) {
 	tests := []struct {
 		args      []string
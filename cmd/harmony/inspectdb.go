@@ -47,7 +47,7 @@ func registerInspectionFlags() error {
 
 func inspectDB(srcDBDir, prefix, startKey string) {
 	fmt.Println("===inspectDB===")
-	srcDB, err := rawdb.NewLevelDBDatabase(srcDBDir, LEVELDB_CACHE_SIZE, LEVELDB_HANDLES, "", false)
+	srcDB, err := openDB(srcDBDir, "")
 	if err != nil {
 		fmt.Println("open src db error:", err)
 		os.Exit(-1)
@@ -24,11 +24,13 @@ import (
 	"github.com/harmony-one/harmony/api/service/prometheus"
 	"github.com/harmony-one/harmony/api/service/stagedstreamsync"
 	"github.com/harmony-one/harmony/api/service/synchronize"
+	"github.com/harmony-one/harmony/api/service/webhookevents"
 	"github.com/harmony-one/harmony/common/fdlimit"
 	"github.com/harmony-one/harmony/common/ntp"
 	"github.com/harmony-one/harmony/consensus"
 	"github.com/harmony-one/harmony/consensus/quorum"
 	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/hmy/downloader"
 	"github.com/harmony-one/harmony/internal/chain"
 	"github.com/harmony-one/harmony/internal/cli"
@@ -106,6 +108,13 @@ func init() {
 	rootCmd.AddCommand(dumpConfigLegacyCmd)
 	rootCmd.AddCommand(dumpDBCmd)
 	rootCmd.AddCommand(inspectDBCmd)
+	rootCmd.AddCommand(abigenCmd)
+	rootCmd.AddCommand(exportCmd)
+	rootCmd.AddCommand(importCmd)
+	dbCmd.AddCommand(pruneStateCmd)
+	dbCmd.AddCommand(convertCmd)
+	dbCmd.AddCommand(verifyCmd)
+	rootCmd.AddCommand(dbCmd)
 
 	if err := registerRootCmdFlags(); err != nil {
 		os.Exit(2)
@@ -119,6 +128,15 @@ func init() {
 	if err := registerInspectionFlags(); err != nil {
 		os.Exit(2)
 	}
+	if err := registerAbigenFlags(); err != nil {
+		os.Exit(2)
+	}
+	if err := registerChainFlags(); err != nil {
+		os.Exit(2)
+	}
+	if err := registerDBFlags(); err != nil {
+		os.Exit(2)
+	}
 }
 
 func main() {
@@ -246,6 +264,8 @@ func applyRootFlags(cmd *cobra.Command, config *harmonyconfig.HarmonyConfig) {
 	applyShardDataFlags(cmd, config)
 	applyGPOFlags(cmd, config)
 	applyCacheFlags(cmd, config)
+	applyHealthFlags(cmd, config)
+	applyAncientFlags(cmd, config)
 }
 
 func setupNodeLog(config harmonyconfig.HarmonyConfig) {
@@ -468,6 +488,9 @@ func setupNodeAndRun(hc harmonyconfig.HarmonyConfig) {
 		currentNode.RegisterExplorerServices()
 	}
 	currentNode.RegisterService(service.CrosslinkSending, crosslink_sending.New(currentNode, currentNode.Blockchain()))
+	if d := currentNode.NodeConfig.WebHooks.Dispatcher; d != nil {
+		currentNode.RegisterService(service.WebHooks, webhookevents.New(d, currentNode, currentNode.Blockchain()))
+	}
 	if hc.Pprof.Enabled {
 		setupPprofService(currentNode, hc)
 	}
@@ -710,14 +733,14 @@ func createGlobalConfig(hc harmonyconfig.HarmonyConfig) (*nodeconfig.ConfigType,
 
 	if hc.Legacy != nil && hc.Legacy.WebHookConfig != nil && len(*hc.Legacy.WebHookConfig) != 0 {
 		p := *hc.Legacy.WebHookConfig
-		config, err := webhooks.NewWebHooksFromPath(p)
+		dispatcher, err := webhooks.NewDispatcher(p)
 		if err != nil {
 			fmt.Fprintf(
-				os.Stderr, "yaml path is bad: %s", p,
+				os.Stderr, "yaml path is bad: %s: %v", p, err,
 			)
 			os.Exit(1)
 		}
-		nodeConfig.WebHooks.Hooks = config
+		nodeConfig.WebHooks.Dispatcher = dispatcher
 	}
 
 	nodeConfig.NtpServer = hc.Sys.NtpServer
@@ -727,22 +750,57 @@ func createGlobalConfig(hc harmonyconfig.HarmonyConfig) (*nodeconfig.ConfigType,
 	return nodeConfig, nil
 }
 
+// newChainDBFactory returns the factory of the chain databases in the data
+// directory of the config, using the configured engine or else the engine of
+// the existing databases.
+func newChainDBFactory(hc harmonyconfig.HarmonyConfig) (shardchain.DBFactory, error) {
+	if hc.ShardData.EnableShardData {
+		if hc.General.DBEngine == rawdb.DBPebble {
+			return nil, errors.New("pebble is not supported for shard data")
+		}
+		return &shardchain.LDBShardFactory{
+			RootDir:    hc.General.DataDir,
+			DiskCount:  hc.ShardData.DiskCount,
+			ShardCount: hc.ShardData.ShardCount,
+			CacheTime:  hc.ShardData.CacheTime,
+			CacheSize:  hc.ShardData.CacheSize,
+		}, nil
+	}
+	engine := hc.General.DBEngine
+	if engine == "" {
+		detected, err := shardchain.DetectDBEngine(hc.General.DataDir)
+		if err != nil {
+			return nil, err
+		}
+		engine = detected
+	}
+	if engine == rawdb.DBPebble {
+		return &shardchain.PDBFactory{
+			RootDir:    hc.General.DataDir,
+			Ancient:    hc.Ancient.Enabled,
+			AncientDir: hc.Ancient.Dir,
+		}, nil
+	}
+	return &shardchain.LDBFactory{
+		RootDir:    hc.General.DataDir,
+		Ancient:    hc.Ancient.Enabled,
+		AncientDir: hc.Ancient.Dir,
+	}, nil
+}
+
 func setupChain(hc harmonyconfig.HarmonyConfig, nodeConfig *nodeconfig.ConfigType, registry *registry.Registry) *registry.Registry {
 
 	// Current node.
 	var chainDBFactory shardchain.DBFactory
 	if hc.General.RunElasticMode {
 		chainDBFactory = setupTiKV(hc)
-	} else if hc.ShardData.EnableShardData {
-		chainDBFactory = &shardchain.LDBShardFactory{
-			RootDir:    nodeConfig.DBDir,
-			DiskCount:  hc.ShardData.DiskCount,
-			ShardCount: hc.ShardData.ShardCount,
-			CacheTime:  hc.ShardData.CacheTime,
-			CacheSize:  hc.ShardData.CacheSize,
-		}
 	} else {
-		chainDBFactory = &shardchain.LDBFactory{RootDir: nodeConfig.DBDir}
+		var err error
+		if chainDBFactory, err = newChainDBFactory(hc); err != nil {
+			_, _ = fmt.Fprintf(os.Stderr, "Error :%v \n", err)
+			os.Exit(1)
+		}
+		chainDBFactory = &recoverPruningFactory{DBFactory: chainDBFactory, dataDir: hc.General.DataDir}
 	}
 
 	engine := chain.NewEngine()
@@ -814,7 +872,7 @@ func setupConsensusAndNode(hc harmonyconfig.HarmonyConfig, nodeConfig *nodeconfi
 	if registry.GetShardChainCollection() == nil {
 		panic("shard chain collection is nil1111111")
 	}
-	registry.SetWebHooks(nodeConfig.WebHooks.Hooks)
+	registry.SetWebHooks(nodeConfig.WebHooks.Dispatcher)
 	cxPool := core.NewCxPool(core.CxPoolSize)
 	registry.SetCxPool(cxPool)
 
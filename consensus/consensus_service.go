@@ -674,17 +674,10 @@ func (consensus *Consensus) BlockVerifier(newBlock *types.Block) error {
 		default:
 		}
 
-		if hooks := consensus.registry.GetWebHooks(); hooks != nil {
-			if p := hooks.ProtocolIssues; p != nil {
-				url := p.OnCannotCommit
-				go func() {
-					webhooks.DoPost(url, map[string]interface{}{
-						"bad-header": newBlock.Header(),
-						"reason":     err.Error(),
-					})
-				}()
-			}
-		}
+		consensus.registry.GetWebHooks().Emit(webhooks.EventBadBlock, map[string]interface{}{
+			"bad-header": newBlock.Header(),
+			"reason":     err.Error(),
+		})
 		utils.Logger().Error().
 			Str("blockHash", newBlock.Hash().Hex()).
 			Int("numTx", len(newBlock.Transactions())).
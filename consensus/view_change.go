@@ -15,6 +15,7 @@ import (
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/p2p"
 	"github.com/harmony-one/harmony/shard"
+	"github.com/harmony-one/harmony/webhooks"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -276,6 +277,12 @@ func (consensus *Consensus) startViewChange() {
 		Str("NextLeader", consensus.LeaderPubKey.Bytes.Hex()).
 		Msg("[startViewChange]")
 	consensusVCCounterVec.With(prometheus.Labels{"viewchange": "started"}).Inc()
+	consensus.registry.GetWebHooks().Emit(webhooks.EventViewChange, map[string]interface{}{
+		"shardID":        consensus.ShardID,
+		"blockNum":       consensus.getBlockNum(),
+		"viewChangingID": nextViewID,
+		"nextLeader":     consensus.LeaderPubKey.Bytes.Hex(),
+	})
 
 	consensus.consensusTimeout[timeoutViewChange].SetDuration(duration)
 	defer consensus.consensusTimeout[timeoutViewChange].Start()
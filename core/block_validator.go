@@ -66,6 +66,11 @@ func (v *BlockValidator) ValidateBody(block *types.Block) error {
 	}
 	// Header validity is known at this point, check the uncles and transactions
 	header := block.Header()
+	if parent := v.bc.GetHeader(block.ParentHash(), block.NumberU64()-1); parent != nil {
+		if err := VerifyEIP1559Header(v.bc.Config(), parent, header); err != nil {
+			return err
+		}
+	}
 	//if err := v.engine.VerifyUncles(v.bc, block); err != nil {
 	//	return err
 	//}
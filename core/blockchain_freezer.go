@@ -0,0 +1,62 @@
+package core
+
+import (
+	"time"
+
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+const (
+	// DefaultAncientThreshold is the number of recent blocks kept in the
+	// key-value store if the ancient threshold is not configured.
+	DefaultAncientThreshold = 90000
+	// freezerRecheckInterval is the interval between checks of the chain
+	// head for blocks which became old enough to be frozen.
+	freezerRecheckInterval = time.Minute
+)
+
+// freeze periodically moves the canonical blocks older than the ancient
+// threshold from the key-value store into the ancient store of the chain
+// database, until the chain is stopped.
+func (bc *BlockChainImpl) freeze() {
+	defer bc.wg.Done()
+
+	threshold := bc.cacheConfig.AncientThreshold
+	if threshold == 0 {
+		threshold = DefaultAncientThreshold
+	}
+	ticker := time.NewTicker(freezerRecheckInterval)
+	defer ticker.Stop()
+	for {
+		head := bc.CurrentBlock().NumberU64()
+		for head > threshold {
+			start := time.Now()
+			frozen, err := rawdb.FreezeBlocks(bc.db, head-threshold)
+			if err != nil {
+				utils.Logger().Error().Err(err).Uint64("head", head).Msg("Failed to freeze blocks")
+				break
+			}
+			if frozen == 0 {
+				break
+			}
+			ancients, _ := bc.db.Ancients()
+			utils.Logger().Info().
+				Uint64("frozen", frozen).
+				Uint64("ancients", ancients).
+				Dur("elapsed", time.Since(start)).
+				Msg("Moved blocks into the ancient store")
+
+			select {
+			case <-bc.quit:
+				return
+			default:
+			}
+		}
+		select {
+		case <-bc.quit:
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -139,6 +139,7 @@ type CacheConfig struct {
 	SnapshotLimit     int           // Memory allowance (MB) to use for caching snapshot entries in memory
 	SnapshotNoBuild   bool          // Whether the background generation is allowed
 	SnapshotWait      bool          // Wait for snapshot construction on startup. TODO(karalabe): This is a dirty hack for testing, nuke it
+	AncientThreshold  uint64        // Number of recent blocks kept in the key-value store before being frozen into the ancient store
 }
 
 // defaultCacheConfig are the default caching values if none are specified by the
@@ -208,6 +209,7 @@ type BlockChainImpl struct {
 	blockAccumulatorCache         *lru.Cache        // Cache of block accumulators
 	leaderPubKeyFromCoinbase      *lru.Cache        // Cache of leader public key from coinbase
 	quit                          chan struct{}     // blockchain quit channel
+	wg                            sync.WaitGroup    // background routines wait group for shutting down
 	running                       int32             // running must be called atomically
 	blockchainPruner              *blockchainPruner // use to prune beacon chain
 	// procInterrupt must be atomically called
@@ -372,6 +374,12 @@ func newBlockChainWithOptions(
 			return nil, errors.WithMessage(err, "failed to write pre-image start end blocks")
 		}
 	}
+
+	// Start freezing old blocks if the database has an ancient store
+	if _, err := bc.db.Ancients(); err == nil {
+		bc.wg.Add(1)
+		go bc.freeze()
+	}
 	return bc, nil
 }
 
@@ -670,6 +678,13 @@ func (bc *BlockChainImpl) setHead(head uint64) error {
 	if err := bc.hc.SetHead(head, delFn); err != nil {
 		return errors.Wrap(err, "headerChain SetHeader")
 	}
+	// Drop the frozen blocks above the new head, their data is already gone
+	// from the key-value store
+	if frozen, err := bc.db.Ancients(); err == nil && frozen > head+1 {
+		if err := bc.db.TruncateHead(head + 1); err != nil {
+			return errors.Wrap(err, "truncate ancient store")
+		}
+	}
 	currentHeader := bc.hc.CurrentHeader()
 
 	// Clear out any stale content from the caches
@@ -1207,6 +1222,7 @@ func (bc *BlockChainImpl) Stop() {
 	bc.scope.Close()
 	close(bc.quit)
 	atomic.StoreInt32(&bc.procInterrupt, 1)
+	bc.wg.Wait()
 
 	// Ensure the state of a recent block is also stored to disk before exiting.
 	// We're writing three different states to catch different restart scenarios:
@@ -0,0 +1,443 @@
+// Package dbverify checks the consistency of the chain data of a shard chain
+// database, and optionally repairs the inconsistencies which can be derived
+// from the data of the canonical blocks.
+package dbverify
+
+import (
+	"bytes"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/harmony-one/harmony/shard"
+)
+
+const (
+	// DefaultStateRoots is the number of recent state roots checked for
+	// availability if not configured.
+	DefaultStateRoots = 128
+	// reportInterval is the interval between progress logs of a verification.
+	reportInterval = 8 * time.Second
+)
+
+// IssueKind is the kind of chain data an issue was found in.
+type IssueKind string
+
+// The kinds of issues reported by Verify.
+const (
+	CanonicalHashIssue     IssueKind = "canonical-hash"
+	HeaderIssue            IssueKind = "header"
+	HeaderNumberIssue      IssueKind = "header-number"
+	ParentIssue            IssueKind = "parent"
+	BodyIssue              IssueKind = "body"
+	ReceiptsIssue          IssueKind = "receipts"
+	TxLookupIssue          IssueKind = "tx-lookup"
+	CommitSigIssue         IssueKind = "commit-sig"
+	ShardStateIssue        IssueKind = "shard-state"
+	CrossLinkIssue         IssueKind = "crosslink"
+	ValidatorSnapshotIssue IssueKind = "validator-snapshot"
+	StateIssue             IssueKind = "state"
+)
+
+// Config is the configuration of a verification.
+type Config struct {
+	From        uint64              // number of the first block to verify
+	To          uint64              // number of the last block to verify, capped to the head block
+	StateRoots  uint64              // number of recent state roots checked for availability
+	Repair      bool                // whether to repair the issues which can be repaired
+	ChainConfig *params.ChainConfig // chain config of the database
+}
+
+// Issue is an inconsistency found in the chain data.
+type Issue struct {
+	Kind     IssueKind   `json:"kind"`
+	Number   uint64      `json:"number"`
+	Hash     common.Hash `json:"hash"`
+	Detail   string      `json:"detail"`
+	Repaired bool        `json:"repaired"`
+}
+
+// Summary is the result of a verification.
+type Summary struct {
+	ShardID             uint32   `json:"shardID"`
+	Head                uint64   `json:"head"`
+	From                uint64   `json:"from"`
+	To                  uint64   `json:"to"`
+	Blocks              uint64   `json:"blocks"`
+	Transactions        uint64   `json:"transactions"`
+	StakingTransactions uint64   `json:"stakingTransactions"`
+	CommitSigs          uint64   `json:"commitSigs"`
+	ShardStates         uint64   `json:"shardStates"`
+	CrossLinks          uint64   `json:"crossLinks"`
+	ValidatorSnapshots  uint64   `json:"validatorSnapshots"`
+	StateRoots          uint64   `json:"stateRoots"`
+	StateRootsAvailable uint64   `json:"stateRootsAvailable"`
+	Issues              []*Issue `json:"issues"`
+	Repaired            int      `json:"repaired"`
+}
+
+// Unrepaired returns the number of issues which were not repaired.
+func (s *Summary) Unrepaired() int {
+	return len(s.Issues) - s.Repaired
+}
+
+// verifier holds the state of a verification.
+type verifier struct {
+	db      ethdb.Database
+	config  Config
+	batch   ethdb.Batch
+	summary *Summary
+}
+
+// Verify walks the canonical blocks of db from config.From to config.To and
+// checks that their headers, bodies, receipts, transaction lookup entries and
+// commit signatures are present and consistent, as well as the shard states
+// and, on the beacon chain, the crosslinks carried by the blocks and the
+// validator snapshots of the head epoch. It also checks the availability of
+// the state tries of the head block and of the recent blocks before it. The
+// issues derivable from the canonical blocks are repaired if config.Repair is
+// set.
+func Verify(db ethdb.Database, config Config) (*Summary, error) {
+	if config.ChainConfig == nil {
+		return nil, errors.New("chain config is required")
+	}
+	headHash := rawdb.ReadHeadBlockHash(db)
+	if headHash == (common.Hash{}) {
+		return nil, errors.New("head block hash not found")
+	}
+	headNumber := rawdb.ReadHeaderNumber(db, headHash)
+	if headNumber == nil {
+		return nil, errors.Errorf("head block %x not found", headHash)
+	}
+	head := rawdb.ReadHeader(db, headHash, *headNumber)
+	if head == nil {
+		return nil, errors.Errorf("head block %d header not found", *headNumber)
+	}
+	to := config.To
+	if to > *headNumber {
+		to = *headNumber
+	}
+	if config.From > to {
+		return nil, errors.Errorf("first block %d is beyond the last block %d", config.From, to)
+	}
+	if config.StateRoots == 0 {
+		config.StateRoots = DefaultStateRoots
+	}
+
+	v := &verifier{
+		db:     db,
+		config: config,
+		batch:  db.NewBatch(),
+		summary: &Summary{
+			ShardID: head.ShardID(),
+			Head:    *headNumber,
+			From:    config.From,
+			To:      to,
+			Issues:  []*Issue{},
+		},
+	}
+	start, reported := time.Now(), time.Now()
+	var parent *block.Header
+	if config.From > 0 {
+		parent = rawdb.ReadHeader(db, rawdb.ReadCanonicalHash(db, config.From-1), config.From-1)
+	}
+	for number := config.From; number <= to; number++ {
+		header, err := v.verifyBlock(number, parent)
+		if err != nil {
+			return nil, err
+		}
+		parent = header
+		if time.Since(reported) >= reportInterval {
+			utils.Logger().Info().
+				Uint64("number", number).
+				Uint64("to", to).
+				Int("issues", len(v.summary.Issues)).
+				Dur("elapsed", time.Since(start)).
+				Msg("Verifying chain database")
+			reported = time.Now()
+		}
+	}
+	if err := v.verifyValidatorSnapshots(head); err != nil {
+		return nil, err
+	}
+	v.verifyStateRoots(head)
+	if err := v.batch.Write(); err != nil {
+		return nil, err
+	}
+	return v.summary, nil
+}
+
+// report records an issue of the block with the given number and hash. The
+// issue is marked repaired if repair is given, and succeeds, in repair mode.
+func (v *verifier) report(kind IssueKind, number uint64, hash common.Hash, detail string, repair func() error) error {
+	issue := &Issue{Kind: kind, Number: number, Hash: hash, Detail: detail}
+	v.summary.Issues = append(v.summary.Issues, issue)
+	if !v.config.Repair || repair == nil {
+		return nil
+	}
+	if err := repair(); err != nil {
+		return errors.Wrapf(err, "failed to repair %s of block %d", kind, number)
+	}
+	issue.Repaired = true
+	v.summary.Repaired++
+	if v.batch.ValueSize() >= ethdb.IdealBatchSize {
+		if err := v.batch.Write(); err != nil {
+			return err
+		}
+		v.batch.Reset()
+	}
+	return nil
+}
+
+// verifyBlock verifies the chain data of the canonical block with the given
+// number, and returns its header if found.
+func (v *verifier) verifyBlock(number uint64, parent *block.Header) (*block.Header, error) {
+	hash := rawdb.ReadCanonicalHash(v.db, number)
+	if hash == (common.Hash{}) {
+		return nil, v.report(CanonicalHashIssue, number, hash, "canonical hash not found", nil)
+	}
+	header := rawdb.ReadHeader(v.db, hash, number)
+	if header == nil {
+		return nil, v.report(HeaderIssue, number, hash, "header not found", nil)
+	}
+	if header.Hash() != hash || header.Number().Uint64() != number {
+		return nil, v.report(HeaderIssue, number, hash, "header does not match the canonical hash", nil)
+	}
+	v.summary.Blocks++
+	if n := rawdb.ReadHeaderNumber(v.db, hash); n == nil || *n != number {
+		if err := v.report(HeaderNumberIssue, number, hash, "hash to number mapping not found", func() error {
+			return rawdb.WriteHeaderNumber(v.batch, hash, number)
+		}); err != nil {
+			return nil, err
+		}
+	}
+	if parent != nil && header.ParentHash() != parent.Hash() {
+		if err := v.report(ParentIssue, number, hash, "parent hash does not match the canonical parent", nil); err != nil {
+			return nil, err
+		}
+	}
+	if err := v.verifyBody(header); err != nil {
+		return nil, err
+	}
+	if err := v.verifyCommitSig(header); err != nil {
+		return nil, err
+	}
+	if err := v.verifyShardState(header); err != nil {
+		return nil, err
+	}
+	if err := v.verifyCrossLinks(header); err != nil {
+		return nil, err
+	}
+	return header, nil
+}
+
+// verifyBody verifies the body, receipts and transaction lookup entries of
+// the canonical block of header.
+func (v *verifier) verifyBody(header *block.Header) error {
+	number, hash := header.Number().Uint64(), header.Hash()
+	blk := rawdb.ReadBlock(v.db, hash, number)
+	if blk == nil {
+		return v.report(BodyIssue, number, hash, "body not found", nil)
+	}
+	txs, stxs := blk.Transactions(), blk.StakingTransactions()
+	v.summary.Transactions += uint64(len(txs))
+	v.summary.StakingTransactions += uint64(len(stxs))
+
+	if !rawdb.HasReceipts(v.db, hash, number) {
+		if err := v.report(ReceiptsIssue, number, hash, "receipts not found", nil); err != nil {
+			return err
+		}
+	} else if receipts := rawdb.ReadReceipts(v.db, hash, number, nil); len(receipts) != len(txs)+len(stxs) {
+		detail := errors.Errorf("%d receipts for %d transactions", len(receipts), len(txs)+len(stxs)).Error()
+		if err := v.report(ReceiptsIssue, number, hash, detail, nil); err != nil {
+			return err
+		}
+	}
+
+	for i, tx := range txs {
+		for _, txHash := range []common.Hash{tx.Hash(), tx.ConvertToEth().Hash()} {
+			if detail := v.lookupMismatch(txHash, hash, number, uint64(i)); detail != "" {
+				if err := v.report(TxLookupIssue, number, hash, detail, func() error {
+					return rawdb.WriteBlockTxLookUpEntries(v.batch, blk)
+				}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	for i, stx := range stxs {
+		if detail := v.lookupMismatch(stx.Hash(), hash, number, uint64(i)); detail != "" {
+			if err := v.report(TxLookupIssue, number, hash, detail, func() error {
+				return rawdb.WriteBlockStxLookUpEntries(v.batch, blk)
+			}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// lookupMismatch describes the mismatch of the lookup entry of the
+// transaction with the given hash with its position in a canonical block, or
+// returns an empty string if the entry matches.
+func (v *verifier) lookupMismatch(txHash, blockHash common.Hash, number, index uint64) string {
+	entryHash, entryNumber, entryIndex := rawdb.ReadTxLookupEntry(v.db, txHash)
+	switch {
+	case entryHash == (common.Hash{}):
+		return "lookup entry of transaction " + txHash.Hex() + " not found"
+	case entryHash != blockHash || entryNumber != number || entryIndex != index:
+		return "lookup entry of transaction " + txHash.Hex() + " does not match its block"
+	}
+	return ""
+}
+
+// verifyCommitSig verifies the commit signature of the canonical block of
+// header against the one carried by its child, if any.
+func (v *verifier) verifyCommitSig(header *block.Header) error {
+	number, hash := header.Number().Uint64(), header.Hash()
+	// The genesis block is not signed
+	if number == 0 {
+		return nil
+	}
+	v.summary.CommitSigs++
+	sig, _ := rawdb.ReadBlockCommitSig(v.db, number)
+	var want []byte
+	if child := rawdb.ReadHeader(v.db, rawdb.ReadCanonicalHash(v.db, number+1), number+1); child != nil {
+		lastSig := child.LastCommitSignature()
+		want = append(lastSig[:], child.LastCommitBitmap()...)
+	}
+	var detail string
+	switch {
+	case len(sig) == 0:
+		detail = "commit signature not found"
+	case want != nil && !bytes.Equal(sig, want):
+		detail = "commit signature does not match the one of the child block"
+	default:
+		return nil
+	}
+	// Only the child block can restore the commit signature
+	var repair func() error
+	if want != nil {
+		repair = func() error { return rawdb.WriteBlockCommitSig(v.batch, number, want) }
+	}
+	return v.report(CommitSigIssue, number, hash, detail, repair)
+}
+
+// verifyShardState verifies that the shard state of the next epoch carried by
+// the last block of an epoch is stored.
+func (v *verifier) verifyShardState(header *block.Header) error {
+	if !header.IsLastBlockInEpoch() {
+		return nil
+	}
+	number, hash := header.Number().Uint64(), header.Hash()
+	want, err := shard.DecodeWrapper(header.ShardState())
+	if err != nil {
+		return v.report(ShardStateIssue, number, hash, "cannot decode shard state of header: "+err.Error(), nil)
+	}
+	// After staking, the next epoch is decided by the shard state
+	epoch := new(big.Int).Add(header.Epoch(), common.Big1)
+	if want.Epoch != nil && v.config.ChainConfig.IsStaking(want.Epoch) {
+		epoch = new(big.Int).Set(want.Epoch)
+	}
+	v.summary.ShardStates++
+	have, err := rawdb.ReadShardState(v.db, epoch)
+	if err == nil && have.Hash() == want.Hash() {
+		return nil
+	}
+	detail := "shard state of epoch " + epoch.String() + " not found"
+	if err == nil {
+		detail = "shard state of epoch " + epoch.String() + " does not match the one of the header"
+	}
+	return v.report(ShardStateIssue, number, hash, detail, func() error {
+		return rawdb.WriteShardStateBytes(v.batch, epoch, header.ShardState())
+	})
+}
+
+// verifyCrossLinks verifies that the crosslinks carried by the canonical
+// beacon chain block of header are stored.
+func (v *verifier) verifyCrossLinks(header *block.Header) error {
+	if header.ShardID() != shard.BeaconChainShardID ||
+		!v.config.ChainConfig.IsCrossLink(header.Epoch()) ||
+		len(header.CrossLinks()) == 0 {
+		return nil
+	}
+	number, hash := header.Number().Uint64(), header.Hash()
+	crossLinks := types.CrossLinks{}
+	if err := rlp.DecodeBytes(header.CrossLinks(), &crossLinks); err != nil {
+		return v.report(CrossLinkIssue, number, hash, "cannot decode crosslinks of header: "+err.Error(), nil)
+	}
+	for _, cl := range crossLinks {
+		cl := cl
+		v.summary.CrossLinks++
+		data, _ := rawdb.ReadCrossLinkShardBlock(v.db, cl.ShardID(), cl.BlockNum())
+		if bytes.Equal(data, cl.Serialize()) {
+			continue
+		}
+		detail := errors.Errorf("crosslink of shard %d block %d not found", cl.ShardID(), cl.BlockNum()).Error()
+		if len(data) > 0 {
+			detail = errors.Errorf("crosslink of shard %d block %d does not match the one of the header", cl.ShardID(), cl.BlockNum()).Error()
+		}
+		if err := v.report(CrossLinkIssue, number, hash, detail, func() error {
+			return rawdb.WriteCrossLinkShardBlock(v.batch, cl.ShardID(), cl.BlockNum(), cl.Serialize())
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// verifyValidatorSnapshots verifies that all the validators of the beacon
+// chain have a snapshot for the epoch of the head block. Snapshots are taken
+// from the state and can't be repaired.
+func (v *verifier) verifyValidatorSnapshots(head *block.Header) error {
+	if head.ShardID() != shard.BeaconChainShardID || !v.config.ChainConfig.IsStaking(head.Epoch()) {
+		return nil
+	}
+	validators, err := rawdb.ReadValidatorList(v.db)
+	if err != nil {
+		return v.report(ValidatorSnapshotIssue, head.Number().Uint64(), head.Hash(), "validator list not found", nil)
+	}
+	for _, addr := range validators {
+		v.summary.ValidatorSnapshots++
+		if snapshot, err := rawdb.ReadValidatorSnapshot(v.db, addr, head.Epoch()); err != nil || snapshot == nil {
+			detail := "snapshot of validator " + addr.Hex() + " for epoch " + head.Epoch().String() + " not found"
+			if err := v.report(ValidatorSnapshotIssue, head.Number().Uint64(), head.Hash(), detail, nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// verifyStateRoots checks the availability of the state tries of the head
+// block and of the recent blocks before it. Only the state of the head block
+// is required, as the state of the other blocks is only flushed periodically
+// unless the node is an archival one.
+func (v *verifier) verifyStateRoots(head *block.Header) {
+	sdb := state.NewDatabase(v.db)
+	for i := uint64(0); i < v.config.StateRoots && i <= head.Number().Uint64(); i++ {
+		number := head.Number().Uint64() - i
+		header := head
+		if i > 0 {
+			if header = rawdb.ReadHeader(v.db, rawdb.ReadCanonicalHash(v.db, number), number); header == nil {
+				break
+			}
+		}
+		v.summary.StateRoots++
+		if _, err := sdb.OpenTrie(header.Root()); err == nil {
+			v.summary.StateRootsAvailable++
+		} else if i == 0 {
+			v.report(StateIssue, number, header.Hash(), "state of head block not found: "+err.Error(), nil)
+		}
+	}
+}
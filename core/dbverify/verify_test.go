@@ -0,0 +1,187 @@
+package dbverify
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/harmony-one/harmony/shard"
+)
+
+// writeTestChain writes a canonical beacon chain of 5 blocks with one
+// transaction each. Block 2 is the last block of its epoch and block 3
+// carries a crosslink.
+func writeTestChain(t *testing.T, db ethdb.Database) ([]*types.Block, *types.CrossLink) {
+	t.Helper()
+	shardState, err := shard.EncodeWrapper(shard.State{
+		Epoch:  big.NewInt(1),
+		Shards: []shard.Committee{{ShardID: 0, Slots: shard.SlotList{}}},
+	}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl := types.CrossLink{
+		HashF:        common.HexToHash("0x1234"),
+		BlockNumberF: big.NewInt(7),
+		ViewIDF:      big.NewInt(7),
+		ShardIDF:     1,
+		EpochF:       big.NewInt(0),
+	}
+	crossLinks, err := rlp.EncodeToBytes(types.CrossLinks{cl})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var blocks []*types.Block
+	for i := 0; i < 5; i++ {
+		setter := blockfactory.NewTestHeader().With().
+			Number(big.NewInt(int64(i))).
+			Epoch(big.NewInt(0)).
+			Root(types.EmptyRootHash)
+		if i > 0 {
+			parent := blocks[i-1]
+			setter = setter.ParentHash(parent.Hash()).
+				LastCommitSignature(testCommitSig(parent.NumberU64())).
+				LastCommitBitmap([]byte{byte(parent.NumberU64())})
+		}
+		switch i {
+		case 2:
+			setter = setter.ShardState(shardState)
+		case 3:
+			setter = setter.CrossLinks(crossLinks)
+		}
+		tx := types.NewTransaction(uint64(i), common.Address{}, 0, big.NewInt(1), 21000, big.NewInt(1), nil)
+		receipts := types.Receipts{{Status: types.ReceiptStatusSuccessful, Logs: []*types.Log{}}}
+		b := types.NewBlock(setter.Header(), types.Transactions{tx}, receipts, nil, nil, nil)
+
+		if err := rawdb.WriteBlock(db, b); err != nil {
+			t.Fatal(err)
+		}
+		if err := rawdb.WriteReceipts(db, b.Hash(), b.NumberU64(), receipts); err != nil {
+			t.Fatal(err)
+		}
+		if err := rawdb.WriteCanonicalHash(db, b.Hash(), b.NumberU64()); err != nil {
+			t.Fatal(err)
+		}
+		if err := rawdb.WriteBlockTxLookUpEntries(db, b); err != nil {
+			t.Fatal(err)
+		}
+		if i > 0 {
+			sig := testCommitSig(b.NumberU64())
+			if err := rawdb.WriteBlockCommitSig(db, b.NumberU64(), append(sig[:], byte(b.NumberU64()))); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := rawdb.WriteHeadBlockHash(db, b.Hash()); err != nil {
+			t.Fatal(err)
+		}
+		blocks = append(blocks, b)
+	}
+	if err := rawdb.WriteValidatorList(db, []common.Address{}); err != nil {
+		t.Fatal(err)
+	}
+	return blocks, &cl
+}
+
+func testCommitSig(number uint64) (sig [96]byte) {
+	copy(sig[:], bytes.Repeat([]byte{byte(number)}, len(sig)))
+	return sig
+}
+
+func TestVerify(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	blocks, cl := writeTestChain(t, db)
+
+	// The shard state and the crosslink are stored along with the chain
+	if err := rawdb.WriteShardStateBytes(db, big.NewInt(1), blocks[2].Header().ShardState()); err != nil {
+		t.Fatal(err)
+	}
+	if err := rawdb.WriteCrossLinkShardBlock(db, cl.ShardID(), cl.BlockNum(), cl.Serialize()); err != nil {
+		t.Fatal(err)
+	}
+	config := Config{To: math.MaxUint64, ChainConfig: params.TestChainConfig}
+	summary, err := Verify(db, config)
+	if err != nil {
+		t.Fatalf("failed to verify database: %v", err)
+	}
+	if len(summary.Issues) != 0 {
+		t.Fatalf("issues found in consistent database: %+v", summary.Issues[0])
+	}
+	if summary.Blocks != 5 || summary.Transactions != 5 || summary.CommitSigs != 4 ||
+		summary.ShardStates != 1 || summary.CrossLinks != 1 || summary.StateRootsAvailable != 5 {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+}
+
+func TestVerifyRepair(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	blocks, cl := writeTestChain(t, db)
+
+	// Corrupt the chain data, leaving the shard state and crosslink unstored
+	rawdb.DeleteHeaderNumber(db, blocks[1].Hash())
+	if err := rawdb.DeleteTxLookupEntry(db, blocks[2].Transactions()[0].Hash()); err != nil {
+		t.Fatal(err)
+	}
+	if err := rawdb.WriteBlockCommitSig(db, 3, []byte{0x01}); err != nil {
+		t.Fatal(err)
+	}
+	if err := rawdb.DeleteReceipts(db, blocks[4].Hash(), 4); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[IssueKind]bool{
+		HeaderNumberIssue: true,
+		TxLookupIssue:     true,
+		CommitSigIssue:    true,
+		ShardStateIssue:   true,
+		CrossLinkIssue:    true,
+		ReceiptsIssue:     false,
+	}
+	config := Config{To: math.MaxUint64, ChainConfig: params.TestChainConfig}
+	summary, err := Verify(db, config)
+	if err != nil {
+		t.Fatalf("failed to verify database: %v", err)
+	}
+	if len(summary.Issues) != len(want) || summary.Repaired != 0 {
+		t.Fatalf("issues mismatch: have %d (%d repaired), want %d", len(summary.Issues), summary.Repaired, len(want))
+	}
+
+	config.Repair = true
+	if summary, err = Verify(db, config); err != nil {
+		t.Fatalf("failed to repair database: %v", err)
+	}
+	for _, issue := range summary.Issues {
+		repairable, ok := want[issue.Kind]
+		if !ok {
+			t.Errorf("unexpected issue: %+v", issue)
+		} else if issue.Repaired != repairable {
+			t.Errorf("issue %s repaired: have %v, want %v", issue.Kind, issue.Repaired, repairable)
+		}
+	}
+	if summary.Unrepaired() != 1 {
+		t.Errorf("unrepaired issues mismatch: have %d, want %d", summary.Unrepaired(), 1)
+	}
+
+	// Only the missing receipts are left
+	config.Repair = false
+	if summary, err = Verify(db, config); err != nil {
+		t.Fatalf("failed to verify database: %v", err)
+	}
+	if len(summary.Issues) != 1 || summary.Issues[0].Kind != ReceiptsIssue || summary.Issues[0].Number != 4 {
+		t.Errorf("issues after repair mismatch: %+v", summary.Issues)
+	}
+	if n := rawdb.ReadHeaderNumber(db, blocks[1].Hash()); n == nil || *n != 1 {
+		t.Errorf("hash to number mapping not repaired")
+	}
+	if data, _ := rawdb.ReadCrossLinkShardBlock(db, cl.ShardID(), cl.BlockNum()); !bytes.Equal(data, cl.Serialize()) {
+		t.Errorf("crosslink not repaired")
+	}
+}
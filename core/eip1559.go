@@ -0,0 +1,101 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package core
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/internal/params"
+	"github.com/pkg/errors"
+)
+
+var (
+	errMissingBaseFee = errors.New("header is missing baseFee")
+	errUnexpectedFee  = errors.New("header carries baseFee before the London fork")
+)
+
+// VerifyEIP1559Header verifies the EIP-1559 base fee of header, which is
+// derived from its parent.
+func VerifyEIP1559Header(config *params.ChainConfig, parent, header *block.Header) error {
+	if !config.IsLondon(header.Epoch()) {
+		if header.BaseFee() != nil {
+			return errUnexpectedFee
+		}
+		return nil
+	}
+	if header.BaseFee() == nil {
+		return errMissingBaseFee
+	}
+	if expected := CalcBaseFee(config, parent); header.BaseFee().Cmp(expected) != 0 {
+		return fmt.Errorf("invalid baseFee: have %s, want %s, parentBaseFee %s, parentGasUsed %d",
+			header.BaseFee(), expected, parent.BaseFee(), parent.GasUsed())
+	}
+	return nil
+}
+
+// CalcBaseFee calculates the base fee of the block following parent. The base
+// fee rises when parent used more than half of its gas limit and falls when
+// it used less, by at most 1/BaseFeeChangeDenominator per block.
+func CalcBaseFee(config *params.ChainConfig, parent *block.Header) *big.Int {
+	// The first London block starts from the initial base fee
+	if !config.IsLondon(parent.Epoch()) || parent.BaseFee() == nil {
+		return new(big.Int).SetUint64(params.InitialBaseFee)
+	}
+
+	parentBaseFee := parent.BaseFee()
+	parentGasTarget := parent.GasLimit() / params.ElasticityMultiplier
+	if parentGasTarget == 0 {
+		return parentBaseFee
+	}
+	// If the parent gasUsed is the same as the target, the baseFee remains unchanged.
+	if parent.GasUsed() == parentGasTarget {
+		return parentBaseFee
+	}
+
+	var (
+		num   = new(big.Int)
+		denom = new(big.Int)
+	)
+
+	if parent.GasUsed() > parentGasTarget {
+		// If the parent block used more gas than its target, the baseFee should increase.
+		// max(1, parentBaseFee * gasUsedDelta / parentGasTarget / baseFeeChangeDenominator)
+		num.SetUint64(parent.GasUsed() - parentGasTarget)
+		num.Mul(num, parentBaseFee)
+		num.Div(num, denom.SetUint64(parentGasTarget))
+		num.Div(num, denom.SetUint64(params.BaseFeeChangeDenominator))
+		if num.Cmp(common.Big1) < 0 {
+			num.Set(common.Big1)
+		}
+		return num.Add(num, parentBaseFee)
+	}
+	// Otherwise if the parent block used less gas than its target, the baseFee should decrease.
+	// max(0, parentBaseFee * gasUsedDelta / parentGasTarget / baseFeeChangeDenominator)
+	num.SetUint64(parentGasTarget - parent.GasUsed())
+	num.Mul(num, parentBaseFee)
+	num.Div(num, denom.SetUint64(parentGasTarget))
+	num.Div(num, denom.SetUint64(params.BaseFeeChangeDenominator))
+
+	baseFee := num.Sub(parentBaseFee, num)
+	if baseFee.Sign() < 0 {
+		baseFee.SetUint64(0)
+	}
+	return baseFee
+}
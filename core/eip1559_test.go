@@ -0,0 +1,64 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+func TestCalcBaseFee(t *testing.T) {
+	tests := []struct {
+		parentBaseFee   uint64
+		parentGasLimit  uint64
+		parentGasUsed   uint64
+		expectedBaseFee uint64
+	}{
+		{params.InitialBaseFee, 20000000, 10000000, params.InitialBaseFee}, // usage == target
+		{params.InitialBaseFee, 20000000, 9000000, 98750000000},            // usage below target
+		{params.InitialBaseFee, 20000000, 11000000, 101250000000},          // usage above target
+		{params.InitialBaseFee, 20000000, 0, 87500000000},                  // empty block
+		{params.InitialBaseFee, 20000000, 20000000, 112500000000},          // full block
+		{7, 20000000, 10000001, 8},                                         // increase by at least one
+		{0, 20000000, 0, 0},                                                // never below zero
+	}
+	for i, test := range tests {
+		parent := blockfactory.ForTest.NewHeader(big.NewInt(1)).With().
+			GasLimit(test.parentGasLimit).
+			GasUsed(test.parentGasUsed).
+			BaseFee(new(big.Int).SetUint64(test.parentBaseFee)).
+			Header()
+		if have, want := CalcBaseFee(params.TestChainConfig, parent), new(big.Int).SetUint64(test.expectedBaseFee); have.Cmp(want) != 0 {
+			t.Errorf("test %d: have %d want %d", i, have, want)
+		}
+	}
+}
+
+func TestCalcBaseFeeForkTransition(t *testing.T) {
+	config := *params.TestChainConfig
+	config.LondonEpoch = big.NewInt(2)
+	factory := blockfactory.NewFactory(&config)
+
+	// The first London block starts from the initial base fee
+	parent := factory.NewHeader(big.NewInt(1))
+	if parent.BaseFee() != nil {
+		t.Fatalf("pre-London header carries a base fee: %v", parent.BaseFee())
+	}
+	baseFee := CalcBaseFee(&config, parent)
+	if baseFee.Cmp(new(big.Int).SetUint64(params.InitialBaseFee)) != 0 {
+		t.Errorf("wrong initial base fee: have %d want %d", baseFee, params.InitialBaseFee)
+	}
+
+	header := factory.NewHeader(big.NewInt(2)).With().BaseFee(baseFee).Header()
+	if err := VerifyEIP1559Header(&config, parent, header); err != nil {
+		t.Errorf("valid header rejected: %v", err)
+	}
+	header.SetBaseFee(new(big.Int).Add(baseFee, big.NewInt(1)))
+	if err := VerifyEIP1559Header(&config, parent, header); err == nil {
+		t.Error("header with a wrong base fee accepted")
+	}
+	if err := VerifyEIP1559Header(&config, factory.NewHeader(big.NewInt(0)), parent); err != nil {
+		t.Errorf("pre-London header rejected: %v", err)
+	}
+}
@@ -87,6 +87,7 @@ func NewEVMContext(msg Message, header *block.Header, chain ChainContext, author
 		EpochNumber:           header.Epoch(),
 		Time:                  header.Time(),
 		VRF:                   vrf,
+		BaseFee:               header.BaseFee(),
 		TxType:                0,
 		CreateValidator:       CreateValidatorFn(header, chain),
 		EditValidator:         EditValidatorFn(header, chain),
@@ -381,6 +382,7 @@ func CalculateMigrationGasFn(chain ChainContext) vm.CalculateMigrationGasFunc {
 			}
 			thisGas, err := vm.IntrinsicGas(
 				encoded,
+				nil,
 				false,
 				homestead,
 				istanbul,
@@ -404,6 +406,7 @@ func CalculateMigrationGasFn(chain ChainContext) vm.CalculateMigrationGasFunc {
 			// there are no delegations to migrate
 			return vm.IntrinsicGas(
 				[]byte{},
+				nil,
 				false,
 				homestead,
 				istanbul,
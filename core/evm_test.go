@@ -65,7 +65,7 @@ func TestEVMStaking(t *testing.T) {
 	// fake transaction
 	tx := types.NewTransaction(1, common.BytesToAddress([]byte{0x11}), 0, big.NewInt(111), 1111, big.NewInt(11111), []byte{0x11, 0x11, 0x11})
 	// transaction as message (chainId = 2)
-	msg, _ := tx.AsMessage(types.NewEIP155Signer(common.Big2))
+	msg, _ := tx.AsMessage(types.NewEIP155Signer(common.Big2), nil)
 	// context
 	ctx := NewEVMContext(msg, header, chain, nil /* coinbase */)
 
@@ -436,7 +436,7 @@ func TestWriteCapablePrecompilesIntegration(t *testing.T) {
 	chain, db, header, _ := getTestEnvironment(*key)
 	// gp := new(GasPool).AddGas(math.MaxUint64)
 	tx := types.NewTransaction(1, common.BytesToAddress([]byte{0x11}), 0, big.NewInt(111), 1111, big.NewInt(11111), []byte{0x11, 0x11, 0x11})
-	msg, _ := tx.AsMessage(types.NewEIP155Signer(common.Big2))
+	msg, _ := tx.AsMessage(types.NewEIP155Signer(common.Big2), nil)
 	ctx := NewEVMContext(msg, header, chain, nil /* coinbase */)
 	evm := vm.NewEVM(ctx, db, params.TestChainConfig, vm.Config{})
 	// interpreter := vm.NewEVMInterpreter(evm, vm.Config{})
@@ -388,6 +388,12 @@ func WriteBlockRewardAccumulator(db DatabaseWriter, newAccum *big.Int, number ui
 
 // ReadBlockCommitSig retrieves the signature signed on a block.
 func ReadBlockCommitSig(db DatabaseReader, blockNum uint64) ([]byte, error) {
+	// The signatures of frozen blocks are only kept in the ancient store
+	if reader, ok := db.(ethdb.AncientReader); ok {
+		if data, _ := reader.Ancient(ChainFreezerCommitSigTable, blockNum); len(data) > 0 {
+			return data, nil
+		}
+	}
 	var data []byte
 	data, err := db.Get(blockCommitSigKey(blockNum))
 	if err != nil {
@@ -17,7 +17,6 @@
 package rawdb
 
 // The list of table names of chain freezer.
-// This variables is NOT used, just ported over from the Ethereum
 const (
 	// ChainFreezerHeaderTable indicates the name of the freezer header table.
 	ChainFreezerHeaderTable = "headers"
@@ -32,18 +31,21 @@ const (
 	ChainFreezerReceiptTable = "receipts"
 
 	// ChainFreezerDifficultyTable indicates the name of the freezer total difficulty table.
+	// This table is NOT used, just ported over from the Ethereum
 	ChainFreezerDifficultyTable = "diffs"
+
+	// ChainFreezerCommitSigTable indicates the name of the freezer commit signature table.
+	ChainFreezerCommitSigTable = "sigs"
 )
 
 // chainFreezerNoSnappy configures whether compression is disabled for the ancient-tables.
-// Hashes and difficulties don't compress well.
-// This function is NOT used, just ported over from the Ethereum
+// Hashes and commit signatures don't compress well.
 var chainFreezerNoSnappy = map[string]bool{
-	ChainFreezerHeaderTable:     false,
-	ChainFreezerHashTable:       true,
-	ChainFreezerBodiesTable:     false,
-	ChainFreezerReceiptTable:    false,
-	ChainFreezerDifficultyTable: true,
+	ChainFreezerHeaderTable:    false,
+	ChainFreezerHashTable:      true,
+	ChainFreezerBodiesTable:    false,
+	ChainFreezerReceiptTable:   false,
+	ChainFreezerCommitSigTable: true,
 }
 
 // The list of identifiers of ancient stores.
@@ -0,0 +1,128 @@
+package rawdb
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+)
+
+// freezerBatchLimit is the maximum number of blocks to freeze in one batch
+// before doing an fsync and deleting them from the key-value store.
+const freezerBatchLimit = 30000
+
+// FreezeBlocks moves the canonical blocks below limit from the key-value store
+// of db into its ancient store, together with their receipts and commit
+// signatures, at most freezerBatchLimit blocks per call. Side chain blocks at
+// the frozen heights are deleted. The genesis block and the hash to number
+// mappings are kept in the key-value store. It returns the number of frozen
+// blocks, and fails if db has no ancient store.
+func FreezeBlocks(db ethdb.Database, limit uint64) (uint64, error) {
+	frozen, err := db.Ancients()
+	if err != nil {
+		return 0, err
+	}
+	if frozen >= limit {
+		return 0, nil
+	}
+	first, last := frozen, limit-1
+	if last-first >= freezerBatchLimit {
+		last = first + freezerBatchLimit - 1
+	}
+	var hashes []common.Hash
+	if _, err := db.ModifyAncients(func(op ethdb.AncientWriteOp) error {
+		hashes, err = freezeRange(db, op, first, last)
+		return err
+	}); err != nil {
+		return 0, err
+	}
+	if err := db.Sync(); err != nil {
+		return 0, err
+	}
+
+	// Wipe out the frozen canonical blocks from the key-value store
+	batch := db.NewBatch()
+	for i, hash := range hashes {
+		number := first + uint64(i)
+		if number == 0 {
+			continue
+		}
+		DeleteBlockWithoutNumber(batch, hash, number)
+		DeleteCanonicalHash(batch, number)
+		if err := batch.Delete(blockCommitSigKey(number)); err != nil {
+			return 0, err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+	batch.Reset()
+
+	// Wipe out the side chains at the frozen heights
+	for i, canonical := range hashes {
+		number := first + uint64(i)
+		if number == 0 {
+			continue
+		}
+		for _, hash := range ReadAllHashes(db, number) {
+			if hash != canonical {
+				DeleteBlock(batch, hash, number)
+			}
+		}
+		if batch.ValueSize() >= ethdb.IdealBatchSize {
+			if err := batch.Write(); err != nil {
+				return 0, err
+			}
+			batch.Reset()
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return 0, err
+	}
+	return uint64(len(hashes)), nil
+}
+
+// freezeRange appends the canonical blocks from first to last (inclusive) in
+// the key-value store of db to the ancient store, and returns their hashes.
+func freezeRange(db ethdb.KeyValueReader, op ethdb.AncientWriteOp, first, last uint64) ([]common.Hash, error) {
+	hashes := make([]common.Hash, 0, last-first+1)
+	for number := first; number <= last; number++ {
+		hash, _ := db.Get(headerHashKey(number))
+		if len(hash) == 0 {
+			return nil, fmt.Errorf("canonical hash missing, can't freeze block %d", number)
+		}
+		h := common.BytesToHash(hash)
+		header, _ := db.Get(headerKey(number, h))
+		if len(header) == 0 {
+			return nil, fmt.Errorf("block header missing, can't freeze block %d", number)
+		}
+		body, _ := db.Get(blockBodyKey(number, h))
+		if len(body) == 0 {
+			return nil, fmt.Errorf("block body missing, can't freeze block %d", number)
+		}
+		receipts, _ := db.Get(blockReceiptsKey(number, h))
+		if len(receipts) == 0 {
+			return nil, fmt.Errorf("block receipts missing, can't freeze block %d", number)
+		}
+		// The genesis block has no commit signature
+		sig, _ := db.Get(blockCommitSigKey(number))
+
+		if err := op.AppendRaw(ChainFreezerHashTable, number, hash); err != nil {
+			return nil, fmt.Errorf("can't write hash to freezer: %v", err)
+		}
+		if err := op.AppendRaw(ChainFreezerHeaderTable, number, header); err != nil {
+			return nil, fmt.Errorf("can't write header to freezer: %v", err)
+		}
+		if err := op.AppendRaw(ChainFreezerBodiesTable, number, body); err != nil {
+			return nil, fmt.Errorf("can't write body to freezer: %v", err)
+		}
+		if err := op.AppendRaw(ChainFreezerReceiptTable, number, receipts); err != nil {
+			return nil, fmt.Errorf("can't write receipts to freezer: %v", err)
+		}
+		if err := op.AppendRaw(ChainFreezerCommitSigTable, number, sig); err != nil {
+			return nil, fmt.Errorf("can't write commit signature to freezer: %v", err)
+		}
+		hashes = append(hashes, h)
+	}
+	return hashes, nil
+}
@@ -0,0 +1,111 @@
+package rawdb
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+// Tests that frozen blocks are moved out of the key-value store and are still
+// served by the accessors from the ancient store.
+func TestFreezeBlocks(t *testing.T) {
+	db, err := NewDatabaseWithFreezer(memorydb.New(), t.TempDir(), "", false)
+	if err != nil {
+		t.Fatalf("failed to create database with freezer: %v", err)
+	}
+	defer db.Close()
+
+	// Write a canonical chain of 6 blocks and a side block at height 2
+	var (
+		blocks []*types.Block
+		parent common.Hash
+	)
+	for i := 0; i < 6; i++ {
+		header := blockfactory.NewTestHeader().With().Number(big.NewInt(int64(i))).ParentHash(parent).Header()
+		block := types.NewBlockWithHeader(header)
+		writeFreezerTestBlock(t, db, block, true)
+		blocks = append(blocks, block)
+		parent = block.Hash()
+	}
+	side := types.NewBlockWithHeader(blockfactory.NewTestHeader().With().
+		Number(big.NewInt(2)).ParentHash(blocks[1].Hash()).Extra([]byte("side")).Header())
+	writeFreezerTestBlock(t, db, side, false)
+
+	frozen, err := FreezeBlocks(db, 4)
+	if err != nil {
+		t.Fatalf("failed to freeze blocks: %v", err)
+	}
+	if frozen != 4 {
+		t.Fatalf("frozen blocks mismatch: have %d, want %d", frozen, 4)
+	}
+	if ancients, _ := db.Ancients(); ancients != 4 {
+		t.Fatalf("ancients mismatch: have %d, want %d", ancients, 4)
+	}
+	for _, block := range blocks {
+		number, hash := block.NumberU64(), block.Hash()
+		if have := ReadCanonicalHash(db, number); have != hash {
+			t.Errorf("block %d: canonical hash mismatch: have %x, want %x", number, have, hash)
+		}
+		if entry := ReadBlock(db, hash, number); entry == nil || entry.Hash() != hash {
+			t.Errorf("block %d: block not found", number)
+		}
+		if !HasHeader(db, hash, number) || !HasBody(db, hash, number) || !HasReceipts(db, hash, number) {
+			t.Errorf("block %d: block data not found", number)
+		}
+		if receipts := ReadReceipts(db, hash, number, nil); len(receipts) != 1 {
+			t.Errorf("block %d: receipts mismatch: have %d, want %d", number, len(receipts), 1)
+		}
+		if number > 0 {
+			if sig, err := ReadBlockCommitSig(db, number); err != nil || !bytes.Equal(sig, freezerTestSig(number)) {
+				t.Errorf("block %d: commit signature mismatch: have %x, want %x", number, sig, freezerTestSig(number))
+			}
+		}
+		// Frozen blocks but the genesis must be gone from the key-value store
+		inKV, _ := db.Has(headerKey(number, hash))
+		if want := number == 0 || number >= 4; inKV != want {
+			t.Errorf("block %d: header in key-value store: have %v, want %v", number, inKV, want)
+		}
+		if n := ReadHeaderNumber(db, hash); n == nil || *n != number {
+			t.Errorf("block %d: hash to number mapping not found", number)
+		}
+	}
+	if HasHeader(db, side.Hash(), side.NumberU64()) {
+		t.Errorf("side block not deleted")
+	}
+	if frozen, err := FreezeBlocks(db, 4); err != nil || frozen != 0 {
+		t.Errorf("refreeze: have %d, %v, want 0, nil", frozen, err)
+	}
+}
+
+func writeFreezerTestBlock(t *testing.T, db ethdb.Database, block *types.Block, canonical bool) {
+	number, hash := block.NumberU64(), block.Hash()
+	receipts := types.Receipts{{Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: number, Logs: []*types.Log{}}}
+	if err := WriteBlock(db, block); err != nil {
+		t.Fatalf("failed to write block %d: %v", number, err)
+	}
+	if err := WriteReceipts(db, hash, number, receipts); err != nil {
+		t.Fatalf("failed to write receipts %d: %v", number, err)
+	}
+	if !canonical {
+		return
+	}
+	if err := WriteCanonicalHash(db, hash, number); err != nil {
+		t.Fatalf("failed to write canonical hash %d: %v", number, err)
+	}
+	if number > 0 {
+		if err := WriteBlockCommitSig(db, number, freezerTestSig(number)); err != nil {
+			t.Fatalf("failed to write commit signature %d: %v", number, err)
+		}
+	}
+	WriteHeadHeaderHash(db, hash)
+}
+
+func freezerTestSig(number uint64) []byte {
+	return bytes.Repeat([]byte{byte(number)}, 100)
+}
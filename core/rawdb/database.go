@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	ethRawDB "github.com/ethereum/go-ethereum/core/rawdb"
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/ethdb/leveldb"
 	"github.com/ethereum/go-ethereum/ethdb/memorydb"
@@ -36,6 +37,9 @@ import (
 
 var errNotSupported = errors.New("not supported")
 
+// freezerTableSize defines the maximum size of freezer data files.
+const freezerTableSize = 2 * 1000 * 1000 * 1000
+
 // convertLegacyFn takes a raw freezer entry in an older format and
 // returns it in the new format.
 type convertLegacyFn = func([]byte) ([]byte, error)
@@ -158,7 +162,6 @@ func NewDatabase(db ethdb.KeyValueStore) ethdb.Database {
 
 // resolveChainFreezerDir is a helper function which resolves the absolute path
 // of chain freezer by considering backward compatibility.
-// This function is NOT used, just ported over from the Ethereum
 func resolveChainFreezerDir(ancient string) string {
 	// Check if the chain freezer is already present in the specified
 	// sub folder, if not then two possibilities:
@@ -180,6 +183,60 @@ func resolveChainFreezerDir(ancient string) string {
 	return freezer
 }
 
+// NewDatabaseWithFreezer creates a high level database on top of a given key-
+// value data store with a freezer moving immutable chain segments into cold
+// storage. The freezer is durable and append-only, and does not support batch
+// writes.
+func NewDatabaseWithFreezer(db ethdb.KeyValueStore, ancient string, namespace string, readonly bool) (ethdb.Database, error) {
+	// Create the idle freezer instance
+	frdb, err := ethRawDB.NewFreezer(resolveChainFreezerDir(ancient), namespace, readonly, freezerTableSize, chainFreezerNoSnappy)
+	if err != nil {
+		return nil, err
+	}
+	// Since the freezer can be stored separately from the key-value database,
+	// make sure the two are not mixed up across chains or shards, and that
+	// there is no gap between them:
+	//
+	//   - If the key-value store is empty, we have a new database, or a
+	//     database that is going to be initialized, so nothing to validate.
+	//   - If neither is empty, the genesis hashes must be identical, and the
+	//     key-value store must continue where the freezer left off.
+	//   - If the freezer is empty, nothing must have been moved out of the
+	//     key-value store yet, otherwise the ancient directory is wrong.
+	if kvgenesis, _ := db.Get(headerHashKey(0)); len(kvgenesis) > 0 {
+		if frozen, _ := frdb.Ancients(); frozen > 0 {
+			frgenesis, err := frdb.Ancient(ChainFreezerHashTable, 0)
+			if err != nil {
+				frdb.Close()
+				return nil, fmt.Errorf("failed to retrieve genesis from ancient %v", err)
+			} else if !bytes.Equal(kvgenesis, frgenesis) {
+				frdb.Close()
+				return nil, fmt.Errorf("genesis mismatch: %#x (leveldb) != %#x (ancients)", kvgenesis, frgenesis)
+			}
+			if kvhash, _ := db.Get(headerHashKey(frozen)); len(kvhash) == 0 {
+				// The block after the freezer is missing from the key-value
+				// store, reject if the key-value store has a more recent head.
+				if number := ReadHeaderNumber(db, ReadHeadHeaderHash(db)); number != nil && *number > frozen-1 {
+					frdb.Close()
+					return nil, fmt.Errorf("gap in the chain between ancients [0 - #%d] and leveldb [#%d - #%d]", frozen-1, frozen, *number)
+				}
+			}
+		} else if ReadHeadHeaderHash(db) != common.BytesToHash(kvgenesis) {
+			// The key-value store contains more than the genesis block, make
+			// sure block #1 was not frozen into another ancient directory.
+			if kvblob, _ := db.Get(headerHashKey(1)); len(kvblob) == 0 {
+				frdb.Close()
+				return nil, errors.New("ancient chain segments already extracted, please set the correct ancient directory")
+			}
+		}
+	}
+	return &freezerdb{
+		ancientRoot:   ancient,
+		KeyValueStore: db,
+		AncientStore:  frdb,
+	}, nil
+}
+
 // NewMemoryDatabase creates an ephemeral in-memory key-value database without a
 // freezer moving immutable chain segments into cold storage.
 func NewMemoryDatabase() ethdb.Database {
@@ -204,15 +261,32 @@ func NewLevelDBDatabase(file string, cache int, handles int, namespace string, r
 	return NewDatabase(db), nil
 }
 
+// NewLevelDBDatabaseWithFreezer creates a persistent key-value database with a
+// freezer moving immutable chain segments into cold storage.
+func NewLevelDBDatabaseWithFreezer(file string, cache int, handles int, ancient string, namespace string, readonly bool) (ethdb.Database, error) {
+	kvdb, err := leveldb.New(file, cache, handles, namespace, readonly)
+	if err != nil {
+		return nil, err
+	}
+	frdb, err := NewDatabaseWithFreezer(kvdb, ancient, namespace, readonly)
+	if err != nil {
+		kvdb.Close()
+		return nil, err
+	}
+	log.Info("Using LevelDB as the backing database with ancient store", "ancient", ancient)
+	return frdb, nil
+}
+
+// The supported database engines.
 const (
-	dbPebble  = "pebble"
-	dbLeveldb = "leveldb"
+	DBPebble  = "pebble"
+	DBLeveldb = "leveldb"
 )
 
-// hasPreexistingDb checks the given data directory whether a database is already
+// PreexistingDatabase checks the given data directory whether a database is already
 // instantiated at that location, and if so, returns the type of database (or the
 // empty string).
-func hasPreexistingDb(path string) string {
+func PreexistingDatabase(path string) string {
 	if _, err := os.Stat(filepath.Join(path, "CURRENT")); err != nil {
 		return "" // No pre-existing db
 	}
@@ -220,9 +294,9 @@ func hasPreexistingDb(path string) string {
 		if err != nil {
 			panic(err) // only possible if the pattern is malformed
 		}
-		return dbPebble
+		return DBPebble
 	}
-	return dbLeveldb
+	return DBLeveldb
 }
 
 // OpenOptions contains the options to apply when opening a database.
@@ -244,11 +318,11 @@ type OpenOptions struct {
 //	db is non-existent |  leveldb default  |  specified type
 //	db is existent     |  from db          |  specified type (if compatible)
 func openKeyValueDatabase(o OpenOptions) (ethdb.Database, error) {
-	existingDb := hasPreexistingDb(o.Directory)
+	existingDb := PreexistingDatabase(o.Directory)
 	if len(existingDb) != 0 && len(o.Type) != 0 && o.Type != existingDb {
 		return nil, fmt.Errorf("db.engine choice was %v but found pre-existing %v database in specified data directory", o.Type, existingDb)
 	}
-	if o.Type == dbPebble || existingDb == dbPebble {
+	if o.Type == DBPebble || existingDb == DBPebble {
 		if PebbleEnabled {
 			log.Info("Using pebble as the backing database")
 			return NewPebbleDBDatabase(o.Directory, o.Cache, o.Handles, o.Namespace, o.ReadOnly)
@@ -256,7 +330,7 @@ func openKeyValueDatabase(o OpenOptions) (ethdb.Database, error) {
 			return nil, errors.New("db.engine 'pebble' not supported on this platform")
 		}
 	}
-	if len(o.Type) != 0 && o.Type != dbLeveldb {
+	if len(o.Type) != 0 && o.Type != DBLeveldb {
 		return nil, fmt.Errorf("unknown db.engine %v", o.Type)
 	}
 	log.Info("Using leveldb as the backing database")
@@ -264,6 +338,25 @@ func openKeyValueDatabase(o OpenOptions) (ethdb.Database, error) {
 	return NewLevelDBDatabase(o.Directory, o.Cache, o.Handles, o.Namespace, o.ReadOnly)
 }
 
+// Open opens both a disk-based key-value database such as leveldb or pebble, but also
+// integrates it with a freezer database -- if the AncientsDirectory option has been
+// set on the provided OpenOptions.
+func Open(o OpenOptions) (ethdb.Database, error) {
+	kvdb, err := openKeyValueDatabase(o)
+	if err != nil {
+		return nil, err
+	}
+	if len(o.AncientsDirectory) == 0 {
+		return kvdb, nil
+	}
+	frdb, err := NewDatabaseWithFreezer(kvdb, o.AncientsDirectory, o.Namespace, o.ReadOnly)
+	if err != nil {
+		kvdb.Close()
+		return nil, err
+	}
+	return frdb, nil
+}
+
 type counter uint64
 
 func (c counter) String() string {
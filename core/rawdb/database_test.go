@@ -15,3 +15,45 @@
 // along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
 
 package rawdb
+
+import (
+	"testing"
+)
+
+// Tests that Open detects the engine of an existing database and rejects a
+// conflicting engine choice.
+func TestOpenEngine(t *testing.T) {
+	if !PebbleEnabled {
+		t.Skip("pebble is not supported on this platform")
+	}
+	dir := t.TempDir()
+	if engine := PreexistingDatabase(dir); engine != "" {
+		t.Fatalf("engine of empty directory: have %q, want none", engine)
+	}
+	db, err := Open(OpenOptions{Type: DBPebble, Directory: dir, Cache: 16, Handles: 16})
+	if err != nil {
+		t.Fatalf("failed to create pebble database: %v", err)
+	}
+	if err := db.Put([]byte("key"), []byte("value")); err != nil {
+		t.Fatalf("failed to write: %v", err)
+	}
+	db.Close()
+
+	if engine := PreexistingDatabase(dir); engine != DBPebble {
+		t.Fatalf("detected engine: have %q, want %q", engine, DBPebble)
+	}
+	if _, err := Open(OpenOptions{Type: DBLeveldb, Directory: dir, Cache: 16, Handles: 16}); err == nil {
+		t.Fatalf("opened pebble database as leveldb")
+	}
+	db, err = Open(OpenOptions{Directory: dir, Cache: 16, Handles: 16, AncientsDirectory: t.TempDir()})
+	if err != nil {
+		t.Fatalf("failed to reopen pebble database: %v", err)
+	}
+	defer db.Close()
+	if value, err := db.Get([]byte("key")); err != nil || string(value) != "value" {
+		t.Fatalf("value mismatch: have %q, %v, want %q", value, err, "value")
+	}
+	if _, err := db.Ancients(); err != nil {
+		t.Fatalf("ancient store not opened: %v", err)
+	}
+}
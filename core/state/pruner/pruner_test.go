@@ -0,0 +1,172 @@
+package pruner
+
+import (
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/harmony-one/harmony/block"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/state/snapshot"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+var (
+	contractAddr  = common.HexToAddress("0x1000")
+	validatorAddr = common.HexToAddress("0x2000")
+	accountAddr   = common.HexToAddress("0x3000")
+)
+
+func writeHeadBlock(t *testing.T, db rawdb.DatabaseWriter, number int64, root common.Hash) *types.Block {
+	t.Helper()
+	var header *block.Header = blockfactory.NewTestHeader().With().
+		Number(big.NewInt(number)).Root(root).Header()
+	b := types.NewBlockWithHeader(header)
+	if err := rawdb.WriteBlock(db, b); err != nil {
+		t.Fatal(err)
+	}
+	if err := rawdb.WriteCanonicalHash(db, b.Hash(), b.NumberU64()); err != nil {
+		t.Fatal(err)
+	}
+	if err := rawdb.WriteHeadBlockHash(db, b.Hash()); err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func commitState(t *testing.T, sdb state.Database, root common.Hash, update func(*state.DB)) common.Hash {
+	t.Helper()
+	statedb, err := state.New(root, sdb, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	update(statedb)
+	root, err = statedb.Commit(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sdb.TrieDB().Commit(root, false); err != nil {
+		t.Fatal(err)
+	}
+	return root
+}
+
+// newTestState returns a database whose head state at block 2 follows a
+// stale state, along with the genesis, stale and head state roots. The
+// snapshot of the head state is journaled.
+func newTestState(t *testing.T) (ethdb.Database, common.Hash, common.Hash, common.Hash) {
+	t.Helper()
+	var (
+		db  = rawdb.NewMemoryDatabase()
+		sdb = state.NewDatabase(db)
+	)
+	genesisRoot := commitState(t, sdb, common.Hash{}, func(statedb *state.DB) {
+		statedb.AddBalance(accountAddr, big.NewInt(1))
+	})
+	writeHeadBlock(t, db, 0, genesisRoot)
+
+	staleRoot := commitState(t, sdb, genesisRoot, func(statedb *state.DB) {
+		statedb.AddBalance(accountAddr, big.NewInt(1))
+		statedb.SetState(contractAddr, common.Hash{1}, common.Hash{1})
+	})
+	headRoot := commitState(t, sdb, staleRoot, func(statedb *state.DB) {
+		statedb.AddBalance(accountAddr, big.NewInt(1))
+		statedb.SetCode(contractAddr, []byte{0x60, 0x00}, false)
+		statedb.SetState(contractAddr, common.Hash{1}, common.Hash{2})
+		statedb.SetCode(validatorAddr, []byte("validator wrapper"), true)
+	})
+	writeHeadBlock(t, db, 2, headRoot)
+
+	snaps, err := snapshot.New(snapshot.Config{CacheSize: 16}, db, sdb.TrieDB(), headRoot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := snaps.Journal(headRoot); err != nil {
+		t.Fatal(err)
+	}
+	return db, genesisRoot, staleRoot, headRoot
+}
+
+// checkPrunedState checks that only the stale state of newTestState is
+// pruned, and that the state bloom filter is removed from datadir.
+func checkPrunedState(t *testing.T, db ethdb.Database, datadir string, genesisRoot, staleRoot, headRoot common.Hash) {
+	t.Helper()
+	if rawdb.HasLegacyTrieNode(db, staleRoot) {
+		t.Error("stale state root not pruned")
+	}
+	for _, root := range []common.Hash{genesisRoot, headRoot} {
+		if !rawdb.HasLegacyTrieNode(db, root) {
+			t.Errorf("state root %x pruned", root)
+		}
+	}
+	statedb, err := state.New(headRoot, state.NewDatabase(db), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if balance := statedb.GetBalance(accountAddr); balance.Cmp(big.NewInt(3)) != 0 {
+		t.Errorf("have balance %v, want 3", balance)
+	}
+	if value := statedb.GetState(contractAddr, common.Hash{1}); value != (common.Hash{2}) {
+		t.Errorf("have storage %x, want %x", value, common.Hash{2})
+	}
+	if code := statedb.GetCode(contractAddr); len(code) != 2 {
+		t.Errorf("have code %x", code)
+	}
+	if code := statedb.GetCode(validatorAddr); string(code) != "validator wrapper" {
+		t.Errorf("have validator code %q", code)
+	}
+	if files, _ := os.ReadDir(datadir); len(files) != 0 {
+		t.Errorf("state bloom filter not removed")
+	}
+}
+
+func TestPruneState(t *testing.T) {
+	db, genesisRoot, staleRoot, headRoot := newTestState(t)
+	datadir := t.TempDir()
+	pruner, err := NewPruner(db, Config{Datadir: datadir, BloomSize: 256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := pruner.Prune(headRoot); err != nil {
+		t.Fatal(err)
+	}
+	checkPrunedState(t, db, datadir, genesisRoot, staleRoot, headRoot)
+}
+
+func TestRecoverPruning(t *testing.T) {
+	db, genesisRoot, staleRoot, headRoot := newTestState(t)
+	datadir := t.TempDir()
+
+	// nothing is recovered without a state bloom filter
+	if err := RecoverPruning(datadir, db); err != nil {
+		t.Fatal(err)
+	}
+	if !rawdb.HasLegacyTrieNode(db, staleRoot) {
+		t.Fatal("stale state root pruned without a state bloom filter")
+	}
+
+	// the pruning is interrupted once the state bloom filter is committed
+	pruner, err := NewPruner(db, Config{Datadir: datadir, BloomSize: 256})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := snapshot.GenerateTrie(pruner.snaptree, headRoot, db, pruner.stateBloom); err != nil {
+		t.Fatal(err)
+	}
+	if err := extractGenesis(db, pruner.stateBloom); err != nil {
+		t.Fatal(err)
+	}
+	filterName := bloomFilterName(datadir, headRoot)
+	if err := pruner.stateBloom.Commit(filterName, filterName+stateBloomFileTempSuffix); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RecoverPruning(datadir, db); err != nil {
+		t.Fatal(err)
+	}
+	checkPrunedState(t, db, datadir, genesisRoot, staleRoot, headRoot)
+}
@@ -1169,6 +1169,39 @@ func (db *DB) Commit(deleteEmptyObjects bool) (common.Hash, error) {
 	return root, nil
 }
 
+// PrepareAccessList handles the preparatory steps for executing a state transition with
+// regards to EIP-2929 and EIP-2930:
+//
+// - Add sender to access list (2929)
+// - Add destination to access list (2929)
+// - Add precompiles to access list (2929)
+// - Add the contents of the optional tx access list (2930)
+//
+// It also clears the EIP-1153 transient storage, which lives for a single
+// transaction.
+//
+// This method should only be called if Berlin rules are active.
+func (db *DB) PrepareAccessList(sender common.Address, dst *common.Address, precompiles []common.Address, list types2.AccessList) {
+	// Clear out any leftover from previous executions
+	db.accessList = newAccessList()
+	db.transientStorage = newTransientStorage()
+
+	db.AddAddressToAccessList(sender)
+	if dst != nil {
+		db.AddAddressToAccessList(*dst)
+		// If it's a create-tx, the destination will be added inside evm.create
+	}
+	for _, addr := range precompiles {
+		db.AddAddressToAccessList(addr)
+	}
+	for _, el := range list {
+		db.AddAddressToAccessList(el.Address)
+		for _, key := range el.StorageKeys {
+			db.AddSlotToAccessList(el.Address, key)
+		}
+	}
+}
+
 // AddAddressToAccessList adds the given address to the access list
 func (db *DB) AddAddressToAccessList(addr common.Address) {
 	if db.accessList.AddAddress(addr) {
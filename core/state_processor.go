@@ -284,6 +284,17 @@ func ApplyTransaction(bc ChainContext, author *common.Address, gp *GasPool, stat
 		)
 	}
 
+	if tx.Type() != types.LegacyTxType && !config.IsBerlin(header.Epoch()) {
+		return nil, nil, nil, 0, errors.Wrapf(
+			types.ErrTxTypeNotSupported, "cannot handle transaction type %d at epoch %v", tx.Type(), header.Epoch(),
+		)
+	}
+	if tx.Type() == types.DynamicFeeTxType && !config.IsLondon(header.Epoch()) {
+		return nil, nil, nil, 0, errors.Wrapf(
+			types.ErrTxTypeNotSupported, "cannot handle transaction type %d at epoch %v", tx.Type(), header.Epoch(),
+		)
+	}
+
 	var signer types.Signer
 	if tx.IsEthCompatible() {
 		if !config.IsEthCompatible(header.Epoch()) {
@@ -293,7 +304,7 @@ func ApplyTransaction(bc ChainContext, author *common.Address, gp *GasPool, stat
 	} else {
 		signer = types.MakeSigner(config, header.Epoch())
 	}
-	msg, err := tx.AsMessage(signer)
+	msg, err := tx.AsMessage(signer, header.BaseFee())
 
 	// skip signer err for additiononly tx
 	if err != nil {
@@ -332,6 +343,7 @@ func ApplyTransaction(bc ChainContext, author *common.Address, gp *GasPool, stat
 	// Create a new receipt for the transaction, storing the intermediate root and gas used by the tx
 	// based on the eip phase, we're passing whether the root touch-delete accounts.
 	receipt := types.NewReceipt(root, failedExe, *usedGas)
+	receipt.Type = tx.Type()
 	receipt.TxHash = tx.Hash()
 	receipt.GasUsed = result.UsedGas
 	// if the transaction created a contract, store the creation address in the receipt.
@@ -24,6 +24,7 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/internal/params"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/harmony-one/harmony/numeric"
 	"github.com/harmony-one/harmony/shard"
@@ -71,6 +72,8 @@ type StateTransition struct {
 	msg        Message
 	gas        uint64
 	gasPrice   *big.Int
+	gasFeeCap  *big.Int
+	gasTipCap  *big.Int
 	initialGas uint64
 	value      *big.Int
 	data       []byte
@@ -85,6 +88,8 @@ type Message interface {
 	To() *common.Address
 
 	GasPrice() *big.Int
+	GasFeeCap() *big.Int
+	GasTipCap() *big.Int
 	Gas() uint64
 	Value() *big.Int
 
@@ -93,6 +98,7 @@ type Message interface {
 	Data() []byte
 	Type() types.TransactionType
 	BlockNum() *big.Int
+	AccessList() types.AccessList
 }
 
 // ExecutionResult is the return value from a transaction committed to the DB
@@ -132,13 +138,15 @@ func (result *ExecutionResult) Revert() []byte {
 // NewStateTransition initialises and returns a new state transition object.
 func NewStateTransition(evm *vm.EVM, msg Message, gp *GasPool) *StateTransition {
 	return &StateTransition{
-		gp:       gp,
-		evm:      evm,
-		msg:      msg,
-		gasPrice: msg.GasPrice(),
-		value:    msg.Value(),
-		data:     msg.Data(),
-		state:    evm.StateDB,
+		gp:        gp,
+		evm:       evm,
+		msg:       msg,
+		gasPrice:  msg.GasPrice(),
+		gasFeeCap: msg.GasFeeCap(),
+		gasTipCap: msg.GasTipCap(),
+		value:     msg.Value(),
+		data:      msg.Data(),
+		state:     evm.StateDB,
 	}
 }
 
@@ -177,10 +185,16 @@ func (st *StateTransition) useGas(amount uint64) error {
 
 func (st *StateTransition) buyGas() error {
 	mgval := new(big.Int).Mul(new(big.Int).SetUint64(st.msg.Gas()), st.gasPrice)
-	if have := st.state.GetBalance(st.msg.From()); have.Cmp(mgval) < 0 {
+	// A dynamic fee transaction must afford its fee cap even though only the
+	// effective gas price is charged.
+	balanceCheck := mgval
+	if st.gasFeeCap != nil {
+		balanceCheck = new(big.Int).Mul(new(big.Int).SetUint64(st.msg.Gas()), st.gasFeeCap)
+	}
+	if have := st.state.GetBalance(st.msg.From()); have.Cmp(balanceCheck) < 0 {
 		return errors.Wrapf(
 			errInsufficientBalanceForGas,
-			"had: %s but need: %s", have.String(), mgval.String(),
+			"had: %s but need: %s", have.String(), balanceCheck.String(),
 		)
 	}
 	if err := st.gp.SubGas(st.msg.Gas()); err != nil {
@@ -204,6 +218,28 @@ func (st *StateTransition) preCheck() error {
 			return ErrNonceTooLow
 		}
 	}
+	// Make sure that the transaction gasFeeCap is greater than the baseFee (post london)
+	if st.evm.ChainConfig().IsLondon(st.evm.EpochNumber) && st.evm.BaseFee != nil {
+		// Skip the checks if gas fields are zero and baseFee was explicitly disabled (eth_call)
+		if !st.evm.Config().NoBaseFee || st.gasFeeCap.BitLen() > 0 || st.gasTipCap.BitLen() > 0 {
+			if l := st.gasFeeCap.BitLen(); l > 256 {
+				return errors.Wrapf(ErrFeeCapVeryHigh, "address %v, maxFeePerGas bit length: %d",
+					st.msg.From().Hex(), l)
+			}
+			if l := st.gasTipCap.BitLen(); l > 256 {
+				return errors.Wrapf(ErrTipVeryHigh, "address %v, maxPriorityFeePerGas bit length: %d",
+					st.msg.From().Hex(), l)
+			}
+			if st.gasFeeCap.Cmp(st.gasTipCap) < 0 {
+				return errors.Wrapf(ErrTipAboveFeeCap, "address %v, maxPriorityFeePerGas: %s, maxFeePerGas: %s",
+					st.msg.From().Hex(), st.gasTipCap, st.gasFeeCap)
+			}
+			if st.gasFeeCap.Cmp(st.evm.BaseFee) < 0 {
+				return errors.Wrapf(ErrFeeCapTooLow, "address %v, maxFeePerGas: %s baseFee: %s",
+					st.msg.From().Hex(), st.gasFeeCap, st.evm.BaseFee)
+			}
+		}
+	}
 	return st.buyGas()
 }
 
@@ -214,6 +250,14 @@ func (st *StateTransition) TransitionDb() (ExecutionResult, error) {
 	if err := st.preCheck(); err != nil {
 		return ExecutionResult{}, err
 	}
+	if config := st.evm.Config(); config.Debug {
+		if tracer, ok := config.Tracer.(vm.TxTracer); ok {
+			tracer.CaptureTxStart(st.initialGas)
+			defer func() {
+				tracer.CaptureTxEnd(st.gas)
+			}()
+		}
+	}
 	msg := st.msg
 	sender := vm.AccountRef(msg.From())
 	homestead := st.evm.ChainConfig().IsS3(st.evm.EpochNumber) // s3 includes homestead
@@ -221,7 +265,7 @@ func (st *StateTransition) TransitionDb() (ExecutionResult, error) {
 	contractCreation := msg.To() == nil
 
 	// Pay intrinsic gas
-	gas, err := vm.IntrinsicGas(st.data, contractCreation, homestead, istanbul, false)
+	gas, err := vm.IntrinsicGas(st.data, st.msg.AccessList(), contractCreation, homestead, istanbul, false)
 	if err != nil {
 		return ExecutionResult{}, err
 	}
@@ -231,6 +275,10 @@ func (st *StateTransition) TransitionDb() (ExecutionResult, error) {
 
 	evm := st.evm
 
+	if rules := evm.ChainConfig().Rules(evm.EpochNumber); rules.IsBerlin {
+		st.state.PrepareAccessList(msg.From(), msg.To(), vm.ActivePrecompiles(rules), msg.AccessList())
+	}
+
 	var ret []byte
 	// All VM errors are valid except for insufficient balance, therefore returned separately
 	var vmErr error
@@ -263,8 +311,13 @@ func (st *StateTransition) TransitionDb() (ExecutionResult, error) {
 }
 
 func (st *StateTransition) refundGas() {
-	// Apply refund counter, capped to half of the used gas.
-	refund := st.gasUsed() / 2
+	// Apply refund counter, capped to half of the used gas, or to a fifth
+	// of it after EIP-3529.
+	refundQuotient := params.RefundQuotient
+	if st.evm.ChainConfig().IsLondon(st.evm.EpochNumber) {
+		refundQuotient = params.RefundQuotientEIP3529
+	}
+	refund := st.gasUsed() / refundQuotient
 	if refund > st.state.GetRefund() {
 		refund = st.state.GetRefund()
 	}
@@ -279,12 +332,22 @@ func (st *StateTransition) refundGas() {
 	st.gp.AddGas(st.gas)
 }
 
+// collectGas pays out the fees of the used gas. After the London fork the
+// base fee portion is burned, unless the whole fee goes to the fee collectors.
 func (st *StateTransition) collectGas() {
 	if config := st.evm.ChainConfig(); !config.IsStaking(st.evm.EpochNumber) {
 		// Before staking epoch, add the fees to the block producer
+		price := st.gasPrice
+		if config.IsLondon(st.evm.EpochNumber) && st.evm.BaseFee != nil {
+			price = new(big.Int).Sub(st.gasPrice, st.evm.BaseFee)
+			if price.Sign() < 0 {
+				// only possible for eth_call with the base fee disabled
+				price.SetUint64(0)
+			}
+		}
 		txFee := new(big.Int).Mul(
 			new(big.Int).SetUint64(st.gasUsed()),
-			st.gasPrice,
+			price,
 		)
 		st.state.AddBalance(st.evm.Coinbase, txFee)
 	} else if feeCollectors := shard.Schedule.InstanceForEpoch(
@@ -324,7 +387,7 @@ func (st *StateTransition) StakingTransitionDb() (usedGas uint64, err error) {
 	istanbul := st.evm.ChainConfig().IsIstanbul(st.evm.EpochNumber)
 
 	// Pay intrinsic gas
-	gas, err := vm.IntrinsicGas(st.data, false, homestead, istanbul, msg.Type() == types.StakeCreateVal)
+	gas, err := vm.IntrinsicGas(st.data, nil, false, homestead, istanbul, msg.Type() == types.StakeCreateVal)
 
 	if err != nil {
 		return 0, err
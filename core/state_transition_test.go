@@ -111,7 +111,7 @@ func TestCollectGas(t *testing.T) {
 	from, _ := tx.SenderAddress()
 	initialBalance := big.NewInt(2e18)
 	db.AddBalance(from, initialBalance)
-	msg, _ := tx.AsMessage(types.NewEIP155Signer(common.Big2))
+	msg, _ := tx.AsMessage(types.NewEIP155Signer(common.Big2), nil)
 	ctx := NewEVMContext(msg, header, chain, nil /* coinbase is nil, no block reward */)
 	ctx.TxType = types.SameShardTx
 
@@ -187,7 +187,7 @@ func TestCollectGasRounding(t *testing.T) {
 	from, _ := tx.SenderAddress()
 	initialBalance := big.NewInt(2e18)
 	db.AddBalance(from, initialBalance)
-	msg, _ := tx.AsMessage(types.NewEIP155Signer(common.Big2))
+	msg, _ := tx.AsMessage(types.NewEIP155Signer(common.Big2), nil)
 	ctx := NewEVMContext(msg, header, chain, nil /* coinbase is nil, no block reward */)
 	ctx.TxType = types.SameShardTx
 
@@ -288,11 +288,17 @@ func (l *txList) Add(tx types.PoolTransaction, priceBump uint64) (bool, types.Po
 	// If there's an older better transaction, abort
 	old := l.txs.Get(tx.Nonce())
 	if old != nil {
-		threshold := new(big.Int).Div(new(big.Int).Mul(old.GasPrice(), big.NewInt(100+int64(priceBump))), big.NewInt(100))
-		// Have to ensure that the new gas price is higher than the old gas
-		// price as well as checking the percentage threshold to ensure that
-		// this is accurate for low (Wei-level) gas price replacements
-		if old.GasPrice().Cmp(tx.GasPrice()) >= 0 || threshold.Cmp(tx.GasPrice()) > 0 {
+		// Have to ensure that the new gas fee cap and tip are both higher than
+		// the old ones as well as checking the percentage threshold to ensure
+		// that this is accurate for low (Wei-level) gas price replacements.
+		// Legacy transactions have equal fee cap and tip, their gas price.
+		if old.GasFeeCap().Cmp(tx.GasFeeCap()) >= 0 || old.GasTipCap().Cmp(tx.GasTipCap()) >= 0 {
+			return false, nil
+		}
+		bump, hundred := big.NewInt(100+int64(priceBump)), big.NewInt(100)
+		thresholdFeeCap := new(big.Int).Div(new(big.Int).Mul(old.GasFeeCap(), bump), hundred)
+		thresholdTip := new(big.Int).Div(new(big.Int).Mul(old.GasTipCap(), bump), hundred)
+		if tx.GasFeeCap().Cmp(thresholdFeeCap) < 0 || tx.GasTipCap().Cmp(thresholdTip) < 0 {
 			return false, nil
 		}
 	}
@@ -445,33 +451,56 @@ func (l *txList) Flatten() types.PoolTransactions {
 }
 
 // priceHeap is a heap.Interface implementation over transactions for retrieving
-// price-sorted transactions to discard when the pool fills up.
-type priceHeap []types.PoolTransaction
+// price-sorted transactions to discard when the pool fills up. Once a base fee
+// is set, transactions are sorted by the effective tip they would pay.
+type priceHeap struct {
+	baseFee *big.Int // heap should always be re-sorted after baseFee is changed
+	list    []types.PoolTransaction
+}
 
-func (h priceHeap) Len() int      { return len(h) }
-func (h priceHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *priceHeap) Len() int      { return len(h.list) }
+func (h *priceHeap) Swap(i, j int) { h.list[i], h.list[j] = h.list[j], h.list[i] }
 
-func (h priceHeap) Less(i, j int) bool {
-	// Sort primarily by price, returning the cheaper one
-	switch h[i].GasPrice().Cmp(h[j].GasPrice()) {
+func (h *priceHeap) Less(i, j int) bool {
+	switch h.cmp(h.list[i], h.list[j]) {
 	case -1:
 		return true
 	case 1:
 		return false
+	default:
+		// If the prices match, stabilize via nonces (high nonce is worse)
+		return h.list[i].Nonce() > h.list[j].Nonce()
+	}
+}
+
+// cmp compares the prices a and b pay, the effective tip first if the base
+// fee is known, then the fee cap and finally the tip cap.
+func (h *priceHeap) cmp(a, b types.PoolTransaction) int {
+	if h.baseFee != nil {
+		// Compare effective tips if baseFee is specified
+		aTip, _ := types.EffectiveGasTip(a.GasTipCap(), a.GasFeeCap(), h.baseFee)
+		bTip, _ := types.EffectiveGasTip(b.GasTipCap(), b.GasFeeCap(), h.baseFee)
+		if c := aTip.Cmp(bTip); c != 0 {
+			return c
+		}
 	}
-	// If the prices match, stabilize via nonces (high nonce is worse)
-	return h[i].Nonce() > h[j].Nonce()
+	// Compare fee caps if baseFee is not specified or effective tips are equal
+	if c := a.GasFeeCap().Cmp(b.GasFeeCap()); c != 0 {
+		return c
+	}
+	// Compare tips if effective tips and fee caps are equal
+	return a.GasTipCap().Cmp(b.GasTipCap())
 }
 
 func (h *priceHeap) Push(x interface{}) {
-	*h = append(*h, x.(types.PoolTransaction))
+	h.list = append(h.list, x.(types.PoolTransaction))
 }
 
 func (h *priceHeap) Pop() interface{} {
-	old := *h
+	old := h.list
 	n := len(old)
 	x := old[n-1]
-	*h = old[0 : n-1]
+	h.list = old[0 : n-1]
 	return x
 }
 
@@ -502,27 +531,41 @@ func (l *txPricedList) Put(tx types.PoolTransaction) {
 func (l *txPricedList) Removed() {
 	// Bump the stale counter, but exit if still too low (< 25%)
 	l.stales++
-	if l.stales <= len(*l.items)/4 {
+	if l.stales <= l.items.Len()/4 {
 		return
 	}
 	// Seems we've reached a critical number of stale transactions, reheap
-	reheap := make(priceHeap, 0, l.all.Count())
+	l.Reheap()
+}
 
-	l.stales, l.items = 0, &reheap
+// Reheap forcibly rebuilds the heap based on the current remote transaction set.
+func (l *txPricedList) Reheap() {
+	reheap := &priceHeap{
+		baseFee: l.items.baseFee,
+		list:    make([]types.PoolTransaction, 0, l.all.Count()),
+	}
+	l.stales, l.items = 0, reheap
 	l.all.Range(func(hash common.Hash, tx types.PoolTransaction) bool {
-		*l.items = append(*l.items, tx)
+		l.items.list = append(l.items.list, tx)
 		return true
 	})
 	heap.Init(l.items)
 }
 
+// SetBaseFee updates the base fee and triggers a re-heap. Note that Removed is
+// not necessary to call right before SetBaseFee when processing a new block.
+func (l *txPricedList) SetBaseFee(baseFee *big.Int) {
+	l.items.baseFee = baseFee
+	l.Reheap()
+}
+
 // Cap finds all the transactions below the given price threshold, drops them
 // from the priced list and returns them for further removal from the entire pool.
 func (l *txPricedList) Cap(threshold *big.Int, local *accountSet) types.PoolTransactions {
 	drop := make(types.PoolTransactions, 0, 128) // Remote underpriced transactions to drop
 	save := make(types.PoolTransactions, 0, 64)  // Local underpriced transactions to keep
 
-	for len(*l.items) > 0 {
+	for l.items.Len() > 0 {
 		// Discard stale transactions if found during cleanup
 		tx := heap.Pop(l.items).(types.PoolTransaction)
 		if l.all.Get(tx.Hash()) == nil {
@@ -530,7 +573,7 @@ func (l *txPricedList) Cap(threshold *big.Int, local *accountSet) types.PoolTran
 			continue
 		}
 		// Stop the discards if we've reached the threshold
-		if tx.GasPrice().Cmp(threshold) >= 0 {
+		if tx.GasTipCap().Cmp(threshold) >= 0 {
 			save = append(save, tx)
 			break
 		}
@@ -555,8 +598,8 @@ func (l *txPricedList) Underpriced(tx types.PoolTransaction, local *accountSet)
 		return false
 	}
 	// Discard stale price points if found at the heap start
-	for len(*l.items) > 0 {
-		head := types.PoolTransactions(*l.items)[0]
+	for l.items.Len() > 0 {
+		head := l.items.list[0]
 		if l.all.Get(head.Hash()) == nil {
 			l.stales--
 			heap.Pop(l.items)
@@ -565,12 +608,12 @@ func (l *txPricedList) Underpriced(tx types.PoolTransaction, local *accountSet)
 		break
 	}
 	// Check if the transaction is underpriced or not
-	if len(*l.items) == 0 {
+	if l.items.Len() == 0 {
 		utils.Logger().Error().Msg("Pricing query for empty pool") // This cannot happen, print to catch programming errors
 		return false
 	}
-	cheapest := types.PoolTransactions(*l.items)[0]
-	return cheapest.GasPrice().Cmp(tx.GasPrice()) >= 0
+	cheapest := l.items.list[0]
+	return l.items.cmp(cheapest, tx) >= 0
 }
 
 // Discard finds a number of most underpriced transactions, removes them from the
@@ -579,7 +622,7 @@ func (l *txPricedList) Discard(count int, local *accountSet) types.PoolTransacti
 	drop := make(types.PoolTransactions, 0, count) // Remote underpriced transactions to drop
 	save := make(types.PoolTransactions, 0, 64)    // Local underpriced transactions to keep
 
-	for len(*l.items) > 0 && count > 0 {
+	for l.items.Len() > 0 && count > 0 {
 		// Discard stale transactions if found during cleanup
 		tx := heap.Pop(l.items).(types.PoolTransaction)
 		if l.all.Get(tx.Hash()) == nil {
@@ -305,6 +305,8 @@ type TxPool struct {
 
 	homestead bool
 	istanbul  bool
+	eip2718   bool // Fork indicator whether we are using EIP-2718 type transactions.
+	eip1559   bool // Fork indicator whether we are using EIP-1559 type transactions.
 }
 
 // NewTxPool creates a new transaction pool to gather, sort and filter inbound
@@ -553,6 +555,12 @@ func (pool *TxPool) reset(oldHead, newHead *block.Header) {
 	pool.currentState = statedb
 	pool.pendingState = state.ManageState(statedb)
 	pool.currentMaxGas = newHead.GasLimit()
+	// The typed transactions are accepted from the head, also by a new pool
+	pool.eip2718 = pool.chainconfig.IsBerlin(newHead.Epoch())
+	pool.eip1559 = pool.chainconfig.IsLondon(newHead.Epoch())
+	if pool.eip1559 {
+		pool.priced.SetBaseFee(CalcBaseFee(pool.chainconfig, newHead))
+	}
 
 	// Inject any transactions discarded due to reorgs
 	utils.Logger().Debug().Int("count", len(reinject)).Msg("Reinjecting stale transactions")
@@ -730,6 +738,18 @@ func (pool *TxPool) validateTx(tx types.PoolTransaction, local bool) error {
 	if tx.ShardID() != pool.chain.CurrentBlock().ShardID() {
 		return errors.WithMessagef(ErrInvalidShard, "transaction shard is %d", tx.ShardID())
 	}
+	var accessList types.AccessList
+	if plainTx, ok := tx.(*types.Transaction); ok {
+		// Reject typed transactions until EIP-2718 activates
+		if !pool.eip2718 && plainTx.Type() != types.LegacyTxType {
+			return errors.WithMessagef(types.ErrTxTypeNotSupported, "transaction type is %d", plainTx.Type())
+		}
+		// Reject dynamic fee transactions until EIP-1559 activates
+		if !pool.eip1559 && plainTx.Type() == types.DynamicFeeTxType {
+			return errors.WithMessagef(types.ErrTxTypeNotSupported, "transaction type is %d", plainTx.Type())
+		}
+		accessList = plainTx.AccessList()
+	}
 	// For DOS prevention, reject excessively large transactions.
 	if tx.Size() >= types.MaxPoolTransactionDataSize {
 		return errors.WithMessagef(ErrOversizedData, "transaction size is %s", tx.Size().String())
@@ -743,6 +763,17 @@ func (pool *TxPool) validateTx(tx types.PoolTransaction, local bool) error {
 	if pool.currentMaxGas < tx.GasLimit() {
 		return errors.WithMessagef(ErrGasLimit, "transaction gas is %d", tx.GasLimit())
 	}
+	// Sanity check for extremely large numbers
+	if tx.GasFeeCap().BitLen() > 256 {
+		return ErrFeeCapVeryHigh
+	}
+	if tx.GasTipCap().BitLen() > 256 {
+		return ErrTipVeryHigh
+	}
+	// Ensure gasFeeCap is greater than or equal to gasTipCap.
+	if tx.GasFeeCap().Cmp(tx.GasTipCap()) < 0 {
+		return errors.WithMessagef(ErrTipAboveFeeCap, "transaction tip is %s, fee cap is %s", tx.GasTipCap().String(), tx.GasFeeCap().String())
+	}
 	// Make sure the transaction is signed properly
 	from, err := tx.SenderAddress()
 	if err != nil {
@@ -788,10 +819,12 @@ func (pool *TxPool) validateTx(tx types.PoolTransaction, local bool) error {
 			}
 		}
 	}
-	// Drop non-local transactions under our own minimal accepted gas price
+	// Drop non-local transactions under our own minimal accepted gas price,
+	// which is a floor of the fee cap since the base fee is part of the price
+	// paid by dynamic fee transactions
 	local = local || pool.locals.contains(from) // account may be local even if the transaction arrived from the network
-	if !local && pool.gasPrice.Cmp(tx.GasPrice()) > 0 {
-		gasPrice := new(big.Float).SetInt64(tx.GasPrice().Int64())
+	if !local && pool.gasPrice.Cmp(tx.GasFeeCap()) > 0 {
+		gasPrice := new(big.Float).SetInt64(tx.GasFeeCap().Int64())
 		gasPrice = gasPrice.Mul(gasPrice, new(big.Float).SetFloat64(1e-9)) // Gas-price is in Nano
 
 		minGasPrice := new(big.Float).SetInt64(pool.gasPrice.Int64())
@@ -820,9 +853,9 @@ func (pool *TxPool) validateTx(tx types.PoolTransaction, local bool) error {
 	}
 	intrGas := uint64(0)
 	if isStakingTx {
-		intrGas, err = vm.IntrinsicGas(tx.Data(), false, pool.homestead, pool.istanbul, stakingTx.StakingType() == staking.DirectiveCreateValidator)
+		intrGas, err = vm.IntrinsicGas(tx.Data(), nil, false, pool.homestead, pool.istanbul, stakingTx.StakingType() == staking.DirectiveCreateValidator)
 	} else {
-		intrGas, err = vm.IntrinsicGas(tx.Data(), tx.To() == nil, pool.homestead, pool.istanbul, false)
+		intrGas, err = vm.IntrinsicGas(tx.Data(), accessList, tx.To() == nil, pool.homestead, pool.istanbul, false)
 	}
 	if err != nil {
 		return err
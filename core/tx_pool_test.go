@@ -326,6 +326,40 @@ func TestInvalidTransactions(t *testing.T) {
 	}
 }
 
+func TestDynamicFeeTransactionPrice(t *testing.T) {
+	t.Parallel()
+
+	pool, key := setupTxPool(nil)
+	defer pool.Stop()
+
+	from := crypto.PubkeyToAddress(key.PublicKey)
+	pool.currentState.AddBalance(from, big.NewInt(8e18))
+	dynamicTx := func(nonce uint64, tip, feeCap *big.Int) types.PoolTransaction {
+		tx, _ := types.SignTx(
+			types.NewDynamicFeeTransaction(params.TestChainConfig.ChainID, nonce, &common.Address{}, 0, 0,
+				big.NewInt(1), 21000, tip, feeCap, nil, nil),
+			types.NewEIP155Signer(params.TestChainConfig.ChainID), key,
+		)
+		return tx
+	}
+
+	// a fee cap covering the minimum gas price is enough, whatever the tip
+	baseFee := big.NewInt(params.InitialBaseFee)
+	if err := pool.AddRemote(dynamicTx(0, big.NewInt(1), baseFee)); err != nil {
+		t.Errorf("expected %v, got %v", nil, err)
+	}
+	if err := pool.AddRemote(dynamicTx(1, common.Big0, new(big.Int).Mul(baseFee, common.Big2))); err != nil {
+		t.Errorf("expected %v, got %v", nil, err)
+	}
+	lowFeeCap := new(big.Int).Sub(pool.gasPrice, common.Big1)
+	if err := pool.AddRemote(dynamicTx(2, common.Big0, lowFeeCap)); err != ErrUnderpriced {
+		t.Errorf("expected %v, got %v", ErrUnderpriced, err)
+	}
+	if pending, _ := pool.Stats(); pending != 2 {
+		t.Errorf("pending transactions mismatch: have %d, want %d", pending, 2)
+	}
+}
+
 func TestErrorSink(t *testing.T) {
 	t.Parallel()
 
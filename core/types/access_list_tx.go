@@ -0,0 +1,390 @@
+// Copyright 2020 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"golang.org/x/crypto/sha3"
+)
+
+// EIP-2718 transaction envelope types.
+//
+// They are not to be confused with TransactionType, which tells apart the
+// harmony specific kinds of transaction (cross shard, staking, ...).
+const (
+	LegacyTxType     = 0x00
+	AccessListTxType = 0x01
+	DynamicFeeTxType = 0x02
+)
+
+// Errors for typed transactions.
+var (
+	ErrTxTypeNotSupported = errors.New("transaction type not supported")
+	errEmptyTypedTx       = errors.New("empty typed transaction bytes")
+)
+
+// AccessList is an EIP-2930 access list.
+type AccessList []AccessTuple
+
+// AccessTuple is the element type of an access list.
+type AccessTuple struct {
+	Address     common.Address `json:"address"     gencodec:"required"`
+	StorageKeys []common.Hash  `json:"storageKeys" gencodec:"required"`
+}
+
+// StorageKeys returns the total number of storage keys in the access list.
+func (al AccessList) StorageKeys() int {
+	sum := 0
+	for _, tuple := range al {
+		sum += len(tuple.StorageKeys)
+	}
+	return sum
+}
+
+func copyAccessList(al AccessList) AccessList {
+	if al == nil {
+		return nil
+	}
+	cpy := make(AccessList, len(al))
+	for i, tuple := range al {
+		cpy[i] = AccessTuple{
+			Address:     tuple.Address,
+			StorageKeys: append(tuple.StorageKeys[:0:0], tuple.StorageKeys...),
+		}
+	}
+	return cpy
+}
+
+func copyBig(v *big.Int) *big.Int {
+	if v == nil {
+		return nil
+	}
+	return new(big.Int).Set(v)
+}
+
+// accessListTxdata is the consensus encoding of an EIP-2930 harmony
+// transaction, which keeps the shard routing fields of txdata.
+type accessListTxdata struct {
+	ChainID      *big.Int
+	AccountNonce uint64
+	Price        *big.Int
+	GasLimit     uint64
+	ShardID      uint32
+	ToShardID    uint32
+	Recipient    *common.Address `rlp:"nil"`
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+	V, R, S      *big.Int
+}
+
+// ethAccessListTxdata is the consensus encoding of an EIP-2930 ethereum
+// compatible transaction, identical to the go-ethereum one.
+type ethAccessListTxdata struct {
+	ChainID      *big.Int
+	AccountNonce uint64
+	Price        *big.Int
+	GasLimit     uint64
+	Recipient    *common.Address `rlp:"nil"`
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+	V, R, S      *big.Int
+}
+
+// NewAccessListTransaction returns a new EIP-2930 transaction signed for chainID.
+// The destination shard equals the source shard unless toShardID differs.
+func NewAccessListTransaction(chainID *big.Int, nonce uint64, to *common.Address, shardID uint32, toShardID uint32, amount *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte, accessList AccessList) *Transaction {
+	tx := newCrossShardTransaction(nonce, to, shardID, toShardID, amount, gasLimit, gasPrice, data)
+	tx.data.Type = AccessListTxType
+	tx.data.ChainID = copyBig(chainID)
+	tx.data.AccessList = copyAccessList(accessList)
+	return tx
+}
+
+// NewEthAccessListTransaction returns a new ethereum compatible EIP-2930 transaction signed for chainID.
+func NewEthAccessListTransaction(chainID *big.Int, nonce uint64, to *common.Address, amount *big.Int, gasLimit uint64, gasPrice *big.Int, data []byte, accessList AccessList) *EthTransaction {
+	tx := newEthTransaction(nonce, to, amount, gasLimit, gasPrice, data)
+	tx.data.Type = AccessListTxType
+	tx.data.ChainID = copyBig(chainID)
+	tx.data.AccessList = copyAccessList(accessList)
+	return tx
+}
+
+// encodeTyped writes the EIP-2718 envelope type || rlp(payload) of d to w.
+func (d *txdata) encodeTyped(w *bytes.Buffer) error {
+	switch d.Type {
+	case AccessListTxType:
+		w.WriteByte(d.Type)
+		return rlp.Encode(w, &accessListTxdata{
+			ChainID:      d.ChainID,
+			AccountNonce: d.AccountNonce,
+			Price:        d.Price,
+			GasLimit:     d.GasLimit,
+			ShardID:      d.ShardID,
+			ToShardID:    d.ToShardID,
+			Recipient:    d.Recipient,
+			Amount:       d.Amount,
+			Payload:      d.Payload,
+			AccessList:   d.AccessList,
+			V:            d.V,
+			R:            d.R,
+			S:            d.S,
+		})
+	case DynamicFeeTxType:
+		w.WriteByte(d.Type)
+		return rlp.Encode(w, &dynamicFeeTxdata{
+			ChainID:      d.ChainID,
+			AccountNonce: d.AccountNonce,
+			GasTipCap:    d.GasTipCap,
+			GasFeeCap:    d.Price,
+			GasLimit:     d.GasLimit,
+			ShardID:      d.ShardID,
+			ToShardID:    d.ToShardID,
+			Recipient:    d.Recipient,
+			Amount:       d.Amount,
+			Payload:      d.Payload,
+			AccessList:   d.AccessList,
+			V:            d.V,
+			R:            d.R,
+			S:            d.S,
+		})
+	default:
+		return ErrTxTypeNotSupported
+	}
+}
+
+// decodeTyped decodes the EIP-2718 envelope b into d.
+func (d *txdata) decodeTyped(b []byte) error {
+	if len(b) == 0 {
+		return errEmptyTypedTx
+	}
+	switch b[0] {
+	case AccessListTxType:
+		var inner accessListTxdata
+		if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+			return err
+		}
+		*d = txdata{
+			AccountNonce: inner.AccountNonce,
+			Price:        inner.Price,
+			GasLimit:     inner.GasLimit,
+			ShardID:      inner.ShardID,
+			ToShardID:    inner.ToShardID,
+			Recipient:    inner.Recipient,
+			Amount:       inner.Amount,
+			Payload:      inner.Payload,
+			Type:         AccessListTxType,
+			ChainID:      inner.ChainID,
+			AccessList:   inner.AccessList,
+			V:            inner.V,
+			R:            inner.R,
+			S:            inner.S,
+		}
+		return nil
+	case DynamicFeeTxType:
+		var inner dynamicFeeTxdata
+		if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+			return err
+		}
+		*d = txdata{
+			AccountNonce: inner.AccountNonce,
+			Price:        inner.GasFeeCap,
+			GasLimit:     inner.GasLimit,
+			ShardID:      inner.ShardID,
+			ToShardID:    inner.ToShardID,
+			Recipient:    inner.Recipient,
+			Amount:       inner.Amount,
+			Payload:      inner.Payload,
+			Type:         DynamicFeeTxType,
+			ChainID:      inner.ChainID,
+			AccessList:   inner.AccessList,
+			GasTipCap:    inner.GasTipCap,
+			V:            inner.V,
+			R:            inner.R,
+			S:            inner.S,
+		}
+		return nil
+	default:
+		return ErrTxTypeNotSupported
+	}
+}
+
+// encodeTyped writes the EIP-2718 envelope type || rlp(payload) of d to w.
+func (d *ethTxdata) encodeTyped(w *bytes.Buffer) error {
+	switch d.Type {
+	case AccessListTxType:
+		w.WriteByte(d.Type)
+		return rlp.Encode(w, &ethAccessListTxdata{
+			ChainID:      d.ChainID,
+			AccountNonce: d.AccountNonce,
+			Price:        d.Price,
+			GasLimit:     d.GasLimit,
+			Recipient:    d.Recipient,
+			Amount:       d.Amount,
+			Payload:      d.Payload,
+			AccessList:   d.AccessList,
+			V:            d.V,
+			R:            d.R,
+			S:            d.S,
+		})
+	case DynamicFeeTxType:
+		w.WriteByte(d.Type)
+		return rlp.Encode(w, &ethDynamicFeeTxdata{
+			ChainID:      d.ChainID,
+			AccountNonce: d.AccountNonce,
+			GasTipCap:    d.GasTipCap,
+			GasFeeCap:    d.Price,
+			GasLimit:     d.GasLimit,
+			Recipient:    d.Recipient,
+			Amount:       d.Amount,
+			Payload:      d.Payload,
+			AccessList:   d.AccessList,
+			V:            d.V,
+			R:            d.R,
+			S:            d.S,
+		})
+	default:
+		return ErrTxTypeNotSupported
+	}
+}
+
+// decodeTyped decodes the EIP-2718 envelope b into d.
+func (d *ethTxdata) decodeTyped(b []byte) error {
+	if len(b) == 0 {
+		return errEmptyTypedTx
+	}
+	switch b[0] {
+	case AccessListTxType:
+		var inner ethAccessListTxdata
+		if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+			return err
+		}
+		*d = ethTxdata{
+			AccountNonce: inner.AccountNonce,
+			Price:        inner.Price,
+			GasLimit:     inner.GasLimit,
+			Recipient:    inner.Recipient,
+			Amount:       inner.Amount,
+			Payload:      inner.Payload,
+			Type:         AccessListTxType,
+			ChainID:      inner.ChainID,
+			AccessList:   inner.AccessList,
+			V:            inner.V,
+			R:            inner.R,
+			S:            inner.S,
+		}
+		return nil
+	case DynamicFeeTxType:
+		var inner ethDynamicFeeTxdata
+		if err := rlp.DecodeBytes(b[1:], &inner); err != nil {
+			return err
+		}
+		*d = ethTxdata{
+			AccountNonce: inner.AccountNonce,
+			Price:        inner.GasFeeCap,
+			GasLimit:     inner.GasLimit,
+			Recipient:    inner.Recipient,
+			Amount:       inner.Amount,
+			Payload:      inner.Payload,
+			Type:         DynamicFeeTxType,
+			ChainID:      inner.ChainID,
+			AccessList:   inner.AccessList,
+			GasTipCap:    inner.GasTipCap,
+			V:            inner.V,
+			R:            inner.R,
+			S:            inner.S,
+		}
+		return nil
+	default:
+		return ErrTxTypeNotSupported
+	}
+}
+
+// prefixedRlpHash writes the prefix into the hasher before rlp-encoding x.
+// It's used for typed transactions.
+func prefixedRlpHash(prefix byte, x interface{}) (h common.Hash) {
+	sha := sha3.NewLegacyKeccak256()
+	sha.Write([]byte{prefix})
+	rlp.Encode(sha, x)
+	sha.Sum(h[:0])
+	return h
+}
+
+// MarshalBinary returns the canonical encoding of the transaction.
+// For legacy transactions, it returns the RLP encoding. For EIP-2718 typed
+// transactions, it returns the type and payload.
+func (tx *Transaction) MarshalBinary() ([]byte, error) {
+	if tx.Type() == LegacyTxType {
+		return rlp.EncodeToBytes(&tx.data)
+	}
+	var buf bytes.Buffer
+	err := tx.data.encodeTyped(&buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary decodes the canonical encoding of transactions.
+// It supports legacy RLP transactions and EIP-2718 typed transactions.
+func (tx *Transaction) UnmarshalBinary(b []byte) error {
+	var data txdata
+	if len(b) > 0 && b[0] > 0x7f {
+		// It's a legacy transaction.
+		if err := rlp.DecodeBytes(b, &data); err != nil {
+			return err
+		}
+	} else if err := data.decodeTyped(b); err != nil {
+		return err
+	}
+	*tx = Transaction{data: data, time: time.Now()}
+	tx.size.Store(common.StorageSize(len(b)))
+	return nil
+}
+
+// MarshalBinary returns the canonical encoding of the transaction.
+// For legacy transactions, it returns the RLP encoding. For EIP-2718 typed
+// transactions, it returns the type and payload.
+func (tx *EthTransaction) MarshalBinary() ([]byte, error) {
+	if tx.Type() == LegacyTxType {
+		return rlp.EncodeToBytes(&tx.data)
+	}
+	var buf bytes.Buffer
+	err := tx.data.encodeTyped(&buf)
+	return buf.Bytes(), err
+}
+
+// UnmarshalBinary decodes the canonical encoding of transactions.
+// It supports legacy RLP transactions and EIP-2718 typed transactions.
+func (tx *EthTransaction) UnmarshalBinary(b []byte) error {
+	var data ethTxdata
+	if len(b) > 0 && b[0] > 0x7f {
+		// It's a legacy transaction.
+		if err := rlp.DecodeBytes(b, &data); err != nil {
+			return err
+		}
+	} else if err := data.decodeTyped(b); err != nil {
+		return err
+	}
+	*tx = EthTransaction{data: data, time: time.Now()}
+	tx.size.Store(common.StorageSize(len(b)))
+	return nil
+}
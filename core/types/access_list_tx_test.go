@@ -0,0 +1,159 @@
+package types
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+var testAccessList = AccessList{{
+	Address:     common.HexToAddress("0x0000000000000000000000000000000000000001"),
+	StorageKeys: []common.Hash{{0}, {1}},
+}}
+
+func TestAccessListTxSigning(t *testing.T) {
+	key, addr := defaultTestKey()
+	signer := NewEIP155Signer(big.NewInt(2))
+
+	tx, err := SignTx(NewAccessListTransaction(big.NewInt(2), 3, &addr, 0, 1, big.NewInt(10), 50000, big.NewInt(1), []byte("abc"), testAccessList), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Type() != AccessListTxType {
+		t.Fatalf("wrong tx type: have %d want %d", tx.Type(), AccessListTxType)
+	}
+	if !tx.Protected() {
+		t.Fatal("expected typed tx to be protected")
+	}
+	from, err := Sender(signer, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Errorf("wrong sender: have %x want %x", from, addr)
+	}
+
+	if _, err := Sender(NewEIP155Signer(big.NewInt(3)), tx); err == nil {
+		t.Error("expected error for signer with a different chain id")
+	}
+	if _, err := Sender(HomesteadSigner{}, tx); err != ErrTxTypeNotSupported {
+		t.Errorf("wrong error from homestead signer: have %v want %v", err, ErrTxTypeNotSupported)
+	}
+}
+
+func TestAccessListTxEncoding(t *testing.T) {
+	key, addr := defaultTestKey()
+	signer := NewEIP155Signer(big.NewInt(2))
+
+	tx, err := SignTx(NewAccessListTransaction(big.NewInt(2), 3, &addr, 0, 1, big.NewInt(10), 50000, big.NewInt(1), []byte("abc"), testAccessList), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bin, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bin[0] != AccessListTxType {
+		t.Fatalf("wrong envelope type: %x", bin[0])
+	}
+	var binTx Transaction
+	if err := binTx.UnmarshalBinary(bin); err != nil {
+		t.Fatal(err)
+	}
+	assertEqualTx(t, tx, &binTx)
+
+	// the block body rlp wraps the envelope in an rlp string
+	enc, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rlpTx Transaction
+	if err := rlp.DecodeBytes(enc, &rlpTx); err != nil {
+		t.Fatal(err)
+	}
+	assertEqualTx(t, tx, &rlpTx)
+
+	js, err := tx.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var jsonTx Transaction
+	if err := jsonTx.UnmarshalJSON(js); err != nil {
+		t.Fatal(err)
+	}
+	assertEqualTx(t, tx, &jsonTx)
+
+	if err := new(Transaction).UnmarshalBinary([]byte{0x7f, 0xc0}); err != ErrTxTypeNotSupported {
+		t.Errorf("wrong error for unknown type: have %v want %v", err, ErrTxTypeNotSupported)
+	}
+}
+
+// TestEthAccessListTxCompatibility checks the ethereum compatible typed
+// transaction encodes and hashes exactly like the go-ethereum one.
+func TestEthAccessListTxCompatibility(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	to := common.HexToAddress("0x095e7baea6a6c7c4c2dfeb977efac326af552d87")
+	chainID := big.NewInt(1666600000)
+
+	tx, err := SignEthTx(NewEthAccessListTransaction(chainID, 7, &to, big.NewInt(10), 50000, big.NewInt(1), []byte("abc"), testAccessList), NewEIP155Signer(chainID), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ethTx, err := ethtypes.SignNewTx(key, ethtypes.NewEIP2930Signer(chainID), &ethtypes.AccessListTx{
+		ChainID:  chainID,
+		Nonce:    7,
+		GasPrice: big.NewInt(1),
+		Gas:      50000,
+		To:       &to,
+		Value:    big.NewInt(10),
+		Data:     []byte("abc"),
+		AccessList: ethtypes.AccessList{{
+			Address:     testAccessList[0].Address,
+			StorageKeys: testAccessList[0].StorageKeys,
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := ethTx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Fatalf("encoding mismatch:\nhave %x\nwant %x", have, want)
+	}
+	if tx.Hash() != ethTx.Hash() {
+		t.Errorf("hash mismatch: have %x want %x", tx.Hash(), ethTx.Hash())
+	}
+}
+
+func assertEqualTx(t *testing.T, want, have *Transaction) {
+	t.Helper()
+	if have.Hash() != want.Hash() {
+		t.Fatalf("hash mismatch: have %x want %x", have.Hash(), want.Hash())
+	}
+	if have.Type() != want.Type() {
+		t.Errorf("type mismatch: have %d want %d", have.Type(), want.Type())
+	}
+	if have.ChainID().Cmp(want.ChainID()) != 0 {
+		t.Errorf("chain id mismatch: have %v want %v", have.ChainID(), want.ChainID())
+	}
+	if have.ShardID() != want.ShardID() || have.ToShardID() != want.ToShardID() {
+		t.Errorf("shard mismatch: have %d->%d want %d->%d", have.ShardID(), have.ToShardID(), want.ShardID(), want.ToShardID())
+	}
+	if len(have.AccessList()) != len(want.AccessList()) || have.AccessList().StorageKeys() != want.AccessList().StorageKeys() {
+		t.Errorf("access list mismatch: have %v want %v", have.AccessList(), want.AccessList())
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package types
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrGasFeeCapTooLow is returned if the transaction fee cap is less than the
+// base fee of the block.
+var ErrGasFeeCapTooLow = errors.New("fee cap less than block base fee")
+
+// dynamicFeeTxdata is the consensus encoding of an EIP-1559 harmony
+// transaction, which keeps the shard routing fields of txdata.
+type dynamicFeeTxdata struct {
+	ChainID      *big.Int
+	AccountNonce uint64
+	GasTipCap    *big.Int
+	GasFeeCap    *big.Int
+	GasLimit     uint64
+	ShardID      uint32
+	ToShardID    uint32
+	Recipient    *common.Address `rlp:"nil"`
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+	V, R, S      *big.Int
+}
+
+// ethDynamicFeeTxdata is the consensus encoding of an EIP-1559 ethereum
+// compatible transaction, identical to the go-ethereum one.
+type ethDynamicFeeTxdata struct {
+	ChainID      *big.Int
+	AccountNonce uint64
+	GasTipCap    *big.Int
+	GasFeeCap    *big.Int
+	GasLimit     uint64
+	Recipient    *common.Address `rlp:"nil"`
+	Amount       *big.Int
+	Payload      []byte
+	AccessList   AccessList
+	V, R, S      *big.Int
+}
+
+// NewDynamicFeeTransaction returns a new EIP-1559 transaction signed for chainID,
+// paying at most gasFeeCap per gas of which at most gasTipCap on top of the base fee.
+// The destination shard equals the source shard unless toShardID differs.
+func NewDynamicFeeTransaction(chainID *big.Int, nonce uint64, to *common.Address, shardID uint32, toShardID uint32, amount *big.Int, gasLimit uint64, gasTipCap, gasFeeCap *big.Int, data []byte, accessList AccessList) *Transaction {
+	tx := newCrossShardTransaction(nonce, to, shardID, toShardID, amount, gasLimit, gasFeeCap, data)
+	tx.data.Type = DynamicFeeTxType
+	tx.data.ChainID = copyBig(chainID)
+	tx.data.AccessList = copyAccessList(accessList)
+	tx.data.GasTipCap = new(big.Int)
+	if gasTipCap != nil {
+		tx.data.GasTipCap.Set(gasTipCap)
+	}
+	return tx
+}
+
+// NewEthDynamicFeeTransaction returns a new ethereum compatible EIP-1559 transaction signed for chainID.
+func NewEthDynamicFeeTransaction(chainID *big.Int, nonce uint64, to *common.Address, amount *big.Int, gasLimit uint64, gasTipCap, gasFeeCap *big.Int, data []byte, accessList AccessList) *EthTransaction {
+	tx := newEthTransaction(nonce, to, amount, gasLimit, gasFeeCap, data)
+	tx.data.Type = DynamicFeeTxType
+	tx.data.ChainID = copyBig(chainID)
+	tx.data.AccessList = copyAccessList(accessList)
+	tx.data.GasTipCap = new(big.Int)
+	if gasTipCap != nil {
+		tx.data.GasTipCap.Set(gasTipCap)
+	}
+	return tx
+}
+
+// EffectiveGasTip returns the tip per gas paid on top of baseFee by a
+// transaction bidding at most gasFeeCap per gas, of which at most gasTipCap
+// as tip. The error is ErrGasFeeCapTooLow, together with a negative tip, if
+// gasFeeCap does not even cover baseFee. A nil baseFee, before the London
+// fork, leaves the whole gasTipCap to the tip.
+func EffectiveGasTip(gasTipCap, gasFeeCap, baseFee *big.Int) (*big.Int, error) {
+	if baseFee == nil {
+		return new(big.Int).Set(gasTipCap), nil
+	}
+	var err error
+	tip := new(big.Int).Sub(gasFeeCap, baseFee)
+	if tip.Sign() < 0 {
+		err = ErrGasFeeCapTooLow
+	}
+	if tip.Cmp(gasTipCap) > 0 {
+		tip.Set(gasTipCap)
+	}
+	return tip, err
+}
+
+// EffectiveGasPrice returns the gas price actually paid by a transaction
+// bidding gasTipCap and gasFeeCap in a block of the given baseFee, which is
+// min(gasTipCap+baseFee, gasFeeCap).
+func EffectiveGasPrice(gasTipCap, gasFeeCap, baseFee *big.Int) *big.Int {
+	if baseFee == nil {
+		return new(big.Int).Set(gasFeeCap)
+	}
+	price := new(big.Int).Add(gasTipCap, baseFee)
+	if price.Cmp(gasFeeCap) > 0 {
+		price.Set(gasFeeCap)
+	}
+	return price
+}
@@ -0,0 +1,154 @@
+package types
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+func TestDynamicFeeTxEncoding(t *testing.T) {
+	key, addr := defaultTestKey()
+	signer := NewEIP155Signer(big.NewInt(2))
+
+	tx, err := SignTx(NewDynamicFeeTransaction(big.NewInt(2), 3, &addr, 0, 1, big.NewInt(10), 50000, big.NewInt(1), big.NewInt(5), []byte("abc"), testAccessList), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tx.Type() != DynamicFeeTxType {
+		t.Fatalf("wrong tx type: have %d want %d", tx.Type(), DynamicFeeTxType)
+	}
+	from, err := Sender(signer, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if from != addr {
+		t.Errorf("wrong sender: have %x want %x", from, addr)
+	}
+
+	bin, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bin[0] != DynamicFeeTxType {
+		t.Fatalf("wrong envelope type: %x", bin[0])
+	}
+	var binTx Transaction
+	if err := binTx.UnmarshalBinary(bin); err != nil {
+		t.Fatal(err)
+	}
+	assertEqualTx(t, tx, &binTx)
+	assertEqualFees(t, tx, &binTx)
+
+	enc, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rlpTx Transaction
+	if err := rlp.DecodeBytes(enc, &rlpTx); err != nil {
+		t.Fatal(err)
+	}
+	assertEqualTx(t, tx, &rlpTx)
+	assertEqualFees(t, tx, &rlpTx)
+
+	js, err := tx.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var jsonTx Transaction
+	if err := jsonTx.UnmarshalJSON(js); err != nil {
+		t.Fatal(err)
+	}
+	assertEqualTx(t, tx, &jsonTx)
+	assertEqualFees(t, tx, &jsonTx)
+}
+
+// TestEthDynamicFeeTxCompatibility checks the ethereum compatible dynamic fee
+// transaction encodes and hashes exactly like the go-ethereum one.
+func TestEthDynamicFeeTxCompatibility(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	to := common.HexToAddress("0x095e7baea6a6c7c4c2dfeb977efac326af552d87")
+	chainID := big.NewInt(1666600000)
+
+	tx, err := SignEthTx(NewEthDynamicFeeTransaction(chainID, 7, &to, big.NewInt(10), 50000, big.NewInt(1), big.NewInt(5), []byte("abc"), testAccessList), NewEIP155Signer(chainID), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ethTx, err := ethtypes.SignNewTx(key, ethtypes.NewLondonSigner(chainID), &ethtypes.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     7,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(5),
+		Gas:       50000,
+		To:        &to,
+		Value:     big.NewInt(10),
+		Data:      []byte("abc"),
+		AccessList: ethtypes.AccessList{{
+			Address:     testAccessList[0].Address,
+			StorageKeys: testAccessList[0].StorageKeys,
+		}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	have, err := tx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := ethTx.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(have, want) {
+		t.Fatalf("encoding mismatch:\nhave %x\nwant %x", have, want)
+	}
+	if tx.Hash() != ethTx.Hash() {
+		t.Errorf("hash mismatch: have %x want %x", tx.Hash(), ethTx.Hash())
+	}
+}
+
+func TestEffectiveGasTip(t *testing.T) {
+	tests := []struct {
+		tipCap, feeCap, baseFee int64
+		tip, price              int64
+		err                     error
+	}{
+		{tipCap: 1, feeCap: 5, baseFee: 2, tip: 1, price: 3},
+		{tipCap: 4, feeCap: 5, baseFee: 2, tip: 3, price: 5},
+		{tipCap: 1, feeCap: 5, baseFee: 5, tip: 0, price: 5},
+		{tipCap: 1, feeCap: 5, baseFee: 6, tip: -1, price: 5, err: ErrGasFeeCapTooLow},
+	}
+	for i, test := range tests {
+		tipCap, feeCap, baseFee := big.NewInt(test.tipCap), big.NewInt(test.feeCap), big.NewInt(test.baseFee)
+		tip, err := EffectiveGasTip(tipCap, feeCap, baseFee)
+		if err != test.err {
+			t.Errorf("test %d: wrong error: have %v want %v", i, err, test.err)
+		}
+		if tip.Int64() != test.tip {
+			t.Errorf("test %d: wrong tip: have %d want %d", i, tip, test.tip)
+		}
+		if price := EffectiveGasPrice(tipCap, feeCap, baseFee); price.Int64() != test.price {
+			t.Errorf("test %d: wrong price: have %d want %d", i, price, test.price)
+		}
+	}
+	// Before the London fork the tip is the whole gas price
+	if tip, err := EffectiveGasTip(big.NewInt(3), big.NewInt(3), nil); err != nil || tip.Int64() != 3 {
+		t.Errorf("wrong pre-London tip: have %v, %v want 3", tip, err)
+	}
+}
+
+func assertEqualFees(t *testing.T, want, have *Transaction) {
+	t.Helper()
+	if have.GasFeeCap().Cmp(want.GasFeeCap()) != 0 {
+		t.Errorf("fee cap mismatch: have %v want %v", have.GasFeeCap(), want.GasFeeCap())
+	}
+	if have.GasTipCap().Cmp(want.GasTipCap()) != 0 {
+		t.Errorf("tip cap mismatch: have %v want %v", have.GasTipCap(), want.GasTipCap())
+	}
+}
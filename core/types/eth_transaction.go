@@ -17,6 +17,8 @@
 package types
 
 import (
+	"bytes"
+	"errors"
 	"io"
 	"math/big"
 	"sync/atomic"
@@ -57,6 +59,13 @@ type ethTxdata struct {
 	Amount       *big.Int        `json:"value"    gencodec:"required"`
 	Payload      []byte          `json:"input"    gencodec:"required"`
 
+	// EIP-2718 envelope fields, they are not part of the legacy RLP list.
+	// Dynamic fee transactions keep their max fee per gas in Price.
+	Type       uint8      `json:"type"                           rlp:"-"`
+	ChainID    *big.Int   `json:"chainId,omitempty"              rlp:"-"`
+	AccessList AccessList `json:"accessList,omitempty"           rlp:"-"`
+	GasTipCap  *big.Int   `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
+
 	// Signature values
 	V *big.Int `json:"v" gencodec:"required"`
 	R *big.Int `json:"r" gencodec:"required"`
@@ -73,6 +82,10 @@ func (d *ethTxdata) CopyFrom(d2 *ethTxdata) {
 	d.Recipient = copyAddr(d2.Recipient)
 	d.Amount = new(big.Int).Set(d2.Amount)
 	d.Payload = append(d2.Payload[:0:0], d2.Payload...)
+	d.Type = d2.Type
+	d.ChainID = copyBig(d2.ChainID)
+	d.AccessList = copyAccessList(d2.AccessList)
+	d.GasTipCap = copyBig(d2.GasTipCap)
 	d.V = new(big.Int).Set(d2.V)
 	d.R = new(big.Int).Set(d2.R)
 	d.S = new(big.Int).Set(d2.S)
@@ -85,6 +98,9 @@ type ethTxdataMarshaling struct {
 	GasLimit     hexutil.Uint64
 	Amount       *hexutil.Big
 	Payload      hexutil.Bytes
+	Type         hexutil.Uint64
+	ChainID      *hexutil.Big
+	GasTipCap    *hexutil.Big
 	V            *hexutil.Big
 	R            *hexutil.Big
 	S            *hexutil.Big
@@ -177,11 +193,31 @@ func (tx *EthTransaction) shardID() uint32 {
 
 // ChainID returns which chain id this transaction was signed for (if at all)
 func (tx *EthTransaction) ChainID() *big.Int {
+	if tx.data.Type != LegacyTxType {
+		if tx.data.ChainID == nil {
+			return new(big.Int)
+		}
+		return new(big.Int).Set(tx.data.ChainID)
+	}
 	return deriveChainID(tx.data.V)
 }
 
+// Type returns the EIP-2718 envelope type of the transaction
+func (tx *EthTransaction) Type() uint8 {
+	return tx.data.Type
+}
+
+// AccessList returns the EIP-2930 access list of the transaction, nil for legacy transactions
+func (tx *EthTransaction) AccessList() AccessList {
+	return tx.data.AccessList
+}
+
 // Protected returns whether the transaction is protected from replay protection.
+// Typed transactions always commit to a chain id.
 func (tx *EthTransaction) Protected() bool {
+	if tx.data.Type != LegacyTxType {
+		return true
+	}
 	return isProtectedV(tx.data.V)
 }
 
@@ -205,6 +241,10 @@ func (tx *EthTransaction) ConvertToHmy() *Transaction {
 	d2.Recipient = copyAddr(d.Recipient)
 	d2.Amount = new(big.Int).Set(d.Amount)
 	d2.Payload = append(d.Payload[:0:0], d.Payload...)
+	d2.Type = d.Type
+	d2.ChainID = copyBig(d.ChainID)
+	d2.AccessList = copyAccessList(d.AccessList)
+	d2.GasTipCap = copyBig(d.GasTipCap)
 	d2.V = new(big.Int).Set(d.V)
 	d2.R = new(big.Int).Set(d.R)
 	d2.S = new(big.Int).Set(d.S)
@@ -220,21 +260,46 @@ func (tx *EthTransaction) ConvertToHmy() *Transaction {
 	return &tx2
 }
 
-// EncodeRLP implements rlp.Encoder
+// EncodeRLP implements rlp.Encoder.
+// Typed transactions are wrapped in an RLP string holding their binary encoding.
 func (tx *EthTransaction) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &tx.data)
+	if tx.data.Type == LegacyTxType {
+		return rlp.Encode(w, &tx.data)
+	}
+	var buf bytes.Buffer
+	if err := tx.data.encodeTyped(&buf); err != nil {
+		return err
+	}
+	return rlp.Encode(w, buf.Bytes())
 }
 
 // DecodeRLP implements rlp.Decoder
 func (tx *EthTransaction) DecodeRLP(s *rlp.Stream) error {
-	_, size, _ := s.Kind()
-	err := s.Decode(&tx.data)
-	if err == nil {
+	kind, size, err := s.Kind()
+	switch {
+	case err != nil:
+		return err
+	case kind == rlp.List:
+		err = s.Decode(&tx.data)
+		if err == nil {
+			tx.size.Store(common.StorageSize(rlp.ListSize(size)))
+			tx.time = time.Now()
+		}
+		return err
+	default:
+		b, err := s.Bytes()
+		if err != nil {
+			return err
+		}
+		var data ethTxdata
+		if err := data.decodeTyped(b); err != nil {
+			return err
+		}
+		tx.data = data
 		tx.size.Store(common.StorageSize(rlp.ListSize(size)))
 		tx.time = time.Now()
+		return nil
 	}
-
-	return err
 }
 
 // MarshalJSON encodes the web3 RPC transaction format.
@@ -252,10 +317,24 @@ func (tx *EthTransaction) UnmarshalJSON(input []byte) error {
 		return err
 	}
 
+	switch dec.Type {
+	case LegacyTxType, AccessListTxType:
+	case DynamicFeeTxType:
+		if dec.GasTipCap == nil {
+			return errors.New("missing required field 'maxPriorityFeePerGas' in transaction")
+		}
+	default:
+		return ErrTxTypeNotSupported
+	}
+	if dec.Type != LegacyTxType && dec.ChainID == nil {
+		return errors.New("missing required field 'chainId' in transaction")
+	}
 	withSignature := dec.V.Sign() != 0 || dec.R.Sign() != 0 || dec.S.Sign() != 0
 	if withSignature {
 		var V byte
-		if isProtectedV(dec.V) {
+		if dec.Type != LegacyTxType {
+			V = byte(dec.V.Uint64())
+		} else if isProtectedV(dec.V) {
 			chainID := deriveChainID(dec.V).Uint64()
 			V = byte(dec.V.Uint64() - 35 - 2*chainID)
 		} else {
@@ -280,6 +359,21 @@ func (tx *EthTransaction) GasPrice() *big.Int {
 	return new(big.Int).Set(tx.data.Price)
 }
 
+// GasTipCap returns the max priority fee per gas of Transaction, which is the
+// gas price for all but dynamic fee transactions.
+func (tx *EthTransaction) GasTipCap() *big.Int {
+	if tx.data.Type == DynamicFeeTxType {
+		return new(big.Int).Set(tx.data.GasTipCap)
+	}
+	return new(big.Int).Set(tx.data.Price)
+}
+
+// GasFeeCap returns the max fee per gas of Transaction, which is the gas price
+// for all but dynamic fee transactions.
+func (tx *EthTransaction) GasFeeCap() *big.Int {
+	return new(big.Int).Set(tx.data.Price)
+}
+
 // Nonce returns account nonce from Transaction.
 func (tx *EthTransaction) Nonce() uint64 {
 	return tx.data.AccountNonce
@@ -306,7 +400,13 @@ func (tx *EthTransaction) Hash() common.Hash {
 	if hash := tx.hash.Load(); hash != nil {
 		return hash.(common.Hash)
 	}
-	v := hash.FromRLP(tx)
+	var v common.Hash
+	if tx.data.Type == LegacyTxType {
+		v = hash.FromRLP(tx)
+	} else {
+		enc, _ := tx.MarshalBinary()
+		v = hash.Keccak256Hash(enc)
+	}
 	tx.hash.Store(v)
 	return v
 }
@@ -318,7 +418,7 @@ func (tx *EthTransaction) Size() common.StorageSize {
 		return size.(common.StorageSize)
 	}
 	c := writeCounter(0)
-	rlp.Encode(&c, &tx.data)
+	rlp.Encode(&c, tx)
 	tx.size.Store(common.StorageSize(c))
 	return common.StorageSize(c)
 }
@@ -353,17 +453,21 @@ func (tx *EthTransaction) IsEthCompatible() bool {
 
 // AsMessage returns the transaction as a core.Message.
 //
-// AsMessage requires a signer to derive the sender.
+// AsMessage requires a signer to derive the sender, and the base fee of the
+// block, nil before the London fork, to derive the effective gas price.
 //
 // XXX Rename message to something less arbitrary?
-func (tx *EthTransaction) AsMessage(s Signer) (Message, error) {
+func (tx *EthTransaction) AsMessage(s Signer, baseFee *big.Int) (Message, error) {
 	msg := Message{
 		nonce:      tx.data.AccountNonce,
 		gasLimit:   tx.data.GasLimit,
-		gasPrice:   new(big.Int).Set(tx.data.Price),
+		gasPrice:   EffectiveGasPrice(tx.GasTipCap(), tx.GasFeeCap(), baseFee),
+		gasFeeCap:  tx.GasFeeCap(),
+		gasTipCap:  tx.GasTipCap(),
 		to:         tx.data.Recipient,
 		amount:     tx.data.Amount,
 		data:       tx.data.Payload,
+		accessList: tx.data.AccessList,
 		checkNonce: true,
 	}
 
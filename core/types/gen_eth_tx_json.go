@@ -22,6 +22,10 @@ func (e ethTxdata) MarshalJSON() ([]byte, error) {
 		Recipient    *common.Address `json:"to"       rlp:"nil"`
 		Amount       *hexutil.Big    `json:"value"    gencodec:"required"`
 		Payload      hexutil.Bytes   `json:"input"    gencodec:"required"`
+		Type         hexutil.Uint64  `json:"type"                           rlp:"-"`
+		ChainID      *hexutil.Big    `json:"chainId,omitempty"              rlp:"-"`
+		AccessList   AccessList      `json:"accessList,omitempty"           rlp:"-"`
+		GasTipCap    *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
 		V            *hexutil.Big    `json:"v" gencodec:"required"`
 		R            *hexutil.Big    `json:"r" gencodec:"required"`
 		S            *hexutil.Big    `json:"s" gencodec:"required"`
@@ -34,6 +38,10 @@ func (e ethTxdata) MarshalJSON() ([]byte, error) {
 	enc.Recipient = e.Recipient
 	enc.Amount = (*hexutil.Big)(e.Amount)
 	enc.Payload = e.Payload
+	enc.Type = hexutil.Uint64(e.Type)
+	enc.ChainID = (*hexutil.Big)(e.ChainID)
+	enc.AccessList = e.AccessList
+	enc.GasTipCap = (*hexutil.Big)(e.GasTipCap)
 	enc.V = (*hexutil.Big)(e.V)
 	enc.R = (*hexutil.Big)(e.R)
 	enc.S = (*hexutil.Big)(e.S)
@@ -50,6 +58,10 @@ func (e *ethTxdata) UnmarshalJSON(input []byte) error {
 		Recipient    *common.Address `json:"to"       rlp:"nil"`
 		Amount       *hexutil.Big    `json:"value"    gencodec:"required"`
 		Payload      *hexutil.Bytes  `json:"input"    gencodec:"required"`
+		Type         *hexutil.Uint64 `json:"type"                           rlp:"-"`
+		ChainID      *hexutil.Big    `json:"chainId,omitempty"              rlp:"-"`
+		AccessList   *AccessList     `json:"accessList,omitempty"           rlp:"-"`
+		GasTipCap    *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
 		V            *hexutil.Big    `json:"v" gencodec:"required"`
 		R            *hexutil.Big    `json:"r" gencodec:"required"`
 		S            *hexutil.Big    `json:"s" gencodec:"required"`
@@ -82,6 +94,18 @@ func (e *ethTxdata) UnmarshalJSON(input []byte) error {
 		return errors.New("missing required field 'input' for ethTxdata")
 	}
 	e.Payload = *dec.Payload
+	if dec.Type != nil {
+		e.Type = uint8(*dec.Type)
+	}
+	if dec.ChainID != nil {
+		e.ChainID = (*big.Int)(dec.ChainID)
+	}
+	if dec.AccessList != nil {
+		e.AccessList = *dec.AccessList
+	}
+	if dec.GasTipCap != nil {
+		e.GasTipCap = (*big.Int)(dec.GasTipCap)
+	}
 	if dec.V == nil {
 		return errors.New("missing required field 'v' for ethTxdata")
 	}
@@ -7,6 +7,7 @@ import (
 	ethcommon "github.com/ethereum/go-ethereum/common"
 
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/staking"
 )
 
@@ -112,3 +113,39 @@ func TestFindLogsWithTopic(t *testing.T) {
 		}
 	}
 }
+
+func TestTypedReceiptEncoding(t *testing.T) {
+	for _, txType := range []byte{AccessListTxType, DynamicFeeTxType} {
+		want := &Receipt{
+			Type:              txType,
+			Status:            ReceiptStatusSuccessful,
+			CumulativeGasUsed: 21000,
+			Logs: []*Log{
+				{
+					Address: ethcommon.HexToAddress("0x11"),
+					Topics:  []ethcommon.Hash{crypto.Keccak256Hash([]byte("test"))},
+					Data:    []byte{0x01, 0x02},
+				},
+			},
+		}
+		want.Bloom = CreateBloom(Receipts{want})
+
+		enc, err := rlp.EncodeToBytes(want)
+		if err != nil {
+			t.Fatalf("type %d: encode error: %v", txType, err)
+		}
+		have := new(Receipt)
+		if err := rlp.DecodeBytes(enc, have); err != nil {
+			t.Fatalf("type %d: decode error: %v", txType, err)
+		}
+		if !reflect.DeepEqual(have, want) {
+			t.Errorf("type %d: receipt mismatch\nhave %+v\nwant %+v", txType, have, want)
+		}
+	}
+
+	// unknown receipt types are rejected
+	enc, _ := rlp.EncodeToBytes([]byte{0x7f, 0xc0})
+	if err := rlp.DecodeBytes(enc, new(Receipt)); err != ErrTxTypeNotSupported {
+		t.Errorf("wrong error for unknown type: have %v want %v", err, ErrTxTypeNotSupported)
+	}
+}
@@ -17,6 +17,7 @@
 package types
 
 import (
+	"bytes"
 	"container/heap"
 	"errors"
 	"fmt"
@@ -74,7 +75,15 @@ type InternalTransaction interface {
 	S() *big.Int
 
 	IsEthCompatible() bool
-	AsMessage(s Signer) (Message, error)
+	AsMessage(s Signer, baseFee *big.Int) (Message, error)
+
+	// EIP-2718 envelope type and EIP-2930 access list
+	Type() uint8
+	AccessList() AccessList
+
+	// EIP-1559 fee caps
+	GasTipCap() *big.Int
+	GasFeeCap() *big.Int
 }
 
 // CoreTransaction defines the core funcs of any transactions
@@ -138,6 +147,13 @@ type txdata struct {
 	Amount       *big.Int        `json:"value"      gencodec:"required"`
 	Payload      []byte          `json:"input"      gencodec:"required"`
 
+	// EIP-2718 envelope fields, they are not part of the legacy RLP list.
+	// Dynamic fee transactions keep their max fee per gas in Price.
+	Type       uint8      `json:"type"                           rlp:"-"`
+	ChainID    *big.Int   `json:"chainId,omitempty"              rlp:"-"`
+	AccessList AccessList `json:"accessList,omitempty"           rlp:"-"`
+	GasTipCap  *big.Int   `json:"maxPriorityFeePerGas,omitempty" rlp:"-"`
+
 	// Signature values
 	V *big.Int `json:"v" gencodec:"required"`
 	R *big.Int `json:"r" gencodec:"required"`
@@ -172,6 +188,10 @@ func (d *txdata) CopyFrom(d2 *txdata) {
 	d.Recipient = copyAddr(d2.Recipient)
 	d.Amount = new(big.Int).Set(d2.Amount)
 	d.Payload = append(d2.Payload[:0:0], d2.Payload...)
+	d.Type = d2.Type
+	d.ChainID = copyBig(d2.ChainID)
+	d.AccessList = copyAccessList(d2.AccessList)
+	d.GasTipCap = copyBig(d2.GasTipCap)
 	d.V = new(big.Int).Set(d2.V)
 	d.R = new(big.Int).Set(d2.R)
 	d.S = new(big.Int).Set(d2.S)
@@ -184,6 +204,9 @@ type txdataMarshaling struct {
 	GasLimit     hexutil.Uint64
 	Amount       *hexutil.Big
 	Payload      hexutil.Bytes
+	Type         hexutil.Uint64
+	ChainID      *hexutil.Big
+	GasTipCap    *hexutil.Big
 	V            *hexutil.Big
 	R            *hexutil.Big
 	S            *hexutil.Big
@@ -293,6 +316,27 @@ func (tx *Transaction) GasPrice() *big.Int {
 	return tx.data.Price
 }
 
+// GasTipCap is the max priority fee per gas of the transaction, which is the
+// gas price for all but dynamic fee transactions
+func (tx *Transaction) GasTipCap() *big.Int {
+	if tx.data.Type == DynamicFeeTxType {
+		return tx.data.GasTipCap
+	}
+	return tx.data.Price
+}
+
+// GasFeeCap is the max fee per gas of the transaction, which is the gas price
+// for all but dynamic fee transactions
+func (tx *Transaction) GasFeeCap() *big.Int {
+	return tx.data.Price
+}
+
+// EffectiveGasTip returns the tip per gas the transaction pays on top of the
+// given base fee. See EffectiveGasTip.
+func (tx *Transaction) EffectiveGasTip(baseFee *big.Int) (*big.Int, error) {
+	return EffectiveGasTip(tx.GasTipCap(), tx.GasFeeCap(), baseFee)
+}
+
 // Data returns data payload of Transaction.
 func (tx *Transaction) Data() []byte {
 	return common.CopyBytes(tx.data.Payload)
@@ -300,9 +344,25 @@ func (tx *Transaction) Data() []byte {
 
 // ChainID returns which chain id this transaction was signed for (if at all)
 func (tx *Transaction) ChainID() *big.Int {
+	if tx.data.Type != LegacyTxType {
+		if tx.data.ChainID == nil {
+			return new(big.Int)
+		}
+		return new(big.Int).Set(tx.data.ChainID)
+	}
 	return deriveChainID(tx.data.V)
 }
 
+// Type returns the EIP-2718 envelope type of the transaction
+func (tx *Transaction) Type() uint8 {
+	return tx.data.Type
+}
+
+// AccessList returns the EIP-2930 access list of the transaction, nil for legacy transactions
+func (tx *Transaction) AccessList() AccessList {
+	return tx.data.AccessList
+}
+
 // ShardID returns which shard id this transaction was signed for (if at all)
 func (tx *Transaction) ShardID() uint32 {
 	return tx.data.ShardID
@@ -319,7 +379,11 @@ func (tx *Transaction) Time() time.Time {
 }
 
 // Protected returns whether the transaction is protected from replay protection.
+// Typed transactions always commit to a chain id.
 func (tx *Transaction) Protected() bool {
+	if tx.data.Type != LegacyTxType {
+		return true
+	}
 	return isProtectedV(tx.data.V)
 }
 
@@ -332,21 +396,46 @@ func isProtectedV(V *big.Int) bool {
 	return true
 }
 
-// EncodeRLP implements rlp.Encoder
+// EncodeRLP implements rlp.Encoder.
+// Typed transactions are wrapped in an RLP string holding their binary encoding.
 func (tx *Transaction) EncodeRLP(w io.Writer) error {
-	return rlp.Encode(w, &tx.data)
+	if tx.data.Type == LegacyTxType {
+		return rlp.Encode(w, &tx.data)
+	}
+	var buf bytes.Buffer
+	if err := tx.data.encodeTyped(&buf); err != nil {
+		return err
+	}
+	return rlp.Encode(w, buf.Bytes())
 }
 
 // DecodeRLP implements rlp.Decoder
 func (tx *Transaction) DecodeRLP(s *rlp.Stream) error {
-	_, size, _ := s.Kind()
-	err := s.Decode(&tx.data)
-	if err == nil {
+	kind, size, err := s.Kind()
+	switch {
+	case err != nil:
+		return err
+	case kind == rlp.List:
+		err = s.Decode(&tx.data)
+		if err == nil {
+			tx.size.Store(common.StorageSize(rlp.ListSize(size)))
+			tx.time = time.Now()
+		}
+		return err
+	default:
+		b, err := s.Bytes()
+		if err != nil {
+			return err
+		}
+		var data txdata
+		if err := data.decodeTyped(b); err != nil {
+			return err
+		}
+		tx.data = data
 		tx.size.Store(common.StorageSize(rlp.ListSize(size)))
 		tx.time = time.Now()
+		return nil
 	}
-
-	return err
 }
 
 // MarshalJSON encodes the web3 RPC transaction format.
@@ -364,10 +453,24 @@ func (tx *Transaction) UnmarshalJSON(input []byte) error {
 		return err
 	}
 
+	switch dec.Type {
+	case LegacyTxType, AccessListTxType:
+	case DynamicFeeTxType:
+		if dec.GasTipCap == nil {
+			return errors.New("missing required field 'maxPriorityFeePerGas' in transaction")
+		}
+	default:
+		return ErrTxTypeNotSupported
+	}
+	if dec.Type != LegacyTxType && dec.ChainID == nil {
+		return errors.New("missing required field 'chainId' in transaction")
+	}
 	withSignature := dec.V.Sign() != 0 || dec.R.Sign() != 0 || dec.S.Sign() != 0
 	if withSignature {
 		var V byte
-		if isProtectedV(dec.V) {
+		if dec.Type != LegacyTxType {
+			V = byte(dec.V.Uint64())
+		} else if isProtectedV(dec.V) {
 			chainID := deriveChainID(dec.V).Uint64()
 			V = byte(dec.V.Uint64() - 35 - 2*chainID)
 		} else {
@@ -408,7 +511,13 @@ func (tx *Transaction) Hash() common.Hash {
 	if hash := tx.hash.Load(); hash != nil {
 		return hash.(common.Hash)
 	}
-	v := hash.FromRLP(tx)
+	var v common.Hash
+	if tx.data.Type == LegacyTxType {
+		v = hash.FromRLP(tx)
+	} else {
+		enc, _ := tx.MarshalBinary()
+		v = hash.Keccak256Hash(enc)
+	}
 	tx.hash.Store(v)
 	return v
 }
@@ -429,7 +538,7 @@ func (tx *Transaction) Size() common.StorageSize {
 		return size.(common.StorageSize)
 	}
 	c := writeCounter(0)
-	rlp.Encode(&c, &tx.data)
+	rlp.Encode(&c, tx)
 	tx.size.Store(common.StorageSize(c))
 	return common.StorageSize(c)
 }
@@ -451,6 +560,10 @@ func (tx *Transaction) ConvertToEth() *EthTransaction {
 	d2.Recipient = copyAddr(d.Recipient)
 	d2.Amount = new(big.Int).Set(d.Amount)
 	d2.Payload = append(d.Payload[:0:0], d.Payload...)
+	d2.Type = d.Type
+	d2.ChainID = copyBig(d.ChainID)
+	d2.AccessList = copyAccessList(d.AccessList)
+	d2.GasTipCap = copyBig(d.GasTipCap)
 	d2.V = new(big.Int).Set(d.V)
 	d2.R = new(big.Int).Set(d.R)
 	d2.S = new(big.Int).Set(d.S)
@@ -465,17 +578,21 @@ func (tx *Transaction) ConvertToEth() *EthTransaction {
 
 // AsMessage returns the transaction as a core.Message.
 //
-// AsMessage requires a signer to derive the sender.
+// AsMessage requires a signer to derive the sender, and the base fee of the
+// block, nil before the London fork, to derive the effective gas price.
 //
 // XXX Rename message to something less arbitrary?
-func (tx *Transaction) AsMessage(s Signer) (Message, error) {
+func (tx *Transaction) AsMessage(s Signer, baseFee *big.Int) (Message, error) {
 	msg := Message{
 		nonce:      tx.data.AccountNonce,
 		gasLimit:   tx.data.GasLimit,
-		gasPrice:   new(big.Int).Set(tx.data.Price),
+		gasPrice:   EffectiveGasPrice(tx.GasTipCap(), tx.GasFeeCap(), baseFee),
+		gasFeeCap:  new(big.Int).Set(tx.GasFeeCap()),
+		gasTipCap:  new(big.Int).Set(tx.GasTipCap()),
 		to:         tx.data.Recipient,
 		amount:     tx.data.Amount,
 		data:       tx.data.Payload,
+		accessList: tx.data.AccessList,
 		checkNonce: true,
 	}
 
@@ -565,29 +682,36 @@ func (s *TxByPrice) Pop() interface{} {
 
 // TxByPriceAndTime implements both the sort and the heap interface, making it useful
 // for all at once sorting as well as individually adding and removing elements.
-type TxByPriceAndTime Transactions
+// Transactions are ordered by the tip they pay on top of baseFee, which is
+// their whole gas price before the London fork.
+type TxByPriceAndTime struct {
+	txs     Transactions
+	baseFee *big.Int
+}
 
-func (s TxByPriceAndTime) Len() int { return len(s) }
+func (s TxByPriceAndTime) Len() int { return len(s.txs) }
 func (s TxByPriceAndTime) Less(i, j int) bool {
-	// If the prices are equal, use the time the transaction was first seen for
+	// If the tips are equal, use the time the transaction was first seen for
 	// deterministic sorting
-	cmp := s[i].data.Price.Cmp(s[j].data.Price)
+	tipI, _ := s.txs[i].EffectiveGasTip(s.baseFee)
+	tipJ, _ := s.txs[j].EffectiveGasTip(s.baseFee)
+	cmp := tipI.Cmp(tipJ)
 	if cmp == 0 {
-		return s[i].time.Before(s[j].time)
+		return s.txs[i].time.Before(s.txs[j].time)
 	}
 	return cmp > 0
 }
-func (s TxByPriceAndTime) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+func (s TxByPriceAndTime) Swap(i, j int) { s.txs[i], s.txs[j] = s.txs[j], s.txs[i] }
 
 func (s *TxByPriceAndTime) Push(x interface{}) {
-	*s = append(*s, x.(*Transaction))
+	s.txs = append(s.txs, x.(*Transaction))
 }
 
 func (s *TxByPriceAndTime) Pop() interface{} {
-	old := *s
+	old := s.txs
 	n := len(old)
 	x := old[n-1]
-	*s = old[0 : n-1]
+	s.txs = old[0 : n-1]
 	return x
 }
 
@@ -602,24 +726,33 @@ type TransactionsByPriceAndNonce struct {
 }
 
 // NewTransactionsByPriceAndNonce creates a transaction set that can retrieve
-// price sorted transactions in a nonce-honouring way.
+// price sorted transactions in a nonce-honouring way. Transactions whose fee
+// cap does not cover baseFee, nil before the London fork, are left out along
+// with the rest of their account.
 //
 // Note, the input map is reowned so the caller should not interact any more with
 // if after providing it to the constructor.
-func NewTransactionsByPriceAndNonce(hmySigner Signer, ethSigner Signer, txs map[common.Address]Transactions) *TransactionsByPriceAndNonce {
+func NewTransactionsByPriceAndNonce(hmySigner Signer, ethSigner Signer, txs map[common.Address]Transactions, baseFee *big.Int) *TransactionsByPriceAndNonce {
 	// Initialize a price based heap with the head transactions
-	heads := make(TxByPriceAndTime, 0, len(txs))
+	heads := TxByPriceAndTime{
+		txs:     make(Transactions, 0, len(txs)),
+		baseFee: baseFee,
+	}
 	for from, accTxs := range txs {
 		if accTxs.Len() == 0 {
 			continue
 		}
-		heads = append(heads, accTxs[0])
 		// Ensure the sender address is from the signer
 		signer := hmySigner
 		if accTxs[0].IsEthCompatible() {
 			signer = ethSigner
 		}
 		acc, _ := Sender(signer, accTxs[0])
+		if _, err := accTxs[0].EffectiveGasTip(baseFee); err != nil {
+			delete(txs, from)
+			continue
+		}
+		heads.txs = append(heads.txs, accTxs[0])
 		txs[acc] = accTxs[1:]
 		if from != acc {
 			delete(txs, from)
@@ -638,28 +771,30 @@ func NewTransactionsByPriceAndNonce(hmySigner Signer, ethSigner Signer, txs map[
 
 // Peek returns the next transaction by price.
 func (t *TransactionsByPriceAndNonce) Peek() *Transaction {
-	if len(t.heads) == 0 {
+	if len(t.heads.txs) == 0 {
 		return nil
 	}
-	return t.heads[0]
+	return t.heads.txs[0]
 }
 
 // Shift replaces the current best head with the next one from the same account.
 func (t *TransactionsByPriceAndNonce) Shift() {
-	if len(t.heads) == 0 {
+	if len(t.heads.txs) == 0 {
 		return
 	}
 	signer := t.signer
-	if t.heads[0].IsEthCompatible() {
+	if t.heads.txs[0].IsEthCompatible() {
 		signer = t.ethSigner
 	}
-	acc, _ := Sender(signer, t.heads[0])
+	acc, _ := Sender(signer, t.heads.txs[0])
 	if txs, ok := t.txs[acc]; ok && len(txs) > 0 {
-		t.heads[0], t.txs[acc] = txs[0], txs[1:]
-		heap.Fix(&t.heads, 0)
-	} else {
-		heap.Pop(&t.heads)
+		if _, err := txs[0].EffectiveGasTip(t.heads.baseFee); err == nil {
+			t.heads.txs[0], t.txs[acc] = txs[0], txs[1:]
+			heap.Fix(&t.heads, 0)
+			return
+		}
 	}
+	heap.Pop(&t.heads)
 }
 
 // Pop removes the best transaction, *not* replacing it with the next one from
@@ -678,7 +813,10 @@ type Message struct {
 	amount     *big.Int
 	gasLimit   uint64
 	gasPrice   *big.Int
+	gasFeeCap  *big.Int
+	gasTipCap  *big.Int
 	data       []byte
+	accessList AccessList
 	checkNonce bool
 	blockNum   *big.Int
 	txType     TransactionType
@@ -693,6 +831,8 @@ func NewMessage(from common.Address, to *common.Address, nonce uint64, amount *b
 		amount:     amount,
 		gasLimit:   gasLimit,
 		gasPrice:   gasPrice,
+		gasFeeCap:  gasPrice,
+		gasTipCap:  gasPrice,
 		data:       data,
 		checkNonce: checkNonce,
 	}
@@ -706,6 +846,8 @@ func NewStakingMessage(from common.Address, nonce uint64, gasLimit uint64, gasPr
 		nonce:      nonce,
 		gasLimit:   gasLimit,
 		gasPrice:   new(big.Int).Set(gasPrice),
+		gasFeeCap:  new(big.Int).Set(gasPrice),
+		gasTipCap:  new(big.Int).Set(gasPrice),
 		data:       data,
 		checkNonce: true,
 		blockNum:   blockNum,
@@ -727,6 +869,16 @@ func (m Message) GasPrice() *big.Int {
 	return m.gasPrice
 }
 
+// GasFeeCap returns the max fee per gas of the Message.
+func (m Message) GasFeeCap() *big.Int {
+	return m.gasFeeCap
+}
+
+// GasTipCap returns the max priority fee per gas of the Message.
+func (m Message) GasTipCap() *big.Int {
+	return m.gasTipCap
+}
+
 // Value returns the value amount from Message.
 func (m Message) Value() *big.Int {
 	return m.amount
@@ -767,6 +919,24 @@ func (m Message) BlockNum() *big.Int {
 	return m.blockNum
 }
 
+// AccessList returns the EIP-2930 access list of the Message.
+func (m Message) AccessList() AccessList {
+	return m.accessList
+}
+
+// SetAccessList set the EIP-2930 access list of the Message
+func (m *Message) SetAccessList(accessList AccessList) {
+	m.accessList = accessList
+}
+
+// SetGasFees set the EIP-1559 fee caps of the Message, together with the
+// effective gas price they pay
+func (m *Message) SetGasFees(gasPrice, gasFeeCap, gasTipCap *big.Int) {
+	m.gasPrice = gasPrice
+	m.gasFeeCap = gasFeeCap
+	m.gasTipCap = gasTipCap
+}
+
 // RecentTxsStats is a recent transactions stats map tracking stats like BlockTxsCounts.
 type RecentTxsStats map[uint64]BlockTxsCounts
 
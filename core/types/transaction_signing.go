@@ -136,7 +136,10 @@ func (s EIP155Signer) Equal(s2 Signer) bool {
 	return ok && eip155.chainID.Cmp(s.chainID) == 0
 }
 
-var big8 = big.NewInt(8)
+var (
+	big8  = big.NewInt(8)
+	big27 = big.NewInt(27)
+)
 
 // Sender returns the sender address of the given signer.
 func (s EIP155Signer) Sender(tx InternalTransaction) (common.Address, error) {
@@ -148,6 +151,19 @@ func (s EIP155Signer) Sender(tx InternalTransaction) (common.Address, error) {
 	if tx.ChainID().Cmp(ethChainID) != 0 && tx.ChainID().Cmp(s.chainID) != 0 {
 		return common.Address{}, ErrInvalidChainID
 	}
+	switch tx.Type() {
+	case LegacyTxType:
+	case AccessListTxType, DynamicFeeTxType:
+		// typed transactions carry the chain id in their payload and
+		// the y parity of the signature as V
+		if tx.ChainID().Cmp(s.chainID) != 0 {
+			return common.Address{}, ErrInvalidChainID
+		}
+		V := new(big.Int).Add(tx.V(), big27)
+		return recoverPlain(s.Hash(tx), tx.R(), tx.S(), V, true)
+	default:
+		return common.Address{}, ErrTxTypeNotSupported
+	}
 	V := new(big.Int).Sub(tx.V(), s.chainIDMul)
 	V.Sub(V, big8)
 	return recoverPlain(s.Hash(tx), tx.R(), tx.S(), V, true)
@@ -156,6 +172,17 @@ func (s EIP155Signer) Sender(tx InternalTransaction) (common.Address, error) {
 // SignatureValues returns signature values. This signature
 // needs to be in the [R || S || V] format where V is 0 or 1.
 func (s EIP155Signer) SignatureValues(tx InternalTransaction, sig []byte) (R, S, V *big.Int, err error) {
+	switch tx.Type() {
+	case LegacyTxType:
+	case AccessListTxType, DynamicFeeTxType:
+		if tx.ChainID().Sign() != 0 && tx.ChainID().Cmp(s.chainID) != 0 {
+			return nil, nil, nil, ErrInvalidChainID
+		}
+		R, S, _ = decodeSignature(sig)
+		return R, S, big.NewInt(int64(sig[64])), nil
+	default:
+		return nil, nil, nil, ErrTxTypeNotSupported
+	}
 	R, S, V, err = HomesteadSigner{}.SignatureValues(tx, sig)
 	if err != nil {
 		return nil, nil, nil, err
@@ -170,6 +197,9 @@ func (s EIP155Signer) SignatureValues(tx InternalTransaction, sig []byte) (R, S,
 // Hash returns the hash to be signed by the sender.
 // It does not uniquely identify the transaction.
 func (s EIP155Signer) Hash(tx InternalTransaction) common.Hash {
+	if tx.Type() != LegacyTxType {
+		return s.typedHash(tx)
+	}
 	if params.IsEthCompatible(s.chainID) {
 		// following the same logic as in go-eth implementation
 		return hash.FromRLP([]interface{}{
@@ -195,6 +225,62 @@ func (s EIP155Signer) Hash(tx InternalTransaction) common.Hash {
 	})
 }
 
+// typedHash returns the EIP-2718 signing hash type || rlp(payload without signature).
+func (s EIP155Signer) typedHash(tx InternalTransaction) common.Hash {
+	if tx.Type() == DynamicFeeTxType {
+		if params.IsEthCompatible(s.chainID) {
+			return prefixedRlpHash(tx.Type(), []interface{}{
+				s.chainID,
+				tx.Nonce(),
+				tx.GasTipCap(),
+				tx.GasFeeCap(),
+				tx.GasLimit(),
+				tx.To(),
+				tx.Value(),
+				tx.Data(),
+				tx.AccessList(),
+			})
+		}
+		return prefixedRlpHash(tx.Type(), []interface{}{
+			s.chainID,
+			tx.Nonce(),
+			tx.GasTipCap(),
+			tx.GasFeeCap(),
+			tx.GasLimit(),
+			tx.ShardID(),
+			tx.ToShardID(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+			tx.AccessList(),
+		})
+	}
+	if params.IsEthCompatible(s.chainID) {
+		return prefixedRlpHash(tx.Type(), []interface{}{
+			s.chainID,
+			tx.Nonce(),
+			tx.GasPrice(),
+			tx.GasLimit(),
+			tx.To(),
+			tx.Value(),
+			tx.Data(),
+			tx.AccessList(),
+		})
+	}
+	return prefixedRlpHash(tx.Type(), []interface{}{
+		s.chainID,
+		tx.Nonce(),
+		tx.GasPrice(),
+		tx.GasLimit(),
+		tx.ShardID(),
+		tx.ToShardID(),
+		tx.To(),
+		tx.Value(),
+		tx.Data(),
+		tx.AccessList(),
+	})
+}
+
 // HomesteadSigner implements InternalTransaction using the
 // homestead rules.
 type HomesteadSigner struct{ FrontierSigner }
@@ -213,6 +299,9 @@ func (hs HomesteadSigner) SignatureValues(tx InternalTransaction, sig []byte) (r
 
 // Sender returns the address of the sender.
 func (hs HomesteadSigner) Sender(tx InternalTransaction) (common.Address, error) {
+	if tx.Type() != LegacyTxType {
+		return common.Address{}, ErrTxTypeNotSupported
+	}
 	return recoverPlain(hs.Hash(tx), tx.R(), tx.S(), tx.V(), true)
 }
 
@@ -228,12 +317,10 @@ func (fs FrontierSigner) Equal(s2 Signer) bool {
 // SignatureValues returns signature values. This signature
 // needs to be in the [R || S || V] format where V is 0 or 1.
 func (fs FrontierSigner) SignatureValues(tx InternalTransaction, sig []byte) (r, s, v *big.Int, err error) {
-	if len(sig) != 65 {
-		panic(fmt.Sprintf("wrong size for signature: got %d, want 65", len(sig)))
+	if tx.Type() != LegacyTxType {
+		return nil, nil, nil, ErrTxTypeNotSupported
 	}
-	r = new(big.Int).SetBytes(sig[:32])
-	s = new(big.Int).SetBytes(sig[32:64])
-	v = new(big.Int).SetBytes([]byte{sig[64] + 27})
+	r, s, v = decodeSignature(sig)
 	return r, s, v, nil
 }
 
@@ -254,9 +341,23 @@ func (fs FrontierSigner) Hash(tx InternalTransaction) common.Hash {
 
 // Sender returns the sender address of the given transaction.
 func (fs FrontierSigner) Sender(tx InternalTransaction) (common.Address, error) {
+	if tx.Type() != LegacyTxType {
+		return common.Address{}, ErrTxTypeNotSupported
+	}
 	return recoverPlain(fs.Hash(tx), tx.R(), tx.S(), tx.V(), false)
 }
 
+// decodeSignature splits a [R || S || V] signature, returning V as v+27.
+func decodeSignature(sig []byte) (r, s, v *big.Int) {
+	if len(sig) != 65 {
+		panic(fmt.Sprintf("wrong size for signature: got %d, want 65", len(sig)))
+	}
+	r = new(big.Int).SetBytes(sig[:32])
+	s = new(big.Int).SetBytes(sig[32:64])
+	v = new(big.Int).SetBytes([]byte{sig[64] + 27})
+	return r, s, v
+}
+
 func recoverPlain(sighash common.Hash, R, S, Vb *big.Int, homestead bool) (common.Address, error) {
 	if Vb.BitLen() > 8 {
 		return common.Address{}, ErrInvalidSig
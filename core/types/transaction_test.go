@@ -55,7 +55,7 @@ func TestTransactionPriceNonceSort(t *testing.T) {
 		}
 	}
 	// Sort the transactions and cross check the nonce ordering
-	txset := NewTransactionsByPriceAndNonce(signer, signer, groups)
+	txset := NewTransactionsByPriceAndNonce(signer, signer, groups, nil)
 
 	txs := InternalTransactions{}
 	for tx := txset.Peek(); tx != nil; tx = txset.Peek() {
@@ -162,6 +162,7 @@ func TestTransactionTimeSort(t *testing.T) {
 		NewEIP155Signer(config.ChainID),
 		NewEIP155Signer(config.EthCompatibleChainID),
 		groups,
+		nil,
 	)
 
 	txs := Transactions{}
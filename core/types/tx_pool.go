@@ -31,6 +31,8 @@ type PoolTransaction interface {
 	SenderAddress() (common.Address, error)
 	Size() common.StorageSize
 	Cost() (*big.Int, error)
+	GasFeeCap() *big.Int
+	GasTipCap() *big.Int
 	EncodeRLP(w io.Writer) error
 	DecodeRLP(s *rlp.Stream) error
 }
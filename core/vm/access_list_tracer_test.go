@@ -0,0 +1,47 @@
+package vm
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+func TestAccessListTracer(t *testing.T) {
+	var (
+		from     = common.HexToAddress("0x01")
+		to       = common.HexToAddress("0x02")
+		touched  = common.HexToAddress("0x03")
+		precomp  = common.HexToAddress("0x04")
+		contract = NewContract(AccountRef(from), AccountRef(to), new(big.Int), 0)
+		slot     = common.BigToHash(big.NewInt(7))
+	)
+	tracer := NewAccessListTracer(nil, from, to, []common.Address{precomp})
+
+	stack := newstack()
+	stack.push(big.NewInt(7))
+	tracer.CaptureState(nil, 0, SLOAD, 0, 0, nil, stack, contract, 1, nil)
+
+	// CALL has the address second from the top of the stack
+	for _, addr := range []common.Address{touched, precomp, from} {
+		stack = newstack()
+		stack.pushN(new(big.Int), new(big.Int), new(big.Int), new(big.Int), new(big.Int), addr.Big(), new(big.Int))
+		tracer.CaptureState(nil, 0, CALL, 0, 0, nil, stack, contract, 1, nil)
+	}
+
+	acl := tracer.AccessList()
+	if len(acl) != 2 {
+		t.Fatalf("wrong access list length: have %d want 2: %v", len(acl), acl)
+	}
+	want := NewAccessListTracer(types.AccessList{
+		{Address: to, StorageKeys: []common.Hash{slot}},
+		{Address: touched, StorageKeys: []common.Hash{}},
+	}, from, to, nil)
+	if !tracer.Equal(want) {
+		t.Errorf("wrong access list: have %v want %v", acl, want.AccessList())
+	}
+	if tracer.Equal(NewAccessListTracer(nil, from, to, nil)) {
+		t.Error("access list equals the empty one")
+	}
+}
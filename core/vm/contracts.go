@@ -153,6 +153,44 @@ func init() {
 	}
 }
 
+// ActivePrecompiles returns the addresses of the precompiles, read only and
+// write capable, which are enabled under the given rules.
+func ActivePrecompiles(rules params.Rules) []common.Address {
+	precompiles := PrecompiledContractsHomestead
+	var writeCapablePrecompiles map[common.Address]WriteCapablePrecompiledContract
+	if rules.IsS3 {
+		precompiles = PrecompiledContractsByzantium
+	}
+	if rules.IsIstanbul {
+		precompiles = PrecompiledContractsIstanbul
+	}
+	if rules.IsVRF {
+		precompiles = PrecompiledContractsVRF
+	}
+	if rules.IsSHA3 {
+		precompiles = PrecompiledContractsSHA3FIPS
+	}
+	if rules.IsStakingPrecompile {
+		precompiles = PrecompiledContractsStaking
+		writeCapablePrecompiles = WriteCapablePrecompiledContractsStaking
+	}
+	if rules.IsCrossShardXferPrecompile {
+		writeCapablePrecompiles = WriteCapablePrecompiledContractsCrossXfer
+	}
+	addresses := make([]common.Address, 0, len(precompiles)+len(writeCapablePrecompiles))
+	for address, contract := range precompiles {
+		if contract != nil {
+			addresses = append(addresses, address)
+		}
+	}
+	for address, contract := range writeCapablePrecompiles {
+		if contract != nil {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
 // RunPrecompiledContract runs and evaluates the output of a precompiled contract.
 func RunPrecompiledContract(p PrecompiledContract, input []byte, contract *Contract) (ret []byte, err error) {
 	gas := p.RequiredGas(input)
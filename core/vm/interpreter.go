@@ -50,6 +50,9 @@ type Config struct {
 
 	// ExtraEips the additional EIPS that are to be enabled
 	ExtraEips []int
+
+	// NoBaseFee forces the EIP-1559 base fee to 0 (needed for 0 price calls)
+	NoBaseFee bool
 }
 
 // Interpreter is used to run Ethereum based contracts and will utilise the
@@ -104,6 +107,14 @@ func NewEVMInterpreter(evm *EVM, cfg Config) *EVMInterpreter {
 	if !cfg.JumpTable[STOP].valid {
 		var jt JumpTable
 		switch {
+		case evm.chainRules.IsCancun:
+			jt = cancunInstructionSet
+		case evm.chainRules.IsShanghai:
+			jt = shanghaiInstructionSet
+		case evm.chainRules.IsLondon:
+			jt = londonInstructionSet
+		case evm.chainRules.IsBerlin:
+			jt = berlinInstructionSet
 		case evm.chainRules.IsIstanbul:
 			jt = istanbulInstructionSet
 		case evm.chainRules.IsS3:
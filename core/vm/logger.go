@@ -114,6 +114,25 @@ type Tracer interface {
 	CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) error
 }
 
+// CallFrameTracer is an optional extension of Tracer for tracers interested in
+// the nested call frames of a transaction. CaptureStart and CaptureEnd only
+// report the outermost frame, CaptureEnter and CaptureExit report every frame
+// below it.
+type CallFrameTracer interface {
+	Tracer
+	CaptureEnter(typ OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int)
+	CaptureExit(output []byte, gasUsed uint64, err error)
+}
+
+// TxTracer is an optional extension of Tracer for tracers interested in the
+// gas of the whole transaction, including the intrinsic gas and the refund
+// which are not part of the outermost call frame.
+type TxTracer interface {
+	Tracer
+	CaptureTxStart(gasLimit uint64)
+	CaptureTxEnd(restGas uint64)
+}
+
 // StructLogger is an EVM state logger and implements Tracer.
 //
 // StructLogger can capture state based on the given Log configuration and also keeps
@@ -42,6 +42,7 @@ type Config struct {
 	Time        *big.Int
 	GasLimit    uint64
 	GasPrice    *big.Int
+	BaseFee     *big.Int
 	Value       *big.Int
 	Debug       bool
 	EVMConfig   vm.Config
@@ -200,3 +200,51 @@ func BenchmarkEVM_CREATE2_1200(bench *testing.B) {
 	// initcode size 1200K, repeatedly calls CREATE2 and then modifies the mem contents
 	benchmarkEVMCreate(bench, "5b5862124f80600080f5600152600056")
 }
+
+func TestCancunOpcodes(t *testing.T) {
+	cfg := &Config{ChainConfig: params.TestChainConfig, BaseFee: big.NewInt(7)}
+	// TSTORE 1 at slot 0, copy BASEFEE and the TLOAD result through memory
+	// with MCOPY and return them
+	ret, _, err := Execute([]byte{
+		byte(vm.PUSH1), 1,
+		byte(vm.PUSH0),
+		byte(vm.TSTORE),
+		byte(vm.BASEFEE),
+		byte(vm.PUSH0),
+		byte(vm.MSTORE),
+		byte(vm.PUSH0),
+		byte(vm.TLOAD),
+		byte(vm.PUSH1), 32,
+		byte(vm.MSTORE),
+		byte(vm.PUSH1), 64, // length
+		byte(vm.PUSH0),     // source
+		byte(vm.PUSH1), 64, // destination
+		byte(vm.MCOPY),
+		byte(vm.PUSH1), 64,
+		byte(vm.PUSH1), 64,
+		byte(vm.RETURN),
+	}, nil, cfg)
+	if err != nil {
+		t.Fatal("didn't expect error", err)
+	}
+	if len(ret) != 64 {
+		t.Fatalf("wrong return size: have %d want 64", len(ret))
+	}
+	if baseFee := new(big.Int).SetBytes(ret[:32]); baseFee.Cmp(big.NewInt(7)) != 0 {
+		t.Error("Expected base fee 7, got", baseFee)
+	}
+	if val := new(big.Int).SetBytes(ret[32:]); val.Cmp(big.NewInt(1)) != 0 {
+		t.Error("Expected transient value 1, got", val)
+	}
+}
+
+func TestPush0BeforeShanghai(t *testing.T) {
+	// the default chain config has no shanghai epoch
+	_, _, err := Execute([]byte{
+		byte(vm.PUSH0),
+		byte(vm.STOP),
+	}, nil, nil)
+	if err == nil {
+		t.Fatal("expected PUSH0 to be an invalid opcode")
+	}
+}
@@ -18,12 +18,16 @@ package rpc
 
 import (
 	"net"
+	"net/http"
 
 	"github.com/ethereum/go-ethereum/log"
 )
 
 // StartHTTPEndpoint starts the HTTP RPC endpoint, configured with cors/vhosts/modules
-func StartHTTPEndpoint(endpoint string, apis []API, modules []string, rmf *RpcMethodFilter, cors []string, vhosts []string, timeouts HTTPTimeouts) (net.Listener, *Server, error) {
+// and the request limits of every client, if any.
+// The requests must carry a token signed with jwtSecret, unless it is empty.
+// The handlers are served next to the RPC server, at their path.
+func StartHTTPEndpoint(endpoint string, apis []API, modules []string, rmf *RpcMethodFilter, cors []string, vhosts []string, timeouts HTTPTimeouts, limits *RequestLimits, jwtSecret []byte, handlers map[string]http.Handler) (net.Listener, *Server, error) {
 	// Generate the whitelist based on the allowed modules
 	whitelist := make(map[string]bool)
 	for _, module := range modules {
@@ -31,6 +35,7 @@ func StartHTTPEndpoint(endpoint string, apis []API, modules []string, rmf *RpcMe
 	}
 	// Register all the APIs exposed by the services
 	handler := NewServer()
+	handler.SetRequestLimits(limits)
 	for _, api := range apis {
 		if whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
 			if err := handler.RegisterName(api.Namespace, api.Service, rmf); err != nil {
@@ -47,12 +52,26 @@ func StartHTTPEndpoint(endpoint string, apis []API, modules []string, rmf *RpcMe
 	if listener, err = net.Listen("tcp", endpoint); err != nil {
 		return nil, nil, err
 	}
-	go NewHTTPServer(cors, vhosts, timeouts, handler).Serve(listener)
+	var srv http.Handler = handler
+	if len(jwtSecret) > 0 {
+		srv = newJWTHandler(jwtSecret, handler)
+	}
+	if len(handlers) > 0 {
+		mux := http.NewServeMux()
+		mux.Handle("/", srv)
+		for path, h := range handlers {
+			mux.Handle(path, h)
+		}
+		srv = mux
+	}
+	go NewHTTPServer(cors, vhosts, timeouts, srv).Serve(listener)
 	return listener, handler, err
 }
 
-// StartWSEndpoint starts a websocket endpoint
-func StartWSEndpoint(endpoint string, apis []API, modules []string, rmf *RpcMethodFilter, wsOrigins []string, exposeAll bool) (net.Listener, *Server, error) {
+// StartWSEndpoint starts a websocket endpoint, with the request limits of every
+// client, if any.
+// The connections must carry a token signed with jwtSecret, unless it is empty.
+func StartWSEndpoint(endpoint string, apis []API, modules []string, rmf *RpcMethodFilter, wsOrigins []string, exposeAll bool, limits *RequestLimits, jwtSecret []byte) (net.Listener, *Server, error) {
 
 	// Generate the whitelist based on the allowed modules
 	whitelist := make(map[string]bool)
@@ -61,6 +80,7 @@ func StartWSEndpoint(endpoint string, apis []API, modules []string, rmf *RpcMeth
 	}
 	// Register all the APIs exposed by the services
 	handler := NewServer()
+	handler.SetRequestLimits(limits)
 	for _, api := range apis {
 		if exposeAll || whitelist[api.Namespace] || (len(whitelist) == 0 && api.Public) {
 			if err := handler.RegisterName(api.Namespace, api.Service, rmf); err != nil {
@@ -77,7 +97,11 @@ func StartWSEndpoint(endpoint string, apis []API, modules []string, rmf *RpcMeth
 	if listener, err = net.Listen("tcp", endpoint); err != nil {
 		return nil, nil, err
 	}
-	go NewWSServer(wsOrigins, handler).Serve(listener)
+	srv := NewWSServer(wsOrigins, handler)
+	if len(jwtSecret) > 0 {
+		srv.Handler = newJWTHandler(jwtSecret, srv.Handler)
+	}
+	go srv.Serve(listener)
 	return listener, handler, err
 
 }
@@ -60,6 +60,7 @@ type handler struct {
 	conn           jsonWriter                     // where responses will be sent
 	log            log.Logger
 	allowSubscribe bool
+	limits         *clientLimits // request limits of the client, nil for no limits
 
 	subLock    sync.Mutex
 	serverSubs map[ID]*Subscription
@@ -101,6 +102,15 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 		return
 	}
 
+	// Reject batches with too many requests:
+	if h.limits != nil && h.limits.batchItems > 0 && len(msgs) > h.limits.batchItems {
+		doMetricRejectedRequest("batch", "batch_items")
+		h.startCallProc(func(cp *callProc) {
+			h.conn.writeJSON(cp.ctx, errorMessage(&invalidRequestError{"batch too large"}))
+		})
+		return
+	}
+
 	// Handle non-call messages first:
 	calls := make([]*jsonrpcMessage, 0, len(msgs))
 	for _, msg := range msgs {
@@ -114,8 +124,18 @@ func (h *handler) handleBatch(msgs []*jsonrpcMessage) {
 	// Process calls on a goroutine because they may block indefinitely:
 	h.startCallProc(func(cp *callProc) {
 		answers := make([]*jsonrpcMessage, 0, len(msgs))
+		respSize := 0
 		for _, msg := range calls {
+			// Once the response is too large, the remaining calls are not executed
+			if h.limits != nil && h.limits.batchRespMax > 0 && respSize > h.limits.batchRespMax {
+				doMetricRejectedRequest(h.metricMethod(msg), "response_size")
+				if msg.hasValidID() {
+					answers = append(answers, msg.errorResponse(&responseTooLargeError{"response too large"}))
+				}
+				continue
+			}
 			if answer := h.handleCallMsg(cp, msg); answer != nil {
+				respSize += len(answer.Result)
 				answers = append(answers, answer)
 			}
 		}
@@ -308,8 +328,26 @@ func (h *handler) handleCallMsg(ctx *callProc, msg *jsonrpcMessage) *jsonrpcMess
 	}
 }
 
+// metricMethod returns the method of the message as a metric label. The
+// methods which are not served are labeled unknownMethodLabel, since the
+// clients can send any method name.
+func (h *handler) metricMethod(msg *jsonrpcMessage) string {
+	if msg.isSubscribe() || msg.isUnsubscribe() {
+		if h.reg.hasService(msg.namespace()) {
+			return msg.Method
+		}
+	} else if h.reg.callback(msg.Method) != nil {
+		return msg.Method
+	}
+	return unknownMethodLabel
+}
+
 // handleCall processes method calls.
 func (h *handler) handleCall(cp *callProc, msg *jsonrpcMessage) *jsonrpcMessage {
+	if h.limits != nil && !h.limits.allow(msg.Method) {
+		doMetricRejectedRequest(h.metricMethod(msg), "rate_limit")
+		return msg.errorResponse(&limitExceededError{"request rate limit exceeded"})
+	}
 	if msg.isSubscribe() {
 		return h.handleSubscribe(cp, msg)
 	}
@@ -0,0 +1,172 @@
+package rpc
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// jwtSecretLength is the length in bytes of a JWT secret.
+	jwtSecretLength = 32
+	// jwtIssuedAtSkew is the maximum difference allowed between the issued at
+	// time of a token and the local time.
+	jwtIssuedAtSkew = 60 * time.Second
+)
+
+var (
+	errJWTMissingToken     = errors.New("missing token")
+	errJWTMalformed        = errors.New("malformed token")
+	errJWTAlgorithm        = errors.New("unsupported signing algorithm")
+	errJWTSignature        = errors.New("invalid signature")
+	errJWTMissingIssuedAt  = errors.New("missing issued-at")
+	errJWTIssuedAtTooStale = errors.New("stale token")
+	errJWTIssuedAtInFuture = errors.New("future token")
+	errJWTExpired          = errors.New("token is expired")
+)
+
+// jwtHeader is the JOSE header of a token.
+type jwtHeader struct {
+	Alg string `json:"alg"`
+	Typ string `json:"typ,omitempty"`
+}
+
+// jwtClaims are the claims checked by the server, the others are ignored.
+type jwtClaims struct {
+	IssuedAt  *int64 `json:"iat"`
+	ExpiresAt *int64 `json:"exp,omitempty"`
+}
+
+// jwtHandler is a http.Handler which only passes the requests with a valid
+// HS256 token in the Authorization header to the next handler.
+type jwtHandler struct {
+	secret []byte
+	next   http.Handler
+}
+
+// newJWTHandler wraps next with the authentication of the tokens signed with
+// secret.
+func newJWTHandler(secret []byte, next http.Handler) http.Handler {
+	return &jwtHandler{secret: secret, next: next}
+}
+
+// ServeHTTP implements http.Handler.
+func (h *jwtHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		http.Error(w, errJWTMissingToken.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err := verifyJWT(strings.TrimPrefix(auth, "Bearer "), h.secret, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	h.next.ServeHTTP(w, r)
+}
+
+// verifyJWT checks that token is signed with secret using HS256 and that it was
+// issued within jwtIssuedAtSkew of now.
+func verifyJWT(token string, secret []byte, now time.Time) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return errJWTMalformed
+	}
+	var header jwtHeader
+	if err := decodeJWTSegment(parts[0], &header); err != nil {
+		return err
+	}
+	if header.Alg != "HS256" {
+		return errJWTAlgorithm
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return errJWTMalformed
+	}
+	if !hmac.Equal(signature, signJWT(parts[0]+"."+parts[1], secret)) {
+		return errJWTSignature
+	}
+	var claims jwtClaims
+	if err := decodeJWTSegment(parts[1], &claims); err != nil {
+		return err
+	}
+	if claims.IssuedAt == nil {
+		return errJWTMissingIssuedAt
+	}
+	issuedAt := time.Unix(*claims.IssuedAt, 0)
+	if issuedAt.Before(now.Add(-jwtIssuedAtSkew)) {
+		return errJWTIssuedAtTooStale
+	}
+	if issuedAt.After(now.Add(jwtIssuedAtSkew)) {
+		return errJWTIssuedAtInFuture
+	}
+	if claims.ExpiresAt != nil && !now.Before(time.Unix(*claims.ExpiresAt, 0)) {
+		return errJWTExpired
+	}
+	return nil
+}
+
+// decodeJWTSegment decodes a base64url encoded JSON segment of a token into v.
+func decodeJWTSegment(segment string, v interface{}) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return errJWTMalformed
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return errJWTMalformed
+	}
+	return nil
+}
+
+// signJWT returns the HS256 signature of the signing input of a token.
+func signJWT(input string, secret []byte) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(input))
+	return mac.Sum(nil)
+}
+
+// NewJWTToken returns a HS256 token signed with secret, issued at iat, for
+// the clients of the auth endpoints.
+func NewJWTToken(secret []byte, iat time.Time) string {
+	header, _ := json.Marshal(jwtHeader{Alg: "HS256", Typ: "JWT"})
+	issuedAt := iat.Unix()
+	claims, _ := json.Marshal(jwtClaims{IssuedAt: &issuedAt})
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	return input + "." + base64.RawURLEncoding.EncodeToString(signJWT(input, secret))
+}
+
+// ObtainJWTSecret loads the hex encoded JWT secret from fileName, or generates
+// and stores a new one if the file does not exist.
+func ObtainJWTSecret(fileName string) ([]byte, error) {
+	if data, err := os.ReadFile(fileName); err == nil {
+		secret, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWT secret in %s: %v", fileName, err)
+		}
+		if len(secret) != jwtSecretLength {
+			return nil, fmt.Errorf("invalid JWT secret in %s: length %d, want %d", fileName, len(secret), jwtSecretLength)
+		}
+		return secret, nil
+	} else if !os.IsNotExist(err) {
+		return nil, err
+	}
+	secret := make([]byte, jwtSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(fileName, []byte(hex.EncodeToString(secret)), 0600); err != nil {
+		return nil, err
+	}
+	log.Info("Generated JWT secret", "path", fileName)
+	return secret, nil
+}
@@ -0,0 +1,107 @@
+package rpc
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyJWT(t *testing.T) {
+	var (
+		secret = bytes.Repeat([]byte{0x42}, jwtSecretLength)
+		now    = time.Unix(1700000000, 0)
+	)
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{"valid", NewJWTToken(secret, now), nil},
+		{"skew within limit", NewJWTToken(secret, now.Add(-jwtIssuedAtSkew)), nil},
+		{"stale", NewJWTToken(secret, now.Add(-jwtIssuedAtSkew-time.Second)), errJWTIssuedAtTooStale},
+		{"future", NewJWTToken(secret, now.Add(jwtIssuedAtSkew+time.Second)), errJWTIssuedAtInFuture},
+		{"wrong secret", NewJWTToken(bytes.Repeat([]byte{0x24}, jwtSecretLength), now), errJWTSignature},
+		{"malformed", "abc.def", errJWTMalformed},
+		{"none algorithm", jwtWithSegments(`{"alg":"none"}`, `{"iat":1700000000}`, secret), errJWTAlgorithm},
+		{"missing iat", jwtWithSegments(`{"alg":"HS256"}`, `{}`, secret), errJWTMissingIssuedAt},
+		{"expired", jwtWithSegments(`{"alg":"HS256"}`, `{"iat":1700000000,"exp":1700000000}`, secret), errJWTExpired},
+	}
+	for _, test := range tests {
+		if err := verifyJWT(test.token, secret, now); err != test.want {
+			t.Errorf("%s: have error %v, want %v", test.name, err, test.want)
+		}
+	}
+}
+
+// jwtWithSegments returns a token of the given JSON header and claims signed
+// with secret.
+func jwtWithSegments(header, claims string, secret []byte) string {
+	input := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." + base64.RawURLEncoding.EncodeToString([]byte(claims))
+	return input + "." + base64.RawURLEncoding.EncodeToString(signJWT(input, secret))
+}
+
+func TestJWTHandler(t *testing.T) {
+	var (
+		secret  = bytes.Repeat([]byte{0x42}, jwtSecretLength)
+		srv     = newTestServer()
+		httpsrv = httptest.NewServer(newJWTHandler(secret, srv))
+		body    = `{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]}`
+	)
+	defer srv.Stop()
+	defer httpsrv.Close()
+
+	post := func(token string) int {
+		req, _ := http.NewRequest(http.MethodPost, httpsrv.URL, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		resp.Body.Close()
+		return resp.StatusCode
+	}
+	if code := post(""); code != http.StatusUnauthorized {
+		t.Errorf("request without token: have status %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code := post(NewJWTToken(secret, time.Now().Add(-time.Hour))); code != http.StatusUnauthorized {
+		t.Errorf("request with stale token: have status %d, want %d", code, http.StatusUnauthorized)
+	}
+	if code := post(NewJWTToken(secret, time.Now())); code != http.StatusOK {
+		t.Errorf("request with valid token: have status %d, want %d", code, http.StatusOK)
+	}
+}
+
+func TestObtainJWTSecret(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "jwt.hex")
+
+	secret, err := ObtainJWTSecret(fileName)
+	if err != nil {
+		t.Fatalf("failed to generate secret: %v", err)
+	}
+	if len(secret) != jwtSecretLength {
+		t.Fatalf("wrong secret length: have %d, want %d", len(secret), jwtSecretLength)
+	}
+	loaded, err := ObtainJWTSecret(fileName)
+	if err != nil {
+		t.Fatalf("failed to load secret: %v", err)
+	}
+	if !bytes.Equal(secret, loaded) {
+		t.Errorf("loaded secret %x differs from generated %x", loaded, secret)
+	}
+
+	if err := os.WriteFile(fileName, []byte("0x1234"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ObtainJWTSecret(fileName); err == nil {
+		t.Error("expected error for a short secret")
+	}
+}
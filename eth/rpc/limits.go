@@ -0,0 +1,162 @@
+package rpc
+
+import (
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/harmony-one/harmony/internal/rate"
+	xrate "golang.org/x/time/rate"
+)
+
+// APIKeyHeader is the HTTP header carrying the API key of a client.
+const APIKeyHeader = "X-API-Key"
+
+// DefaultMethodCosts are the costs of the expensive methods, in requests of
+// the rate limit. A method name ending with "*" matches all methods with the
+// prefix before it.
+var DefaultMethodCosts = map[string]int{
+	"debug_trace*":                         20,
+	"trace_*":                              20,
+	"eth_getLogs":                          10,
+	"eth_simulateV1":                       10,
+	"eth_createAccessList":                 5,
+	"eth_feeHistory":                       5,
+	"graphql":                              10,
+	"hmy_getBlocks":                        5,
+	"hmyv2_getBlocks":                      5,
+	"hmy_getTransactionsHistory":           5,
+	"hmyv2_getTransactionsHistory":         5,
+	"hmyv2_getInternalTransactionsHistory": 5,
+}
+
+// LimitsConfig is the config of the RequestLimits of a server.
+type LimitsConfig struct {
+	ClientRequestsPerSecond int            // Requests per second of each client IP, 0 for no limit
+	APIKeyRequestsPerSecond int            // Requests per second of each API key, 0 for no limit
+	APIKeys                 []string       // API keys limited by key instead of by IP
+	MethodCosts             map[string]int // Cost of the methods, on top of DefaultMethodCosts
+	BatchItemLimit          int            // Maximum number of requests in a batch, 0 for no limit
+	BatchResponseMaxSize    int            // Maximum size in bytes of a batch response, 0 for no limit
+}
+
+// RequestLimits are the limits a server enforces on the requests of every
+// client, identified by its API key if it is a known one, or by its IP.
+type RequestLimits struct {
+	clientLimiter rate.IDLimiter
+	clientBurst   int
+	apiKeyLimiter rate.IDLimiter
+	apiKeyBurst   int
+	apiKeys       map[string]struct{}
+
+	methodCosts  map[string]int
+	prefixCosts  map[string]int
+	batchItems   int
+	batchRespMax int
+}
+
+// NewRequestLimits returns the RequestLimits of cfg.
+func NewRequestLimits(cfg LimitsConfig) *RequestLimits {
+	limits := &RequestLimits{
+		apiKeys:      make(map[string]struct{}),
+		methodCosts:  make(map[string]int),
+		prefixCosts:  make(map[string]int),
+		batchItems:   cfg.BatchItemLimit,
+		batchRespMax: cfg.BatchResponseMaxSize,
+	}
+	if cfg.ClientRequestsPerSecond > 0 {
+		limits.clientLimiter = rate.NewLimiterPerID(xrate.Limit(cfg.ClientRequestsPerSecond), cfg.ClientRequestsPerSecond, nil)
+		limits.clientBurst = cfg.ClientRequestsPerSecond
+	}
+	if cfg.APIKeyRequestsPerSecond > 0 {
+		limits.apiKeyLimiter = rate.NewLimiterPerID(xrate.Limit(cfg.APIKeyRequestsPerSecond), cfg.APIKeyRequestsPerSecond, nil)
+		limits.apiKeyBurst = cfg.APIKeyRequestsPerSecond
+	}
+	for _, key := range cfg.APIKeys {
+		if key = strings.TrimSpace(key); key != "" {
+			limits.apiKeys[key] = struct{}{}
+		}
+	}
+	for _, costs := range []map[string]int{DefaultMethodCosts, cfg.MethodCosts} {
+		for method, cost := range costs {
+			if strings.HasSuffix(method, "*") {
+				limits.prefixCosts[strings.TrimSuffix(method, "*")] = cost
+			} else {
+				limits.methodCosts[method] = cost
+			}
+		}
+	}
+	return limits
+}
+
+// cost returns the number of requests of the rate limit a call to method
+// counts for.
+func (l *RequestLimits) cost(method string) int {
+	if cost, ok := l.methodCosts[method]; ok {
+		return cost
+	}
+	// the longest prefix wins
+	cost, length := 1, -1
+	for prefix, c := range l.prefixCosts {
+		if strings.HasPrefix(method, prefix) && len(prefix) > length {
+			cost, length = c, len(prefix)
+		}
+	}
+	return cost
+}
+
+// peerInfo identifies the client of a connection.
+type peerInfo struct {
+	ip     string
+	apiKey string
+}
+
+// newPeerInfo returns the peerInfo of the client sending r.
+func newPeerInfo(r *http.Request) peerInfo {
+	ip, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		ip = r.RemoteAddr
+	}
+	return peerInfo{ip: ip, apiKey: r.Header.Get(APIKeyHeader)}
+}
+
+// clientLimits are the RequestLimits applied to the client of a connection.
+type clientLimits struct {
+	*RequestLimits
+	peer peerInfo
+}
+
+// allow returns whether the client is allowed to call method now.
+func (c *clientLimits) allow(method string) bool {
+	limiter, id, burst := c.clientLimiter, c.peer.ip, c.clientBurst
+	if _, ok := c.apiKeys[c.peer.apiKey]; ok {
+		limiter, id, burst = c.apiKeyLimiter, c.peer.apiKey, c.apiKeyBurst
+	}
+	if limiter == nil {
+		return true
+	}
+	// a cost over the burst would never be allowed, such a call takes
+	// the whole burst instead
+	cost := c.cost(method)
+	if cost > burst {
+		cost = burst
+	}
+	return limiter.AllowN(id, cost)
+}
+
+// Handler returns a http.Handler serving the requests of h within the rate
+// limit of their client, each request counting as a call to method.
+func (l *RequestLimits) Handler(method string, h http.Handler) http.Handler {
+	if l == nil {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limits := &clientLimits{RequestLimits: l, peer: newPeerInfo(r)}
+		if !limits.allow(method) {
+			doMetricRejectedRequest(method, "rate_limit")
+			http.Error(w, "request rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,193 @@
+package rpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestRequestLimitsCost(t *testing.T) {
+	limits := NewRequestLimits(LimitsConfig{
+		MethodCosts: map[string]int{"debug_traceBlock*": 50, "eth_call": 3},
+	})
+	tests := []struct {
+		method string
+		want   int
+	}{
+		{"eth_blockNumber", 1},
+		{"eth_call", 3},
+		{"eth_getLogs", 10},
+		{"debug_traceTransaction", 20},
+		{"debug_traceBlockByNumber", 50},
+		{"trace_block", 20},
+	}
+	for _, test := range tests {
+		if cost := limits.cost(test.method); cost != test.want {
+			t.Errorf("%s: have cost %d, want %d", test.method, cost, test.want)
+		}
+	}
+}
+
+// postLimited posts body to a server with limits and returns the decoded
+// responses.
+func postLimited(t *testing.T, limits *RequestLimits, apiKey string, bodies ...string) []json.RawMessage {
+	srv := newTestServer()
+	srv.SetRequestLimits(limits)
+	httpsrv := httptest.NewServer(srv)
+	defer srv.Stop()
+	defer httpsrv.Close()
+
+	var results []json.RawMessage
+	for _, body := range bodies {
+		req, _ := http.NewRequest(http.MethodPost, httpsrv.URL, strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if apiKey != "" {
+			req.Header.Set(APIKeyHeader, apiKey)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatalf("failed to read response: %v", err)
+		}
+		results = append(results, data)
+	}
+	return results
+}
+
+func TestRequestLimitsRateLimit(t *testing.T) {
+	var (
+		body   = `{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]}`
+		limits = NewRequestLimits(LimitsConfig{
+			ClientRequestsPerSecond: 2,
+			APIKeyRequestsPerSecond: 100,
+			APIKeys:                 []string{"key"},
+		})
+	)
+	resps := postLimited(t, limits, "", body, body, body)
+	for i, resp := range resps[:2] {
+		if strings.Contains(string(resp), `"error"`) {
+			t.Errorf("request %d: unexpected error response %s", i, resp)
+		}
+	}
+	if !strings.Contains(string(resps[2]), `"code":-32005`) {
+		t.Errorf("request over the limit: have response %s, want limit exceeded error", resps[2])
+	}
+
+	// clients with a known API key use the limit of the key
+	resps = postLimited(t, limits, "key", body, body, body)
+	for i, resp := range resps {
+		if strings.Contains(string(resp), `"error"`) {
+			t.Errorf("request %d with API key: unexpected error response %s", i, resp)
+		}
+	}
+}
+
+func TestRequestLimitsExpensiveMethod(t *testing.T) {
+	var (
+		body   = `{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]}`
+		limits = NewRequestLimits(LimitsConfig{
+			ClientRequestsPerSecond: 2,
+			MethodCosts:             map[string]int{"test_echo": 20},
+		})
+	)
+	// a method costing more than the limit takes the whole burst
+	resps := postLimited(t, limits, "", body, body)
+	if strings.Contains(string(resps[0]), `"error"`) {
+		t.Errorf("unexpected error response %s", resps[0])
+	}
+	if !strings.Contains(string(resps[1]), `"code":-32005`) {
+		t.Errorf("request over the limit: have response %s, want limit exceeded error", resps[1])
+	}
+}
+
+func TestRequestLimitsBatch(t *testing.T) {
+	call := `{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]}`
+	batch := "[" + strings.Repeat(call+",", 2) + call + "]"
+
+	resps := postLimited(t, NewRequestLimits(LimitsConfig{BatchItemLimit: 2}), "", batch)
+	if !strings.Contains(string(resps[0]), "batch too large") {
+		t.Errorf("batch over the item limit: have response %s, want batch too large error", resps[0])
+	}
+
+	resps = postLimited(t, NewRequestLimits(LimitsConfig{BatchResponseMaxSize: 1}), "", batch)
+	var answers []jsonrpcMessage
+	if err := json.Unmarshal(resps[0], &answers); err != nil {
+		t.Fatalf("invalid batch response %s: %v", resps[0], err)
+	}
+	if len(answers) != 3 {
+		t.Fatalf("have %d answers, want 3", len(answers))
+	}
+	if answers[0].Error != nil {
+		t.Errorf("first answer: unexpected error %v", answers[0].Error)
+	}
+	for i, answer := range answers[1:] {
+		if answer.Error == nil || answer.Error.Code != (&responseTooLargeError{}).ErrorCode() {
+			t.Errorf("answer %d: have error %v, want response too large", i+1, answer.Error)
+		}
+	}
+}
+
+func TestRequestLimitsRejectedMetric(t *testing.T) {
+	var (
+		echo    = `{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]}`
+		unknown = `{"jsonrpc":"2.0","id":1,"method":"test_unknown%d","params":[]}`
+		limits  = NewRequestLimits(LimitsConfig{ClientRequestsPerSecond: 1})
+	)
+	rejected := func(method string) float64 {
+		return testutil.ToFloat64(requestRejectedCounterVec.WithLabelValues(method, "rate_limit"))
+	}
+	echoes, unknowns := rejected("test_echo"), rejected(unknownMethodLabel)
+	labels := testutil.CollectAndCount(requestRejectedCounterVec)
+
+	// the methods which are not served share a single label
+	postLimited(t, limits, "", echo, echo, fmt.Sprintf(unknown, 1), fmt.Sprintf(unknown, 2))
+	if have := rejected("test_echo") - echoes; have != 1 {
+		t.Errorf("have %v rejected test_echo requests, want 1", have)
+	}
+	if have := rejected(unknownMethodLabel) - unknowns; have != 2 {
+		t.Errorf("have %v rejected unknown requests, want 2", have)
+	}
+	if have := testutil.CollectAndCount(requestRejectedCounterVec); have != labels {
+		t.Errorf("have %d labels, want %d", have, labels)
+	}
+}
+
+func TestRequestLimitsHandler(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	serve := func(h http.Handler, n int) []int {
+		codes := make([]int, n)
+		for i := range codes {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/graphql", nil))
+			codes[i] = rec.Code
+		}
+		return codes
+	}
+
+	// a request costing 10 requests of the limit is served twice a second
+	limits := NewRequestLimits(LimitsConfig{ClientRequestsPerSecond: 20})
+	codes := serve(limits.Handler("graphql", ok), 3)
+	if codes[0] != http.StatusOK || codes[1] != http.StatusOK || codes[2] != http.StatusTooManyRequests {
+		t.Errorf("have status codes %v, want 200, 200, 429", codes)
+	}
+
+	// the handler of no limits serves all the requests
+	var noLimits *RequestLimits
+	for i, code := range serve(noLimits.Handler("graphql", ok), 3) {
+		if code != http.StatusOK {
+			t.Errorf("request %d without limits: have status code %d, want 200", i, code)
+		}
+	}
+}
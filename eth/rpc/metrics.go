@@ -10,9 +10,14 @@ func init() {
 		requestCounterVec,
 		requestErroredCounterVec,
 		requestDurationHistVec,
+		requestRejectedCounterVec,
 	)
 }
 
+// unknownMethodLabel is the method label of the requests to the methods
+// which are not served.
+const unknownMethodLabel = "unknown"
+
 var (
 	requestCounterVec = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -34,6 +39,16 @@ var (
 		[]string{"method"},
 	)
 
+	requestRejectedCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "hmy",
+			Subsystem: "rpc2",
+			Name:      "rejected_count",
+			Help:      "counters of RPC requests rejected by the request limits",
+		},
+		[]string{"method", "reason"},
+	)
+
 	requestDurationHistVec = prometheus.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: "hmy",
@@ -66,3 +81,11 @@ func doMetricErroredRequest(method string) {
 func doMetricDelayHist(timer *prometheus.Timer) {
 	timer.ObserveDuration()
 }
+
+func doMetricRejectedRequest(method string, reason string) {
+	pLabel := prometheus.Labels{
+		"method": method,
+		"reason": reason,
+	}
+	requestRejectedCounterVec.With(pLabel).Inc()
+}
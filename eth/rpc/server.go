@@ -46,6 +46,7 @@ type Server struct {
 	idgen    func() ID
 	run      int32
 	codecs   mapset.Set
+	limits   *RequestLimits
 }
 
 // NewServer creates a new server instance with no registered handlers.
@@ -66,12 +67,32 @@ func (s *Server) RegisterName(name string, receiver interface{}, rmf *RpcMethodF
 	return s.services.registerName(name, receiver, rmf)
 }
 
+// SetRequestLimits sets the limits enforced on the requests of every client.
+// It must be called before serving any connection.
+func (s *Server) SetRequestLimits(limits *RequestLimits) {
+	s.limits = limits
+}
+
+// clientLimits returns the request limits of the client peer, or nil if the
+// server has no limits.
+func (s *Server) clientLimits(peer peerInfo) *clientLimits {
+	if s.limits == nil {
+		return nil
+	}
+	return &clientLimits{RequestLimits: s.limits, peer: peer}
+}
+
 // ServeCodec reads incoming requests from codec, calls the appropriate callback and writes
 // the response back using the given codec. It will block until the codec is closed or the
 // server is stopped. In either case the codec is closed.
 //
 // Note that codec options are no longer supported.
 func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
+	s.serveCodec(codec, peerInfo{})
+}
+
+// serveCodec serves the connection of the client peer.
+func (s *Server) serveCodec(codec ServerCodec, peer peerInfo) {
 	defer codec.close()
 
 	// Don't serve if server is stopped.
@@ -83,7 +104,7 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 	s.codecs.Add(codec)
 	defer s.codecs.Remove(codec)
 
-	c := initClient(codec, s.idgen, &s.services)
+	c := initClient(codec, s.idgen, &s.services, s.clientLimits(peer))
 	<-codec.closed()
 	c.Close()
 }
@@ -91,7 +112,7 @@ func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
 // serveSingleRequest reads and processes a single RPC request from the given codec. This
 // is used to serve HTTP connections. Subscriptions and reverse calls are not allowed in
 // this mode.
-func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
+func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec, peer peerInfo) {
 	// Don't serve if server is stopped.
 	if atomic.LoadInt32(&s.run) == 0 {
 		return
@@ -99,6 +120,7 @@ func (s *Server) serveSingleRequest(ctx context.Context, codec ServerCodec) {
 
 	h := newHandler(ctx, codec, s.idgen, &s.services)
 	h.allowSubscribe = false
+	h.limits = s.clientLimits(peer)
 	defer h.close(io.EOF, nil)
 
 	reqs, batch, err := codec.readBatch()
@@ -0,0 +1,317 @@
+// Copyright 2021 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+package hmy
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"sync/atomic"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/internal/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxFeeHistory is the maximum number of blocks that can be retrieved for a
+	// fee history request.
+	maxFeeHistory = 1024
+	// maxQueryLimit is the maximum number of reward percentiles of a fee
+	// history request.
+	maxQueryLimit = 100
+	// feeHistoryCacheSize is the number of processed blocks kept by the fee
+	// history cache.
+	feeHistoryCacheSize = 2048
+	// maxBlockFetchers is the maximum number of goroutines fetching the
+	// blocks of a fee history request.
+	maxBlockFetchers = 4
+)
+
+var (
+	errInvalidPercentile = errors.New("invalid reward percentile")
+	errRequestBeyondHead = errors.New("request beyond head block")
+)
+
+// blockFees represents a single block for processing
+type blockFees struct {
+	// set by the caller
+	blockNumber uint64
+	header      *block.Header
+	block       *types.Block // only set if reward percentiles are requested
+	receipts    types.Receipts
+	// filled by processBlock
+	results processedFees
+	err     error
+}
+
+// processedFees contains the results of a processed block.
+type processedFees struct {
+	reward               []*big.Int
+	baseFee, nextBaseFee *big.Int
+	gasUsedRatio         float64
+}
+
+// txGasAndReward is sorted in ascending order based on reward
+type txGasAndReward struct {
+	gasUsed uint64
+	reward  *big.Int
+}
+
+// feeCacheKey is the key of a processed block in the fee history cache, as
+// the rewards depend on the requested percentiles.
+type feeCacheKey struct {
+	hash        common.Hash
+	percentiles string
+}
+
+// processBlock takes a blockFees structure with the blockNumber, the header and
+// optionally the block field filled in, retrieves the block from the backend if
+// not present yet and fills the rest of the fields.
+func (gpo *Oracle) processBlock(bf *blockFees, percentiles []float64) {
+	config := gpo.backend.ChainConfig()
+	if bf.results.baseFee = bf.header.BaseFee(); bf.results.baseFee == nil {
+		bf.results.baseFee = new(big.Int)
+	}
+	if config.IsLondon(bf.header.Epoch()) {
+		bf.results.nextBaseFee = core.CalcBaseFee(config, bf.header)
+	} else {
+		bf.results.nextBaseFee = new(big.Int)
+	}
+	// Our block header may report a higher gas limit than the actual one
+	gasLimit := bf.header.GasLimit()
+	if gpo.blockGasLimit != 0 {
+		gasLimit = uint64(gpo.blockGasLimit)
+	}
+	if gasLimit != 0 {
+		bf.results.gasUsedRatio = float64(bf.header.GasUsed()) / float64(gasLimit)
+	}
+	if len(percentiles) == 0 {
+		// rewards were not requested, return null
+		return
+	}
+	if bf.block == nil || len(bf.receipts) < len(bf.block.Transactions()) {
+		utils.Logger().Error().Uint64("block", bf.blockNumber).Msg("Block or receipts are missing while reward percentiles are requested")
+		return
+	}
+
+	bf.results.reward = make([]*big.Int, len(percentiles))
+	if len(bf.block.Transactions()) == 0 {
+		// return an all zero row if there are no transactions to gather data from
+		for i := range bf.results.reward {
+			bf.results.reward[i] = new(big.Int)
+		}
+		return
+	}
+
+	// The gas used by the block includes the gas of the staking transactions,
+	// the percentiles are weighted by the gas of the plain transactions only
+	var txsGasUsed uint64
+	sorter := make([]txGasAndReward, len(bf.block.Transactions()))
+	for i, tx := range bf.block.Transactions() {
+		reward, _ := tx.EffectiveGasTip(bf.block.BaseFee())
+		sorter[i] = txGasAndReward{gasUsed: bf.receipts[i].GasUsed, reward: reward}
+		txsGasUsed += bf.receipts[i].GasUsed
+	}
+	sort.Slice(sorter, func(i, j int) bool {
+		return sorter[i].reward.Cmp(sorter[j].reward) < 0
+	})
+
+	var txIndex int
+	sumGasUsed := sorter[0].gasUsed
+
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(txsGasUsed) * p / 100)
+		for sumGasUsed < thresholdGasUsed && txIndex < len(bf.block.Transactions())-1 {
+			txIndex++
+			sumGasUsed += sorter[txIndex].gasUsed
+		}
+		bf.results.reward[i] = sorter[txIndex].reward
+	}
+}
+
+// resolveBlockRange resolves the specified block range to absolute block numbers
+// while also enforcing backend specific limitations.
+// Note: an error is only returned if retrieving the head header has failed. If
+// there are no retrievable blocks in the specified range then zero block count
+// is returned with no error.
+func (gpo *Oracle) resolveBlockRange(ctx context.Context, reqEnd rpc.BlockNumber, blocks uint64) (uint64, uint64, error) {
+	// Get the chain's current head.
+	head, err := gpo.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return 0, 0, err
+	}
+	headBlock := head.Number().Uint64()
+
+	// Resolve the latest and pending block numbers, pending being the head as
+	// there is no pending block
+	if reqEnd == rpc.LatestBlockNumber || reqEnd == rpc.PendingBlockNumber {
+		reqEnd = rpc.BlockNumber(headBlock)
+	} else if reqEnd < 0 {
+		return 0, 0, fmt.Errorf("invalid block number %d", reqEnd)
+	}
+	if uint64(reqEnd) > headBlock {
+		return 0, 0, fmt.Errorf("%w: requested %d, head %d", errRequestBeyondHead, reqEnd, headBlock)
+	}
+	// Ensure not trying to retrieve before genesis.
+	if uint64(reqEnd+1) < blocks {
+		blocks = uint64(reqEnd + 1)
+	}
+	return uint64(reqEnd), blocks, nil
+}
+
+// FeeHistory returns data relevant for fee estimation based on the specified range of blocks.
+// The range can be specified either with absolute block numbers or ending with the latest
+// block. Blocks are retrieved in parallel and processed with a result cache.
+//   - baseFee: base fee per gas in the given block, 0 before the London fork
+//   - gasUsedRatio: gasUsed/gasLimit in the given block, the gas limit being
+//     GasPriceOracleConfig.BlockGasLimit if set
+//   - reward: the requested percentiles of effective priority fees per gas of
+//     transactions in the given block, weighted by the gas they used
+//
+// Note: baseFee includes the next block after the newest of the returned range,
+// because this value can be derived from the newest block.
+func (gpo *Oracle) FeeHistory(ctx context.Context, blocks uint64, unresolvedLastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, error) {
+	if blocks < 1 {
+		return common.Big0, nil, nil, nil, nil // returning with no data and no error means there are no retrievable blocks
+	}
+	if blocks > maxFeeHistory {
+		utils.Logger().Warn().Uint64("requested", blocks).Int("truncated", maxFeeHistory).Msg("Sanitizing fee history length")
+		blocks = maxFeeHistory
+	}
+	if len(rewardPercentiles) > maxQueryLimit {
+		return common.Big0, nil, nil, nil, fmt.Errorf("%w: over the query limit %d", errInvalidPercentile, maxQueryLimit)
+	}
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 {
+			return common.Big0, nil, nil, nil, fmt.Errorf("%w: %f", errInvalidPercentile, p)
+		}
+		if i > 0 && p < rewardPercentiles[i-1] {
+			return common.Big0, nil, nil, nil, fmt.Errorf("%w: #%d:%f > #%d:%f", errInvalidPercentile, i-1, rewardPercentiles[i-1], i, p)
+		}
+	}
+	lastBlock, blocks, err := gpo.resolveBlockRange(ctx, unresolvedLastBlock, blocks)
+	if err != nil || blocks == 0 {
+		return common.Big0, nil, nil, nil, err
+	}
+	oldestBlock := lastBlock + 1 - blocks
+
+	var next = oldestBlock
+	results := make(chan *blockFees, blocks)
+
+	percentileKey := make([]byte, 8*len(rewardPercentiles))
+	for i, p := range rewardPercentiles {
+		binary.LittleEndian.PutUint64(percentileKey[i*8:(i+1)*8], math.Float64bits(p))
+	}
+	for i := 0; i < maxBlockFetchers && i < int(blocks); i++ {
+		go func() {
+			for {
+				// Retrieve the next block number to fetch with this goroutine
+				blockNumber := atomic.AddUint64(&next, 1) - 1
+				if blockNumber > lastBlock {
+					return
+				}
+
+				fees := &blockFees{blockNumber: blockNumber}
+				fees.header, fees.err = gpo.backend.HeaderByNumber(ctx, rpc.BlockNumber(blockNumber))
+				if fees.header == nil && fees.err == nil {
+					fees.err = fmt.Errorf("header %d not found", blockNumber)
+				}
+				if fees.err == nil {
+					cacheKey := feeCacheKey{hash: fees.header.Hash(), percentiles: string(percentileKey)}
+					if p, ok := gpo.historyCache.Get(cacheKey); ok {
+						fees.results = p.(processedFees)
+					} else {
+						if len(rewardPercentiles) != 0 {
+							fees.block, fees.err = gpo.backend.BlockByNumber(ctx, rpc.BlockNumber(blockNumber))
+							if fees.block != nil && fees.err == nil {
+								fees.receipts, fees.err = gpo.backend.GetReceipts(ctx, fees.header.Hash())
+							}
+						}
+						if fees.err == nil {
+							gpo.processBlock(fees, rewardPercentiles)
+							gpo.historyCache.Add(cacheKey, fees.results)
+						}
+					}
+				}
+				// send to results even if empty to guarantee that blocks items are sent in total
+				results <- fees
+			}
+		}()
+	}
+	var (
+		reward       = make([][]*big.Int, blocks)
+		baseFee      = make([]*big.Int, blocks+1)
+		gasUsedRatio = make([]float64, blocks)
+		firstMissing = blocks
+	)
+	for ; blocks > 0; blocks-- {
+		fees := <-results
+		if fees.err != nil {
+			return common.Big0, nil, nil, nil, fees.err
+		}
+		i := fees.blockNumber - oldestBlock
+		if fees.results.baseFee != nil {
+			reward[i], baseFee[i], baseFee[i+1], gasUsedRatio[i] = fees.results.reward, fees.results.baseFee, fees.results.nextBaseFee, fees.results.gasUsedRatio
+		} else {
+			// getting no block and no error means we are requesting into the future (might happen because of a reorg)
+			if i < firstMissing {
+				firstMissing = i
+			}
+		}
+	}
+	if firstMissing == 0 {
+		return common.Big0, nil, nil, nil, nil
+	}
+	if len(rewardPercentiles) != 0 {
+		reward = reward[:firstMissing]
+	} else {
+		reward = nil
+	}
+	baseFee, gasUsedRatio = baseFee[:firstMissing+1], gasUsedRatio[:firstMissing]
+	return new(big.Int).SetUint64(oldestBlock), reward, baseFee, gasUsedRatio, nil
+}
+
+// SuggestTipCap returns a priority fee so that newly created dynamic fee
+// transactions can have a very high chance to be included in the following
+// blocks. It is the suggested gas price less the base fee of the latest
+// block, the whole suggested price before the London fork.
+func (gpo *Oracle) SuggestTipCap(ctx context.Context) (*big.Int, error) {
+	price, err := gpo.SuggestPrice(ctx)
+	if err != nil {
+		return nil, err
+	}
+	head, err := gpo.backend.HeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+	tip := new(big.Int).Set(price)
+	if baseFee := head.BaseFee(); baseFee != nil {
+		tip.Sub(tip, baseFee)
+		if tip.Sign() < 0 {
+			tip.SetUint64(0)
+		}
+	}
+	return tip, nil
+}
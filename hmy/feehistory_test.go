@@ -0,0 +1,212 @@
+package hmy
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/accounts/abi/bind/backends"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/common/denominations"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/eth/rpc"
+	harmonyconfig "github.com/harmony-one/harmony/internal/configs/harmony"
+	"github.com/harmony-one/harmony/internal/params"
+	commonRPC "github.com/harmony-one/harmony/rpc/common"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+var (
+	testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr   = crypto.PubkeyToAddress(testKey.PublicKey)
+	testTo     = common.HexToAddress("0x1234")
+	gwei       = big.NewInt(denominations.Nano)
+	minPrice   = new(big.Int).SetUint64(core.DefaultTxPoolConfig.PriceLimit)
+)
+
+// testNodeAPI is the node of a test chain, serving it as both the shard and
+// the beacon chain.
+type testNodeAPI struct {
+	NodeAPI
+	chain core.BlockChain
+}
+
+func (n *testNodeAPI) Blockchain() core.BlockChain  { return n.chain }
+func (n *testNodeAPI) Beaconchain() core.BlockChain { return n.chain }
+func (n *testNodeAPI) GetConfig() commonRPC.Config {
+	return commonRPC.Config{HarmonyConfig: harmonyconfig.HarmonyConfig{GPO: DefaultGPOConfig}}
+}
+
+// newTestHarmony returns the backend of a test chain whose block 1 holds a
+// legacy transaction at the minimum price of the pool and dynamic fee
+// transactions with tips of 5 and 0 gwei, followed by the empty block 2.
+func newTestHarmony(t *testing.T) *Harmony {
+	t.Helper()
+	balance := new(big.Int).Mul(big.NewInt(denominations.One), big.NewInt(1000))
+	sim := backends.NewSimulatedBackend(core.GenesisAlloc{testAddr: {Balance: balance}}, 10000000)
+	t.Cleanup(func() { sim.Close() })
+
+	txs := []*types.Transaction{
+		types.NewTransaction(0, testTo, 0, big.NewInt(1), params.TxGas, minPrice, nil),
+		newDynamicFeeTx(1, new(big.Int).Mul(gwei, big.NewInt(5)), minPrice),
+		newDynamicFeeTx(2, common.Big0, minPrice),
+	}
+	for _, tx := range txs {
+		signed, err := types.SignTx(tx, types.NewEIP155Signer(params.TestChainID), testKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sim.SendTransaction(context.Background(), signed); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sim.Commit()
+	sim.Commit()
+
+	chain := sim.Blockchain()
+	poolConfig := core.DefaultTxPoolConfig
+	poolConfig.Journal = ""
+	pool := core.NewTxPool(poolConfig, chain.Config(), chain, types.NewTransactionErrorSink())
+	t.Cleanup(pool.Stop)
+	harmony := New(&testNodeAPI{chain: chain}, pool, nil, 0)
+	t.Cleanup(func() { harmony.BloomIndexer.Close() })
+	return harmony
+}
+
+func newDynamicFeeTx(nonce uint64, tip, feeCap *big.Int) *types.Transaction {
+	return types.NewDynamicFeeTransaction(params.TestChainID, nonce, &testTo, 0, 0, big.NewInt(1), params.TxGas, tip, feeCap, nil, nil)
+}
+
+func TestFeeHistory(t *testing.T) {
+	hmy := newTestHarmony(t)
+	headers := make([]*big.Int, 0, 3)
+	for nr := uint64(1); nr <= 2; nr++ {
+		headers = append(headers, hmy.BlockChain.GetHeaderByNumber(nr).BaseFee())
+	}
+	head := hmy.BlockChain.CurrentBlock().Header()
+	headers = append(headers, core.CalcBaseFee(hmy.ChainConfig(), head))
+	block1 := hmy.BlockChain.GetHeaderByNumber(1)
+
+	// the cached results of the blocks are returned by the second call
+	for i := 0; i < 2; i++ {
+		oldest, reward, baseFee, gasUsedRatio, err := hmy.FeeHistory(context.Background(), 2, rpc.LatestBlockNumber, []float64{0, 50, 100})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if oldest.Uint64() != 1 {
+			t.Errorf("unexpected oldest block %v", oldest)
+		}
+		expReward := [][]*big.Int{
+			{common.Big0, new(big.Int).Mul(gwei, big.NewInt(5)), new(big.Int).Sub(minPrice, block1.BaseFee())},
+			{common.Big0, common.Big0, common.Big0},
+		}
+		if len(reward) != len(expReward) {
+			t.Fatalf("unexpected rewards %v", reward)
+		}
+		for j := range expReward {
+			for k := range expReward[j] {
+				if reward[j][k].Cmp(expReward[j][k]) != 0 {
+					t.Errorf("unexpected reward %v of block %v: %v / %v", k, j+1, reward[j][k], expReward[j][k])
+				}
+			}
+		}
+		if len(baseFee) != len(headers) {
+			t.Fatalf("unexpected base fees %v", baseFee)
+		}
+		for j := range headers {
+			if baseFee[j].Cmp(headers[j]) != 0 {
+				t.Errorf("unexpected base fee %v: %v / %v", j, baseFee[j], headers[j])
+			}
+		}
+		expRatio := float64(3*params.TxGas) / float64(block1.GasLimit())
+		if len(gasUsedRatio) != 2 || gasUsedRatio[0] != expRatio || gasUsedRatio[1] != 0 {
+			t.Errorf("unexpected gas used ratios %v, want %v", gasUsedRatio, expRatio)
+		}
+	}
+
+	// the range is bounded by the genesis block
+	oldest, reward, baseFee, _, err := hmy.FeeHistory(context.Background(), 10, 2, nil)
+	if err != nil || oldest.Uint64() != 0 || reward != nil || len(baseFee) != 4 {
+		t.Errorf("unexpected fee history %v, %v, %v, %v", oldest, reward, baseFee, err)
+	}
+}
+
+func TestFeeHistoryErrors(t *testing.T) {
+	hmy := newTestHarmony(t)
+	tests := []struct {
+		lastBlock   rpc.BlockNumber
+		percentiles []float64
+		err         error
+	}{
+		{rpc.LatestBlockNumber, []float64{101}, errInvalidPercentile},
+		{rpc.LatestBlockNumber, []float64{-1}, errInvalidPercentile},
+		{rpc.LatestBlockNumber, []float64{50, 10}, errInvalidPercentile},
+		{3, nil, errRequestBeyondHead},
+	}
+	for i, test := range tests {
+		if _, _, _, _, err := hmy.FeeHistory(context.Background(), 1, test.lastBlock, test.percentiles); !errors.Is(err, test.err) {
+			t.Errorf("Test %v: unexpected error %v / %v", i, err, test.err)
+		}
+	}
+	if oldest, _, _, _, err := hmy.FeeHistory(context.Background(), 0, rpc.LatestBlockNumber, nil); err != nil || oldest.Sign() != 0 {
+		t.Errorf("unexpected fee history of no block: %v, %v", oldest, err)
+	}
+}
+
+func TestFeeHistoryStakingGas(t *testing.T) {
+	hmy := newTestHarmony(t)
+	baseFee := big.NewInt(params.InitialBaseFee)
+	txs := []*types.Transaction{
+		types.NewTransaction(0, testTo, 0, big.NewInt(1), params.TxGas, new(big.Int).Add(baseFee, gwei), nil),
+		types.NewTransaction(1, testTo, 0, big.NewInt(1), params.TxGas, new(big.Int).Add(baseFee, new(big.Int).Mul(gwei, big.NewInt(10))), nil),
+	}
+	stakingTx, err := staking.NewStakingTransaction(0, 100000, baseFee, func() (staking.Directive, interface{}) {
+		return staking.DirectiveDelegate, staking.Delegate{DelegatorAddress: testAddr, ValidatorAddress: testTo, Amount: big.NewInt(1)}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	receipts := types.Receipts{{GasUsed: params.TxGas}, {GasUsed: params.TxGas}, {GasUsed: 100000}}
+	header := blockfactory.NewTestHeader().With().GasUsed(2*params.TxGas + 100000).BaseFee(baseFee).Header()
+	blk := types.NewBlock(header, txs, receipts, nil, nil, staking.StakingTransactions{stakingTx})
+
+	// the median of the plain transactions is the first one, whatever the
+	// gas used by the staking transaction
+	fees := &blockFees{header: header, block: blk, receipts: receipts}
+	hmy.gpo.processBlock(fees, []float64{50, 100})
+	exp := []*big.Int{gwei, new(big.Int).Mul(gwei, big.NewInt(10))}
+	for i := range exp {
+		if fees.results.reward[i].Cmp(exp[i]) != 0 {
+			t.Errorf("unexpected reward %v: %v / %v", i, fees.results.reward[i], exp[i])
+		}
+	}
+}
+
+func TestSuggestTipCap(t *testing.T) {
+	hmy := newTestHarmony(t)
+	price, err := hmy.SuggestPrice(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tip, err := hmy.SuggestTipCap(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseFee := hmy.BlockChain.CurrentBlock().BaseFee()
+	if feeCap := new(big.Int).Add(baseFee, tip); feeCap.Cmp(price) != 0 {
+		t.Errorf("unexpected base fee and tip %v + %v, want the price %v", baseFee, tip, price)
+	}
+
+	// a transaction paying the base fee and the suggested tip is accepted
+	tx, err := types.SignTx(newDynamicFeeTx(3, tip, new(big.Int).Add(baseFee, tip)), types.NewEIP155Signer(params.TestChainID), testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := hmy.TxPool.AddRemote(tx); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/harmony-one/harmony/core/types"
+	lru "github.com/hashicorp/golang-lru"
 )
 
 // OracleBackend includes all necessary background APIs for oracle.
@@ -55,6 +56,8 @@ type Oracle struct {
 	lowUsageThreshold float64
 	blockGasLimit     int
 	defaultPrice      *big.Int
+
+	historyCache *lru.Cache
 }
 
 var DefaultGPOConfig = harmony.GasPriceOracleConfig{
@@ -106,6 +109,7 @@ func NewOracle(backend *Harmony, params *harmony.GasPriceOracleConfig) *Oracle {
 			Msg("Sanitizing invalid gasprice oracle lowUsageThreshold")
 	}
 	blockGasLimit := params.BlockGasLimit
+	historyCache, _ := lru.New(feeHistoryCacheSize)
 	return &Oracle{
 		backend:           backend,
 		lastPrice:         defaultPrice,
@@ -117,6 +121,7 @@ func NewOracle(backend *Harmony, params *harmony.GasPriceOracleConfig) *Oracle {
 		blockGasLimit:     blockGasLimit,
 		// do not reference lastPrice
 		defaultPrice: new(big.Int).Set(defaultPrice),
+		historyCache: historyCache,
 	}
 }
 
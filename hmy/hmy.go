@@ -17,6 +17,7 @@ import (
 	"github.com/harmony-one/harmony/core/state"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/hmy/tracers"
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 	commonRPC "github.com/harmony-one/harmony/rpc/common"
 	"github.com/harmony-one/harmony/shard"
@@ -92,6 +93,7 @@ type NodeAPI interface {
 	Beaconchain() core.BlockChain
 	GetTransactionsHistory(address, txType, order string) ([]common.Hash, error)
 	GetStakingTransactionsHistory(address, txType, order string) ([]common.Hash, error)
+	GetInternalTransactionsHistory(address, txType, order string, page, size int) ([]*tracers.InternalTransaction, error)
 	GetTransactionsCount(address, txType string) (uint64, error)
 	GetStakingTransactionsCount(address, txType string) (uint64, error)
 	GetTraceResultByHash(hash common.Hash) (json.RawMessage, error)
@@ -238,11 +240,18 @@ func (hmy *Harmony) GetNodeMetadata() commonRPC.NodeMetadata {
 	}
 }
 
-// GetEVM returns a new EVM entity
-func (hmy *Harmony) GetEVM(ctx context.Context, msg core.Message, state *state.DB, header *block.Header) (*vm.EVM, error) {
+// GetEVM returns a new EVM entity, running in blockCtx if given or in the
+// context of header otherwise.
+func (hmy *Harmony) GetEVM(ctx context.Context, msg core.Message, state *state.DB, header *block.Header, blockCtx *vm.Context) (*vm.EVM, error) {
 	state.SetBalance(msg.From(), math.MaxBig256)
 	vmCtx := core.NewEVMContext(msg, header, hmy.BlockChain, nil)
-	return vm.NewEVM(vmCtx, state, hmy.BlockChain.Config(), *hmy.BlockChain.GetVMConfig()), nil
+	if blockCtx != nil {
+		vmCtx = *blockCtx
+	}
+	// calls are allowed to leave the fees unset after the London fork
+	vmConfig := *hmy.BlockChain.GetVMConfig()
+	vmConfig.NoBaseFee = true
+	return vm.NewEVM(vmCtx, state, hmy.BlockChain.Config(), vmConfig), nil
 }
 
 // ChainDb ..
@@ -6,6 +6,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/eth/rpc"
 )
 
 // GetPoolStats returns the number of pending and queued transactions
@@ -51,3 +52,14 @@ func (hmy *Harmony) GetPoolTransactions() (types.PoolTransactions, error) {
 func (hmy *Harmony) SuggestPrice(ctx context.Context) (*big.Int, error) {
 	return hmy.gpo.SuggestPrice(ctx)
 }
+
+// SuggestTipCap returns a priority fee suggestion for dynamic fee transactions.
+func (hmy *Harmony) SuggestTipCap(ctx context.Context) (*big.Int, error) {
+	return hmy.gpo.SuggestTipCap(ctx)
+}
+
+// FeeHistory returns the base fees, gas used ratios and reward percentiles of
+// a range of blocks ending with lastBlock.
+func (hmy *Harmony) FeeHistory(ctx context.Context, blockCount uint64, lastBlock rpc.BlockNumber, rewardPercentiles []float64) (*big.Int, [][]*big.Int, []*big.Int, []float64, error) {
+	return hmy.gpo.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+}
@@ -20,6 +20,7 @@ import (
 	"bufio"
 	"context"
 	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -38,6 +39,7 @@ import (
 	"github.com/harmony-one/harmony/core/vm"
 	"github.com/harmony-one/harmony/eth/rpc"
 	"github.com/harmony-one/harmony/hmy/tracers"
+	"github.com/harmony-one/harmony/hmy/tracers/native"
 	"github.com/harmony-one/harmony/internal/utils"
 )
 
@@ -57,9 +59,10 @@ const (
 // TraceConfig holds extra parameters to trace functions.
 type TraceConfig struct {
 	*vm.LogConfig
-	Tracer  *string
-	Timeout *string
-	Reexec  *uint64
+	Tracer       *string
+	TracerConfig json.RawMessage // Config of a native tracer
+	Timeout      *string
+	Reexec       *uint64
 }
 
 // StdTraceConfig holds extra parameters to standard-json trace functions.
@@ -172,7 +175,7 @@ func (hmy *Harmony) TraceChain(ctx context.Context, start, end *types.Block, con
 					if tx.IsEthCompatible() {
 						signer = ethSigner
 					}
-					msg, _ := tx.AsMessage(signer)
+					msg, _ := tx.AsMessage(signer, task.block.BaseFee())
 					vmCtx := core.NewEVMContext(msg, task.block.Header(), hmy.BlockChain, nil)
 
 					res, err := hmy.TraceTx(ctx, msg, vmCtx, task.statedb, config)
@@ -379,7 +382,7 @@ traceLoop:
 			signer = ethSigner
 		}
 		// Generate the next state snapshot fast without tracing
-		msg, _ := tx.AsMessage(signer)
+		msg, _ := tx.AsMessage(signer, block.BaseFee())
 		statedb.Prepare(tx.Hash(), blockHash, i)
 		statedb.SetTxHashETH(tx.ConvertToEth().Hash())
 		vmctx := core.NewEVMContext(msg, block.Header(), hmy.BlockChain, nil)
@@ -463,7 +466,7 @@ func (hmy *Harmony) TraceBlock(ctx context.Context, block *types.Block, config *
 					signer = ethSigner
 				}
 
-				msg, _ := txs[task.index].AsMessage(signer)
+				msg, _ := txs[task.index].AsMessage(signer, block.BaseFee())
 				vmctx := core.NewEVMContext(msg, block.Header(), hmy.BlockChain, nil)
 				tx := txs[task.index]
 				task.statedb.Prepare(tx.Hash(), blockHash, task.index)
@@ -488,7 +491,7 @@ func (hmy *Harmony) TraceBlock(ctx context.Context, block *types.Block, config *
 			signer = ethSigner
 		}
 		// Generate the next state snapshot fast without tracing
-		msg, _ := tx.AsMessage(signer)
+		msg, _ := tx.AsMessage(signer, block.BaseFee())
 		statedb.Prepare(tx.Hash(), block.Hash(), i)
 		statedb.SetTxHashETH(tx.ConvertToEth().Hash())
 		vmctx := core.NewEVMContext(msg, block.Header(), hmy.BlockChain, nil)
@@ -563,7 +566,7 @@ func (hmy *Harmony) standardTraceBlockToFile(ctx context.Context, block *types.B
 		}
 		// Prepare the transaction for un-traced execution
 		var (
-			msg, _ = tx.AsMessage(signer)
+			msg, _ = tx.AsMessage(signer, block.BaseFee())
 			vmctx  = core.NewEVMContext(msg, block.Header(), hmy.BlockChain, nil)
 
 			vmConf vm.Config
@@ -722,6 +725,13 @@ func (hmy *Harmony) TraceTx(ctx context.Context, message core.Message, vmctx vm.
 		} else if *config.Tracer == "RosettaBlockTracer" {
 			tracer = &tracers.RosettaBlockTracer{ParityBlockTracer: &tracers.ParityBlockTracer{}}
 			break
+		} else if *config.Tracer == "ParityReplayTracer" {
+			replayTracer, err := tracers.NewParityReplayTracer(config.TracerConfig, newStateDiffTracer)
+			if err != nil {
+				return nil, err
+			}
+			tracer = replayTracer
+			break
 		}
 		// Define a meaningful timeout of a single transaction trace
 		timeout := defaultTraceTimeout
@@ -730,15 +740,26 @@ func (hmy *Harmony) TraceTx(ctx context.Context, message core.Message, vmctx vm.
 				return nil, err
 			}
 		}
-		// Constuct the JavaScript tracer to execute with
-		if tracer, err = tracers.New(*config.Tracer); err != nil {
-			return nil, err
+		// Constuct the native tracer to execute with, or the JavaScript one if
+		// there is no native tracer of that name
+		var stop func(error)
+		if nativeTracer, ok, err := native.New(*config.Tracer, config.TracerConfig); ok {
+			if err != nil {
+				return nil, err
+			}
+			tracer, stop = nativeTracer, nativeTracer.Stop
+		} else {
+			jsTracer, err := tracers.New(*config.Tracer)
+			if err != nil {
+				return nil, err
+			}
+			tracer, stop = jsTracer, jsTracer.Stop
 		}
 		// Handle timeouts and RPC cancellations
 		deadlineCtx, cancel := context.WithTimeout(ctx, timeout)
 		go func() {
 			<-deadlineCtx.Done()
-			tracer.(*tracers.Tracer).Stop(errors.New("execution timeout"))
+			stop(errors.New("execution timeout"))
 		}()
 		defer cancel()
 
@@ -749,7 +770,7 @@ func (hmy *Harmony) TraceTx(ctx context.Context, message core.Message, vmctx vm.
 		tracer = vm.NewStructLogger(config.LogConfig)
 	}
 	// Run the transaction with tracing enabled.
-	vmenv := vm.NewEVM(vmctx, statedb, hmy.BlockChain.Config(), vm.Config{Debug: true, Tracer: tracer})
+	vmenv := vm.NewEVM(vmctx, statedb, hmy.BlockChain.Config(), vm.Config{Debug: true, Tracer: tracer, NoBaseFee: true})
 
 	result, err := core.ApplyMessage(vmenv, message, new(core.GasPool).AddGas(message.Gas()))
 	if err != nil {
@@ -767,16 +788,27 @@ func (hmy *Harmony) TraceTx(ctx context.Context, message core.Message, vmctx vm.
 
 	case *tracers.Tracer:
 		return tracer.GetResult()
+	case native.Tracer:
+		return tracer.GetResult()
 	case *tracers.ParityBlockTracer:
 		return tracer.GetResult()
 	case *tracers.RosettaBlockTracer:
 		return tracer.GetResult()
+	case *tracers.ParityReplayTracer:
+		return tracer.GetResult()
 
 	default:
 		panic(fmt.Sprintf("bad tracer type %T", tracer))
 	}
 }
 
+// newStateDiffTracer returns the native prestate tracer in diff mode, which
+// records the stateDiff of the ParityReplayTracer.
+func newStateDiffTracer() (tracers.StateDiffTracer, error) {
+	tracer, _, err := native.New("prestateTracer", json.RawMessage(`{"diffMode":true}`))
+	return tracer, err
+}
+
 // ComputeTxEnv returns the execution environment of a certain transaction.
 func (hmy *Harmony) ComputeTxEnv(block *types.Block, txIndex int, reexec uint64) (core.Message, vm.Context, *state.DB, error) {
 	// Create the parent state database
@@ -804,7 +836,7 @@ func (hmy *Harmony) ComputeTxEnv(block *types.Block, txIndex int, reexec uint64)
 		}
 
 		// Assemble the transaction call message and return if the requested offset
-		msg, _ := tx.AsMessage(signer)
+		msg, _ := tx.AsMessage(signer, block.BaseFee())
 		context := core.NewEVMContext(msg, block.Header(), hmy.BlockChain, nil)
 		if idx == txIndex {
 			return msg, context, statedb, nil
@@ -843,7 +875,7 @@ func (hmy *Harmony) ComputeTxEnvEachBlockWithoutApply(block *types.Block, reexec
 		}
 
 		// Assemble the transaction call message and return if the requested offset
-		msg, _ := tx.AsMessage(signer)
+		msg, _ := tx.AsMessage(signer, block.BaseFee())
 		context := core.NewEVMContext(msg, block.Header(), hmy.BlockChain, nil)
 		if !cb(idx, tx, msg, context, statedb) {
 			return nil
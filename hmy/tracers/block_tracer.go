@@ -442,6 +442,16 @@ func (jst *ParityBlockTracer) GetStorage() *TraceBlockStorage {
 
 // GetResult calls the Javascript 'result' function and returns its value, or any accumulated error
 func (jst *ParityBlockTracer) GetResult() ([]json.RawMessage, error) {
+	return jst.results(true)
+}
+
+// GetTraces returns the traces like GetResult, but without the block and
+// transaction fields, as trace_call and trace_replayBlockTransactions do.
+func (jst *ParityBlockTracer) GetTraces() ([]json.RawMessage, error) {
+	return jst.results(false)
+}
+
+func (jst *ParityBlockTracer) results(withHead bool) ([]json.RawMessage, error) {
 	var results []json.RawMessage
 	var err error
 	var headPiece string
@@ -467,7 +477,7 @@ func (jst *ParityBlockTracer) GetResult() ([]json.RawMessage, error) {
 			resultPiece = `,"result":null`
 		}
 
-		jstr := "{" + headPiece + bodyPiece + resultPiece + "}"
+		jstr := "{" + strings.TrimPrefix(headPiece+bodyPiece+resultPiece, ",") + "}"
 		results = append(results, json.RawMessage(jstr))
 		for i, subAc := range ac.subCalls {
 			finalize(subAc, append(traceAddress[:], i))
@@ -475,10 +485,12 @@ func (jst *ParityBlockTracer) GetResult() ([]json.RawMessage, error) {
 	}
 	for _, curTx := range jst.tracers {
 		root := &curTx.action
-		headPiece = fmt.Sprintf(
-			`"blockNumber":%d,"blockHash":"%s","transactionHash":"%s","transactionPosition":%d`,
-			curTx.blockNumber, curTx.blockHash.Hex(), curTx.transactionHash.Hex(), curTx.transactionPosition,
-		)
+		if withHead {
+			headPiece = fmt.Sprintf(
+				`"blockNumber":%d,"blockHash":"%s","transactionHash":"%s","transactionPosition":%d`,
+				curTx.blockNumber, curTx.blockHash.Hex(), curTx.transactionHash.Hex(), curTx.transactionPosition,
+			)
+		}
 		finalize(root, make([]int, 0))
 	}
 	return results, err
@@ -24,6 +24,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/core/vm"
 	"github.com/harmony-one/harmony/crypto/hash"
 )
 
@@ -204,3 +205,98 @@ func (ts *TraceBlockStorage) ToJson() (json.RawMessage, error) {
 	}
 	return json.Marshal(results)
 }
+
+// InternalTransaction is a value transfer or a contract creation done by a
+// contract during a transaction, i.e. a trace action which is not the top-level
+// call of the transaction.
+type InternalTransaction struct {
+	BlockNumber  uint64         `json:"blockNumber"`
+	TxHash       common.Hash    `json:"transactionHash"`
+	TxIndex      uint64         `json:"transactionPosition"`
+	Index        uint64         `json:"index"` // position of the action in the trace of the transaction
+	TraceAddress []uint64       `json:"traceAddress"`
+	Type         string         `json:"type"` // call, create or suicide
+	From         common.Address `json:"from"`
+	To           common.Address `json:"to"`
+	Value        *big.Int       `json:"value"`
+}
+
+// InternalTransactions returns the internal transactions of the block: the
+// calls moving value, the contract creations and the self destructs of the
+// contracts. The actions reverted, or below a reverted action, are skipped.
+func (ts *TraceBlockStorage) InternalTransactions() ([]*InternalTransaction, error) {
+	var results []*InternalTransaction
+	for txIndex, b := range ts.TraceStorages {
+		var txStorage TxStorage
+		if err := rlp.DecodeBytes(b, &txStorage); err != nil {
+			return nil, err
+		}
+		var reverted [][]uint
+		for index, acStorage := range txStorage.Storages {
+			traceAddress := acStorage.TraceAddress
+			ac := &action{}
+			ac.fromStorage(ts, acStorage)
+			if ac.err != nil {
+				reverted = append(reverted, traceAddress)
+			}
+			if len(traceAddress) == 0 || isBelowAny(traceAddress, reverted) {
+				continue
+			}
+
+			var typ string
+			switch ac.op {
+			case vm.CALL:
+				if ac.value == nil || ac.value.Sign() == 0 {
+					continue
+				}
+				typ = "call"
+			case vm.CREATE, vm.CREATE2:
+				typ = "create"
+			case vm.SELFDESTRUCT:
+				typ = "suicide"
+			default:
+				continue
+			}
+			itx := &InternalTransaction{
+				BlockNumber:  ts.Number,
+				TxHash:       txStorage.Hash,
+				TxIndex:      uint64(txIndex),
+				Index:        uint64(index),
+				TraceAddress: make([]uint64, 0, len(traceAddress)),
+				Type:         typ,
+				From:         ac.from,
+				To:           ac.to,
+				Value:        new(big.Int),
+			}
+			for _, i := range traceAddress {
+				itx.TraceAddress = append(itx.TraceAddress, uint64(i))
+			}
+			if ac.value != nil {
+				itx.Value.Set(ac.value)
+			}
+			results = append(results, itx)
+		}
+	}
+	return results, nil
+}
+
+// isBelowAny returns whether the trace address is one of the addresses, or a
+// descendant of one of them.
+func isBelowAny(traceAddress []uint, addresses [][]uint) bool {
+	for _, address := range addresses {
+		if len(address) > len(traceAddress) {
+			continue
+		}
+		below := true
+		for i := range address {
+			if address[i] != traceAddress[i] {
+				below = false
+				break
+			}
+		}
+		if below {
+			return true
+		}
+	}
+	return false
+}
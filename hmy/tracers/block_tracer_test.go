@@ -294,3 +294,41 @@ func TestActionMarshal(t *testing.T) {
 		}
 	}
 }
+
+func TestInternalTransactions(t *testing.T) {
+	addr := func(i byte) common.Address { return common.BytesToAddress([]byte{i}) }
+	reverted := &action{op: vm.CALL, from: addr(2), to: addr(6), value: big.NewInt(0), err: errors.New("Reverted"), revert: []byte{}}
+	reverted.push(&action{op: vm.CALL, from: addr(6), to: addr(7), value: big.NewInt(4)})
+	root := action{op: vm.CALL, from: addr(1), to: addr(2), value: big.NewInt(10)}
+	root.push(&action{op: vm.CALL, from: addr(2), to: addr(3), value: big.NewInt(5)})
+	root.push(&action{op: vm.STATICCALL, from: addr(2), to: addr(4), value: big.NewInt(0)})
+	root.push(&action{op: vm.CALL, from: addr(2), to: addr(4), value: big.NewInt(0)})
+	root.push(&action{op: vm.CREATE, from: addr(2), to: addr(5), value: big.NewInt(0)})
+	root.push(reverted)
+	root.push(&action{op: vm.SELFDESTRUCT, from: addr(2), to: addr(1), value: big.NewInt(3)})
+
+	txHash := common.HexToHash("0x01")
+	tracer := &ParityBlockTracer{
+		Number:  10,
+		tracers: []*ParityTxTracer{{transactionHash: txHash, action: root}},
+	}
+	itxs, err := tracer.GetStorage().InternalTransactions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := []*InternalTransaction{
+		{10, txHash, 0, 1, []uint64{0}, "call", addr(2), addr(3), big.NewInt(5)},
+		{10, txHash, 0, 4, []uint64{3}, "create", addr(2), addr(5), big.NewInt(0)},
+		{10, txHash, 0, 7, []uint64{5}, "suicide", addr(2), addr(1), big.NewInt(3)},
+	}
+	if len(itxs) != len(exp) {
+		t.Fatalf("unexpected internal transactions size %v / %v", len(itxs), len(exp))
+	}
+	for i := range exp {
+		got, _ := json.Marshal(itxs[i])
+		want, _ := json.Marshal(exp[i])
+		if !bytes.Equal(got, want) {
+			t.Errorf("unexpected internal transaction %s / %s", got, want)
+		}
+	}
+}
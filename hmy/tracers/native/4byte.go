@@ -0,0 +1,111 @@
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+func init() {
+	register("4byteTracer", newFourByteTracer)
+}
+
+// fourByteTracer is the native counterpart of 4byte_tracer.js. It collects
+// the 4 byte method identifiers of the calls along with the size of their
+// arguments, so that a reversed signature can be matched against the size of
+// the data.
+//
+// Example:
+//
+//	> debug.traceTransaction( "0x214e597e35da083692f5386141e69f47e973b2c56e7a8073b1ea08fd7571e9de", {tracer: "4byteTracer"})
+//	{
+//	  0x27dc297e-128: 1,
+//	  0x38cc4831-0: 2,
+//	  0x524f3889-96: 1,
+//	  0xadf59f99-288: 1,
+//	  0xc281d19e-0: 1
+//	}
+type fourByteTracer struct {
+	ids         map[string]int // ids aggregates the 4byte ids found
+	precompiles map[common.Address]bool
+
+	interrupt uint32 // Atomic flag to signal execution interruption
+	reason    error  // Textual reason for the interruption
+}
+
+func newFourByteTracer(config json.RawMessage) (Tracer, error) {
+	return &fourByteTracer{ids: make(map[string]int)}, nil
+}
+
+// store saves the given identifier and datasize.
+func (t *fourByteTracer) store(id []byte, size int) {
+	t.ids[hexutil.Encode(id)+"-"+strconv.Itoa(size)]++
+}
+
+// CaptureStart implements the vm.Tracer interface.
+func (t *fourByteTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	// Precompiles are just fancy opcodes, their calls are skipped
+	t.precompiles = make(map[common.Address]bool)
+	for _, addr := range vm.ActivePrecompiles(env.ChainConfig().Rules(env.EpochNumber)) {
+		t.precompiles[addr] = true
+	}
+	if len(input) >= 4 {
+		t.store(input[0:4], len(input)-4)
+	}
+	return nil
+}
+
+// CaptureState implements the vm.Tracer interface.
+func (t *fourByteTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) (vm.HookAfter, error) {
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		env.Cancel()
+	}
+	return nil, nil
+}
+
+// CaptureFault implements the vm.Tracer interface.
+func (t *fourByteTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd implements the vm.Tracer interface.
+func (t *fourByteTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) error {
+	return nil
+}
+
+// CaptureEnter implements the vm.CallFrameTracer interface.
+func (t *fourByteTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	// Only calls carry a method identifier, creations do not
+	if typ != vm.CALL && typ != vm.CALLCODE && typ != vm.DELEGATECALL && typ != vm.STATICCALL {
+		return
+	}
+	if len(input) < 4 || t.precompiles[to] {
+		return
+	}
+	t.store(input[0:4], len(input)-4)
+}
+
+// CaptureExit implements the vm.CallFrameTracer interface.
+func (t *fourByteTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+}
+
+// GetResult returns the json-encoded identifiers and their counts.
+func (t *fourByteTracer) GetResult() (json.RawMessage, error) {
+	res, err := json.Marshal(t.ids)
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *fourByteTracer) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
@@ -0,0 +1,217 @@
+package native
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+func init() {
+	register("callTracer", newCallTracer)
+}
+
+type callLog struct {
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+type callFrame struct {
+	Type         string          `json:"type"`
+	From         common.Address  `json:"from"`
+	Gas          hexutil.Uint64  `json:"gas"`
+	GasUsed      hexutil.Uint64  `json:"gasUsed"`
+	To           *common.Address `json:"to,omitempty"`
+	Input        hexutil.Bytes   `json:"input"`
+	Output       hexutil.Bytes   `json:"output,omitempty"`
+	Error        string          `json:"error,omitempty"`
+	RevertReason string          `json:"revertReason,omitempty"`
+	Calls        []callFrame     `json:"calls,omitempty"`
+	Logs         []callLog       `json:"logs,omitempty"`
+	Value        *hexutil.Big    `json:"value,omitempty"`
+}
+
+// processOutput records the output of the frame, and the revert reason if
+// the frame was reverted with one.
+func (f *callFrame) processOutput(output []byte, err error) {
+	output = common.CopyBytes(output)
+	if err == nil {
+		f.Output = output
+		return
+	}
+	f.Error = err.Error()
+	if f.Type == vm.CREATE.String() || f.Type == vm.CREATE2.String() {
+		f.To = nil
+	}
+	if err != vm.ErrExecutionReverted || len(output) == 0 {
+		return
+	}
+	f.Output = output
+	if reason, unpackErr := abi.UnpackRevert(output); unpackErr == nil {
+		f.RevertReason = reason
+	}
+}
+
+// clearFailedLogs drops the logs of the reverted frames, which never made it
+// into the receipt.
+func (f *callFrame) clearFailedLogs(parentFailed bool) {
+	failed := f.Error != "" || parentFailed
+	if failed {
+		f.Logs = nil
+	}
+	for i := range f.Calls {
+		f.Calls[i].clearFailedLogs(failed)
+	}
+}
+
+type callTracerConfig struct {
+	OnlyTopCall bool `json:"onlyTopCall"` // If true, call tracer won't collect any subcalls
+	WithLog     bool `json:"withLog"`     // If true, call tracer will collect event logs
+}
+
+// callTracer is the native counterpart of call_tracer.js. It reports the tree
+// of calls made by a transaction, optionally with the logs they emitted.
+type callTracer struct {
+	callstack []callFrame
+	config    callTracerConfig
+	gasLimit  uint64
+
+	interrupt uint32 // Atomic flag to signal execution interruption
+	reason    error  // Textual reason for the interruption
+}
+
+func newCallTracer(config json.RawMessage) (Tracer, error) {
+	t := &callTracer{callstack: make([]callFrame, 1)}
+	if err := parseConfig(config, &t.config); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// CaptureTxStart implements the vm.TxTracer interface.
+func (t *callTracer) CaptureTxStart(gasLimit uint64) {
+	t.gasLimit = gasLimit
+}
+
+// CaptureTxEnd implements the vm.TxTracer interface. The outermost frame
+// accounts for the gas of the whole transaction.
+func (t *callTracer) CaptureTxEnd(restGas uint64) {
+	t.callstack[0].Gas = hexutil.Uint64(t.gasLimit)
+	t.callstack[0].GasUsed = hexutil.Uint64(t.gasLimit - restGas)
+}
+
+// CaptureStart implements the vm.Tracer interface.
+func (t *callTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	typ := vm.CALL
+	if create {
+		typ = vm.CREATE
+	}
+	t.callstack[0] = newCallFrame(typ, from, to, input, gas, value)
+	return nil
+}
+
+// CaptureState implements the vm.Tracer interface. It collects the logs and
+// the self destructs, which are not call frames of their own in the EVM.
+func (t *callTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) (vm.HookAfter, error) {
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		env.Cancel()
+		return nil, nil
+	}
+	if err != nil || (t.config.OnlyTopCall && depth > 1) {
+		return nil, nil
+	}
+	top := &t.callstack[len(t.callstack)-1]
+	switch {
+	case op >= vm.LOG0 && op <= vm.LOG4 && t.config.WithLog:
+		topics := make([]common.Hash, int(op-vm.LOG0))
+		for i := range topics {
+			topics[i] = common.BigToHash(stack.Back(2 + i))
+		}
+		top.Logs = append(top.Logs, callLog{
+			Address: contract.Address(),
+			Topics:  topics,
+			Data:    memory.GetCopy(stack.Back(0).Int64(), stack.Back(1).Int64()),
+		})
+
+	case op == vm.SELFDESTRUCT && !t.config.OnlyTopCall:
+		balance := env.StateDB.GetBalance(contract.Address())
+		top.Calls = append(top.Calls, newCallFrame(op, contract.Address(), common.BigToAddress(stack.Back(0)), nil, 0, balance))
+	}
+	return nil, nil
+}
+
+// CaptureFault implements the vm.Tracer interface.
+func (t *callTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd implements the vm.Tracer interface.
+func (t *callTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) error {
+	t.callstack[0].GasUsed = hexutil.Uint64(gasUsed)
+	t.callstack[0].processOutput(output, err)
+	return nil
+}
+
+// CaptureEnter implements the vm.CallFrameTracer interface.
+func (t *callTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	if t.config.OnlyTopCall {
+		return
+	}
+	t.callstack = append(t.callstack, newCallFrame(typ, from, to, input, gas, value))
+}
+
+// CaptureExit implements the vm.CallFrameTracer interface.
+func (t *callTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	if t.config.OnlyTopCall || len(t.callstack) <= 1 {
+		return
+	}
+	size := len(t.callstack)
+	call := t.callstack[size-1]
+	t.callstack = t.callstack[:size-1]
+
+	call.GasUsed = hexutil.Uint64(gasUsed)
+	call.processOutput(output, err)
+	t.callstack[size-2].Calls = append(t.callstack[size-2].Calls, call)
+}
+
+// GetResult returns the json-encoded call tree of the transaction.
+func (t *callTracer) GetResult() (json.RawMessage, error) {
+	if len(t.callstack) != 1 {
+		return nil, errors.New("incorrect number of top-level calls")
+	}
+	if t.config.WithLog {
+		t.callstack[0].clearFailedLogs(false)
+	}
+	res, err := json.Marshal(t.callstack[0])
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *callTracer) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
+
+func newCallFrame(typ vm.OpCode, from, to common.Address, input []byte, gas uint64, value *big.Int) callFrame {
+	frame := callFrame{
+		Type:  typ.String(),
+		From:  from,
+		To:    &to,
+		Input: common.CopyBytes(input),
+		Gas:   hexutil.Uint64(gas),
+	}
+	if value != nil {
+		frame.Value = (*hexutil.Big)(new(big.Int).Set(value))
+	}
+	return frame
+}
@@ -0,0 +1,155 @@
+package native
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+func init() {
+	register("muxTracer", newMuxTracer)
+}
+
+// muxTracer runs several native tracers over the same execution. Its config
+// maps the name of every tracer to run to the config of that tracer, e.g.
+//
+//	{"callTracer": {"onlyTopCall": true}, "4byteTracer": null}
+//
+// and its result maps the name of every tracer to its result.
+type muxTracer struct {
+	names   []string
+	tracers []Tracer
+}
+
+func newMuxTracer(config json.RawMessage) (Tracer, error) {
+	var configs map[string]json.RawMessage
+	if err := parseConfig(config, &configs); err != nil {
+		return nil, err
+	}
+	t := &muxTracer{}
+	for name, cfg := range configs {
+		tracer, ok, err := New(name, cfg)
+		if !ok {
+			return nil, fmt.Errorf("unknown native tracer %q", name)
+		}
+		if err != nil {
+			return nil, err
+		}
+		t.names = append(t.names, name)
+		t.tracers = append(t.tracers, tracer)
+	}
+	return t, nil
+}
+
+// CaptureTxStart implements the vm.TxTracer interface.
+func (t *muxTracer) CaptureTxStart(gasLimit uint64) {
+	for _, tracer := range t.tracers {
+		if tracer, ok := tracer.(vm.TxTracer); ok {
+			tracer.CaptureTxStart(gasLimit)
+		}
+	}
+}
+
+// CaptureTxEnd implements the vm.TxTracer interface.
+func (t *muxTracer) CaptureTxEnd(restGas uint64) {
+	for _, tracer := range t.tracers {
+		if tracer, ok := tracer.(vm.TxTracer); ok {
+			tracer.CaptureTxEnd(restGas)
+		}
+	}
+}
+
+// CaptureStart implements the vm.Tracer interface.
+func (t *muxTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	for _, tracer := range t.tracers {
+		if err := tracer.CaptureStart(env, from, to, create, input, gas, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CaptureState implements the vm.Tracer interface. The returned hook runs the
+// hooks of all the tracers which returned one.
+func (t *muxTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) (vm.HookAfter, error) {
+	var hooks []vm.HookAfter
+	for _, tracer := range t.tracers {
+		hook, captureErr := tracer.CaptureState(env, pc, op, gas, cost, memory, stack, contract, depth, err)
+		if captureErr != nil {
+			return nil, captureErr
+		}
+		if hook != nil {
+			hooks = append(hooks, hook)
+		}
+	}
+	if len(hooks) == 0 {
+		return nil, nil
+	}
+	return func(memory *vm.Memory, stack *vm.Stack) {
+		for _, hook := range hooks {
+			hook(memory, stack)
+		}
+	}, nil
+}
+
+// CaptureFault implements the vm.Tracer interface.
+func (t *muxTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	for _, tracer := range t.tracers {
+		if captureErr := tracer.CaptureFault(env, pc, op, gas, cost, memory, stack, contract, depth, err); captureErr != nil {
+			return captureErr
+		}
+	}
+	return nil
+}
+
+// CaptureEnd implements the vm.Tracer interface.
+func (t *muxTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	for _, tracer := range t.tracers {
+		if captureErr := tracer.CaptureEnd(output, gasUsed, d, err); captureErr != nil {
+			return captureErr
+		}
+	}
+	return nil
+}
+
+// CaptureEnter implements the vm.CallFrameTracer interface.
+func (t *muxTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	for _, tracer := range t.tracers {
+		if tracer, ok := tracer.(vm.CallFrameTracer); ok {
+			tracer.CaptureEnter(typ, from, to, input, gas, value)
+		}
+	}
+}
+
+// CaptureExit implements the vm.CallFrameTracer interface.
+func (t *muxTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	for _, tracer := range t.tracers {
+		if tracer, ok := tracer.(vm.CallFrameTracer); ok {
+			tracer.CaptureExit(output, gasUsed, err)
+		}
+	}
+}
+
+// GetResult returns the json-encoded results of all the tracers by name.
+func (t *muxTracer) GetResult() (json.RawMessage, error) {
+	results := make(map[string]json.RawMessage, len(t.tracers))
+	for i, tracer := range t.tracers {
+		res, err := tracer.GetResult()
+		if err != nil {
+			return nil, err
+		}
+		results[t.names[i]] = res
+	}
+	return json.Marshal(results)
+}
+
+// Stop terminates execution of all the tracers at the first opportune moment.
+func (t *muxTracer) Stop(err error) {
+	for _, tracer := range t.tracers {
+		tracer.Stop(err)
+	}
+}
@@ -0,0 +1,52 @@
+// Package native is a collection of tracers written in Go, which run much
+// faster than their JavaScript counterparts in hmy/tracers.
+package native
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+// Tracer is a native tracer. Besides the vm.Tracer callbacks it can be stopped
+// early and returns its result as JSON, like the JavaScript tracers.
+type Tracer interface {
+	vm.Tracer
+	GetResult() (json.RawMessage, error)
+	Stop(err error)
+}
+
+// ctorFn builds a native tracer from its JSON encoded configuration, which may
+// be empty.
+type ctorFn func(config json.RawMessage) (Tracer, error)
+
+// ctors contains all the native tracers by name.
+var ctors = make(map[string]ctorFn)
+
+// register makes a native tracer available under name.
+func register(name string, ctor ctorFn) {
+	ctors[name] = ctor
+}
+
+// New returns the native tracer registered under name, configured by config.
+// The boolean is false if there is no native tracer of that name.
+func New(name string, config json.RawMessage) (Tracer, bool, error) {
+	ctor, ok := ctors[name]
+	if !ok {
+		return nil, false, nil
+	}
+	tracer, err := ctor(config)
+	return tracer, true, err
+}
+
+// parseConfig decodes the optional JSON configuration of a tracer into v.
+func parseConfig(config json.RawMessage, v interface{}) error {
+	if len(config) == 0 || string(config) == "null" {
+		return nil
+	}
+	if err := json.Unmarshal(config, v); err != nil {
+		return errors.New("invalid tracer config: " + err.Error())
+	}
+	return nil
+}
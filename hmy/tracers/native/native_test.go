@@ -0,0 +1,248 @@
+package native
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/core/vm/runtime"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+var (
+	origin  = common.HexToAddress("0x00000000000000000000000000000000000000ff")
+	caller  = common.HexToAddress("0x00000000000000000000000000000000000000aa")
+	callee  = common.HexToAddress("0x00000000000000000000000000000000000000bb")
+	reverts = common.HexToAddress("0x00000000000000000000000000000000000000cc")
+
+	originBalance = big.NewInt(1000000000)
+)
+
+// traceTestTx runs a transaction from origin to caller, which calls callee
+// with the method id 0x12345678 and then reverts, and returns the trace of
+// the tracer registered under name.
+func traceTestTx(t *testing.T, name string, config string) (json.RawMessage, core.ExecutionResult) {
+	t.Helper()
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.AddBalance(origin, originBalance)
+	statedb.SetCode(caller, []byte{
+		// mstore(0, 0x12345678 << 224)
+		byte(vm.PUSH4), 0x12, 0x34, 0x56, 0x78, byte(vm.PUSH1), 0xe0, byte(vm.SHL), byte(vm.PUSH1), 0, byte(vm.MSTORE),
+		// call(gas, callee, 0, 0, 4, 0, 32)
+		byte(vm.PUSH1), 32, byte(vm.PUSH1), 0, byte(vm.PUSH1), 4, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0xbb, byte(vm.GAS), byte(vm.CALL), byte(vm.POP),
+		// call(gas, reverts, 0, 0, 0, 0, 0)
+		byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0xcc, byte(vm.GAS), byte(vm.CALL), byte(vm.POP),
+		byte(vm.STOP),
+	}, false)
+	statedb.SetCode(callee, []byte{
+		// sstore(0, 1)
+		byte(vm.PUSH1), 1, byte(vm.PUSH1), 0, byte(vm.SSTORE),
+		// log1(0, 0, 0x42)
+		byte(vm.PUSH1), 0x42, byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.LOG1),
+		// mstore(0, 7) return(0, 32)
+		byte(vm.PUSH1), 7, byte(vm.PUSH1), 0, byte(vm.MSTORE), byte(vm.PUSH1), 32, byte(vm.PUSH1), 0, byte(vm.RETURN),
+	}, false)
+	statedb.SetCode(reverts, []byte{
+		// log0(0, 0) revert(0, 0)
+		byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.LOG0), byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.REVERT),
+	}, false)
+
+	tracer, ok, err := New(name, json.RawMessage(config))
+	if !ok || err != nil {
+		t.Fatalf("failed to create %s: %v, %v", name, ok, err)
+	}
+	env := runtime.NewEnv(&runtime.Config{
+		ChainConfig: params.TestChainConfig,
+		Origin:      origin,
+		BlockNumber: big.NewInt(1),
+		EpochNumber: big.NewInt(1),
+		Time:        big.NewInt(1),
+		GasLimit:    1000000,
+		GasPrice:    big.NewInt(1),
+		State:       statedb,
+		EVMConfig:   vm.Config{Debug: true, Tracer: tracer},
+	})
+	msg := types.NewMessage(origin, &caller, 0, new(big.Int), 100000, big.NewInt(1), nil, false)
+	result, err := core.ApplyMessage(env, msg, new(core.GasPool).AddGas(msg.Gas()))
+	if err != nil {
+		t.Fatalf("failed to execute transaction: %v", err)
+	}
+	res, err := tracer.GetResult()
+	if err != nil {
+		t.Fatalf("failed to retrieve trace result: %v", err)
+	}
+	return res, result
+}
+
+func TestCallTracer(t *testing.T) {
+	res, result := traceTestTx(t, "callTracer", `{"withLog": true}`)
+	var call callFrame
+	if err := json.Unmarshal(res, &call); err != nil {
+		t.Fatalf("failed to unmarshal trace result: %v", err)
+	}
+	if call.Type != "CALL" || call.From != origin || *call.To != caller {
+		t.Errorf("wrong top call: %s %x -> %x", call.Type, call.From, call.To)
+	}
+	if uint64(call.Gas) != 100000 || uint64(call.GasUsed) != result.UsedGas {
+		t.Errorf("wrong top call gas: have %d/%d want %d/%d", call.GasUsed, call.Gas, result.UsedGas, 100000)
+	}
+	if len(call.Calls) != 2 {
+		t.Fatalf("wrong number of subcalls: have %d want 2", len(call.Calls))
+	}
+
+	sub := call.Calls[0]
+	if sub.From != caller || *sub.To != callee || sub.Input.String() != "0x12345678" || sub.Error != "" {
+		t.Errorf("wrong first subcall: %+v", sub)
+	}
+	if len(sub.Output) != 32 || sub.Output[31] != 7 {
+		t.Errorf("wrong first subcall output: %x", sub.Output)
+	}
+	if len(sub.Logs) != 1 || sub.Logs[0].Address != callee || sub.Logs[0].Topics[0] != common.BigToHash(big.NewInt(0x42)) {
+		t.Errorf("wrong first subcall logs: %+v", sub.Logs)
+	}
+
+	sub = call.Calls[1]
+	if *sub.To != reverts || sub.Error != vm.ErrExecutionReverted.Error() {
+		t.Errorf("wrong second subcall: %+v", sub)
+	}
+	if len(sub.Logs) != 0 {
+		t.Errorf("reverted subcall kept its logs: %+v", sub.Logs)
+	}
+}
+
+func TestCallTracerOnlyTopCall(t *testing.T) {
+	res, _ := traceTestTx(t, "callTracer", `{"onlyTopCall": true}`)
+	var call callFrame
+	if err := json.Unmarshal(res, &call); err != nil {
+		t.Fatalf("failed to unmarshal trace result: %v", err)
+	}
+	if len(call.Calls) != 0 || len(call.Logs) != 0 {
+		t.Errorf("top call only trace has subcalls or logs: %+v", call)
+	}
+}
+
+func TestCallTracerFailedCreate(t *testing.T) {
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	statedb.AddBalance(origin, originBalance)
+	statedb.SetCode(caller, []byte{
+		// create(1, 0, 0) without any balance to send
+		byte(vm.PUSH1), 0, byte(vm.PUSH1), 0, byte(vm.PUSH1), 1, byte(vm.CREATE), byte(vm.POP),
+		byte(vm.STOP),
+	}, false)
+
+	tracer, _, err := New("callTracer", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := runtime.NewEnv(&runtime.Config{
+		ChainConfig: params.TestChainConfig,
+		Origin:      origin,
+		BlockNumber: big.NewInt(1),
+		EpochNumber: big.NewInt(1),
+		Time:        big.NewInt(1),
+		GasLimit:    1000000,
+		GasPrice:    big.NewInt(1),
+		State:       statedb,
+		EVMConfig:   vm.Config{Debug: true, Tracer: tracer},
+	})
+	msg := types.NewMessage(origin, &caller, 0, new(big.Int), 100000, big.NewInt(1), nil, false)
+	if _, err := core.ApplyMessage(env, msg, new(core.GasPool).AddGas(msg.Gas())); err != nil {
+		t.Fatalf("failed to execute transaction: %v", err)
+	}
+	res, err := tracer.GetResult()
+	if err != nil {
+		t.Fatalf("failed to retrieve trace result: %v", err)
+	}
+	var call callFrame
+	if err := json.Unmarshal(res, &call); err != nil {
+		t.Fatalf("failed to unmarshal trace result: %v", err)
+	}
+	// the creation failing before running its code is still a frame
+	if len(call.Calls) != 1 {
+		t.Fatalf("wrong number of subcalls: have %d want 1", len(call.Calls))
+	}
+	if sub := call.Calls[0]; sub.Type != "CREATE" || sub.From != caller || sub.Error != vm.ErrInsufficientBalance.Error() || sub.GasUsed != 0 {
+		t.Errorf("wrong failed creation: %+v", sub)
+	}
+}
+
+type testAccount struct {
+	Balance string                      `json:"balance"`
+	Nonce   uint64                      `json:"nonce"`
+	Storage map[common.Hash]common.Hash `json:"storage"`
+}
+
+func TestPrestateTracer(t *testing.T) {
+	res, _ := traceTestTx(t, "prestateTracer", "")
+	var pre map[common.Address]testAccount
+	if err := json.Unmarshal(res, &pre); err != nil {
+		t.Fatalf("failed to unmarshal trace result: %v", err)
+	}
+	for _, addr := range []common.Address{origin, caller, callee, reverts} {
+		if _, ok := pre[addr]; !ok {
+			t.Errorf("account %x missing from the prestate", addr)
+		}
+	}
+	if have, want := pre[origin].Balance, "0x3b9aca00"; have != want || pre[origin].Nonce != 0 {
+		t.Errorf("wrong origin prestate: have %s/%d want %s/0", have, pre[origin].Nonce, want)
+	}
+	if value, ok := pre[callee].Storage[common.Hash{}]; !ok || value != (common.Hash{}) {
+		t.Errorf("wrong callee prestate storage: %v", pre[callee].Storage)
+	}
+}
+
+func TestPrestateTracerDiffMode(t *testing.T) {
+	res, result := traceTestTx(t, "prestateTracer", `{"diffMode": true}`)
+	var diff struct {
+		Pre  map[common.Address]testAccount `json:"pre"`
+		Post map[common.Address]testAccount `json:"post"`
+	}
+	if err := json.Unmarshal(res, &diff); err != nil {
+		t.Fatalf("failed to unmarshal trace result: %v", err)
+	}
+	// Only the origin, paying for gas, and the callee, writing storage, change
+	if len(diff.Pre) != 2 || len(diff.Post) != 2 {
+		t.Errorf("wrong number of changed accounts: have %d/%d want 2/2", len(diff.Pre), len(diff.Post))
+	}
+	spent := new(big.Int).SetUint64(result.UsedGas)
+	if have, want := diff.Post[origin].Balance, new(big.Int).Sub(originBalance, spent); have != "0x"+want.Text(16) || diff.Post[origin].Nonce != 1 {
+		t.Errorf("wrong origin poststate: have %s/%d want %#x/1", have, diff.Post[origin].Nonce, want)
+	}
+	if len(diff.Pre[callee].Storage) != 0 {
+		t.Errorf("empty slot kept in the prestate: %v", diff.Pre[callee].Storage)
+	}
+	if value := diff.Post[callee].Storage[common.Hash{}]; value != common.BigToHash(common.Big1) {
+		t.Errorf("wrong callee poststate storage: %v", diff.Post[callee].Storage)
+	}
+}
+
+func TestFourByteTracer(t *testing.T) {
+	res, _ := traceTestTx(t, "4byteTracer", "")
+	var ids map[string]int
+	if err := json.Unmarshal(res, &ids); err != nil {
+		t.Fatalf("failed to unmarshal trace result: %v", err)
+	}
+	if len(ids) != 1 || ids["0x12345678-0"] != 1 {
+		t.Errorf("wrong ids: %v", ids)
+	}
+}
+
+func TestMuxTracer(t *testing.T) {
+	res, _ := traceTestTx(t, "muxTracer", `{"callTracer": {"onlyTopCall": true}, "4byteTracer": null}`)
+	var results map[string]json.RawMessage
+	if err := json.Unmarshal(res, &results); err != nil {
+		t.Fatalf("failed to unmarshal trace result: %v", err)
+	}
+	if len(results) != 2 || results["callTracer"] == nil || results["4byteTracer"] == nil {
+		t.Errorf("wrong results: %s", res)
+	}
+	if _, _, err := New("muxTracer", json.RawMessage(`{"noopTracer": null}`)); err == nil {
+		t.Error("expected error for an unknown tracer")
+	}
+}
@@ -0,0 +1,279 @@
+package native
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+func init() {
+	register("prestateTracer", newPrestateTracer)
+}
+
+type stateMap = map[common.Address]*account
+
+type account struct {
+	Balance *big.Int
+	Code    []byte
+	Nonce   uint64
+	Storage map[common.Hash]common.Hash
+}
+
+func (a *account) exists() bool {
+	return a.Nonce > 0 || len(a.Code) > 0 || len(a.Storage) > 0 || (a.Balance != nil && a.Balance.Sign() != 0)
+}
+
+// MarshalJSON encodes the balance and the code as hex strings.
+func (a *account) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Balance *hexutil.Big                `json:"balance,omitempty"`
+		Code    hexutil.Bytes               `json:"code,omitempty"`
+		Nonce   uint64                      `json:"nonce,omitempty"`
+		Storage map[common.Hash]common.Hash `json:"storage,omitempty"`
+	}{
+		Balance: (*hexutil.Big)(a.Balance),
+		Code:    a.Code,
+		Nonce:   a.Nonce,
+		Storage: a.Storage,
+	})
+}
+
+type prestateTracerConfig struct {
+	DiffMode bool `json:"diffMode"` // If true, this tracer will return state modifications
+}
+
+// prestateTracer is the native counterpart of prestate_tracer.js. It reports
+// the accounts touched by a transaction as they were before it, or in diff
+// mode both before and after it, restricted to what the transaction changed.
+type prestateTracer struct {
+	env      *vm.EVM
+	pre      stateMap
+	post     stateMap
+	create   bool
+	to       common.Address
+	gasLimit uint64
+	config   prestateTracerConfig
+	created  map[common.Address]bool
+	deleted  map[common.Address]bool
+
+	interrupt uint32 // Atomic flag to signal execution interruption
+	reason    error  // Textual reason for the interruption
+}
+
+func newPrestateTracer(config json.RawMessage) (Tracer, error) {
+	t := &prestateTracer{
+		pre:     stateMap{},
+		post:    stateMap{},
+		created: make(map[common.Address]bool),
+		deleted: make(map[common.Address]bool),
+	}
+	if err := parseConfig(config, &t.config); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// CaptureTxStart implements the vm.TxTracer interface.
+func (t *prestateTracer) CaptureTxStart(gasLimit uint64) {
+	t.gasLimit = gasLimit
+}
+
+// CaptureTxEnd implements the vm.TxTracer interface. In diff mode it compares
+// the touched accounts with their final state, once the gas has been refunded
+// and paid out.
+func (t *prestateTracer) CaptureTxEnd(restGas uint64) {
+	if !t.config.DiffMode || t.env == nil {
+		return
+	}
+	for addr, prev := range t.pre {
+		// The deleted account's state is pruned from post but kept in pre
+		if t.deleted[addr] {
+			continue
+		}
+		modified := false
+		post := &account{Storage: make(map[common.Hash]common.Hash)}
+		if balance := t.env.StateDB.GetBalance(addr); balance.Cmp(prev.Balance) != 0 {
+			modified = true
+			post.Balance = balance
+		}
+		if nonce := t.env.StateDB.GetNonce(addr); nonce != prev.Nonce {
+			modified = true
+			post.Nonce = nonce
+		}
+		if code := t.env.StateDB.GetCode(addr); !bytes.Equal(code, prev.Code) {
+			modified = true
+			post.Code = code
+		}
+		for key, val := range prev.Storage {
+			// Omit empty and unchanged slots
+			if val == (common.Hash{}) {
+				delete(prev.Storage, key)
+			}
+			newVal := t.env.StateDB.GetState(addr, key)
+			if val == newVal {
+				delete(prev.Storage, key)
+				continue
+			}
+			modified = true
+			if newVal != (common.Hash{}) {
+				post.Storage[key] = newVal
+			}
+		}
+		if modified {
+			t.post[addr] = post
+		} else {
+			delete(t.pre, addr)
+		}
+	}
+	// Created contracts had no state before the transaction, unless the
+	// address was already funded
+	for addr := range t.created {
+		if prev := t.pre[addr]; prev != nil && !prev.exists() {
+			delete(t.pre, addr)
+		}
+	}
+}
+
+// CaptureStart implements the vm.Tracer interface.
+func (t *prestateTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.env = env
+	t.create = create
+	t.to = to
+
+	t.lookupAccount(from)
+	t.lookupAccount(to)
+	t.lookupAccount(env.Coinbase)
+
+	// The value was already transferred and the gas already bought when the
+	// call starts, take both back to get the balances before the transaction.
+	t.pre[to].Balance = new(big.Int).Sub(t.pre[to].Balance, value)
+	fromBalance := new(big.Int).Mul(env.GasPrice, new(big.Int).SetUint64(t.gasLimit))
+	fromBalance.Add(fromBalance, value)
+	t.pre[from].Balance = fromBalance.Add(fromBalance, t.pre[from].Balance)
+	t.pre[from].Nonce--
+
+	if create {
+		// The created contract got its nonce already, while any account
+		// colliding with it must have had none
+		t.pre[to].Nonce = 0
+		if t.config.DiffMode {
+			t.created[to] = true
+		}
+	}
+	return nil
+}
+
+// CaptureState implements the vm.Tracer interface. It looks up every account
+// and storage slot the op is about to access.
+func (t *prestateTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) (vm.HookAfter, error) {
+	if atomic.LoadUint32(&t.interrupt) > 0 {
+		env.Cancel()
+		return nil, nil
+	}
+	if err != nil {
+		return nil, nil
+	}
+	stackLen := len(stack.Data())
+	caller := contract.Address()
+	switch {
+	case stackLen >= 1 && (op == vm.SLOAD || op == vm.SSTORE):
+		t.lookupStorage(caller, common.BigToHash(stack.Back(0)))
+
+	case stackLen >= 1 && (op == vm.EXTCODECOPY || op == vm.EXTCODEHASH || op == vm.EXTCODESIZE || op == vm.BALANCE || op == vm.SELFDESTRUCT):
+		t.lookupAccount(common.BigToAddress(stack.Back(0)))
+		if op == vm.SELFDESTRUCT {
+			t.deleted[caller] = true
+		}
+
+	case stackLen >= 5 && (op == vm.DELEGATECALL || op == vm.CALL || op == vm.STATICCALL || op == vm.CALLCODE):
+		t.lookupAccount(common.BigToAddress(stack.Back(1)))
+
+	case op == vm.CREATE:
+		addr := crypto.CreateAddress(caller, env.StateDB.GetNonce(caller))
+		t.lookupAccount(addr)
+		t.created[addr] = true
+
+	case stackLen >= 4 && op == vm.CREATE2:
+		init := memory.GetCopy(stack.Back(1).Int64(), stack.Back(2).Int64())
+		addr := crypto.CreateAddress2(caller, common.BigToHash(stack.Back(3)), crypto.Keccak256(init))
+		t.lookupAccount(addr)
+		t.created[addr] = true
+	}
+	return nil, nil
+}
+
+// CaptureFault implements the vm.Tracer interface.
+func (t *prestateTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd implements the vm.Tracer interface.
+func (t *prestateTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) error {
+	if t.config.DiffMode {
+		return nil
+	}
+	// Keep an account that existed at the address of the created contract
+	if t.create {
+		if prev := t.pre[t.to]; prev != nil && !prev.exists() {
+			delete(t.pre, t.to)
+		}
+	}
+	return nil
+}
+
+// GetResult returns the json-encoded pre state of the touched accounts, or in
+// diff mode both their pre and post states.
+func (t *prestateTracer) GetResult() (json.RawMessage, error) {
+	var (
+		res []byte
+		err error
+	)
+	if t.config.DiffMode {
+		res, err = json.Marshal(struct {
+			Post stateMap `json:"post"`
+			Pre  stateMap `json:"pre"`
+		}{t.post, t.pre})
+	} else {
+		res, err = json.Marshal(t.pre)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, t.reason
+}
+
+// Stop terminates execution of the tracer at the first opportune moment.
+func (t *prestateTracer) Stop(err error) {
+	t.reason = err
+	atomic.StoreUint32(&t.interrupt, 1)
+}
+
+// lookupAccount fetches the state of addr, unless it was already fetched.
+func (t *prestateTracer) lookupAccount(addr common.Address) {
+	if _, ok := t.pre[addr]; ok {
+		return
+	}
+	t.pre[addr] = &account{
+		Balance: t.env.StateDB.GetBalance(addr),
+		Nonce:   t.env.StateDB.GetNonce(addr),
+		Code:    t.env.StateDB.GetCode(addr),
+		Storage: make(map[common.Hash]common.Hash),
+	}
+}
+
+// lookupStorage fetches the value of the storage slot key of addr, unless it
+// was already fetched.
+func (t *prestateTracer) lookupStorage(addr common.Address, key common.Hash) {
+	t.lookupAccount(addr)
+	if _, ok := t.pre[addr].Storage[key]; ok {
+		return
+	}
+	t.pre[addr].Storage[key] = t.env.StateDB.GetState(addr, key)
+}
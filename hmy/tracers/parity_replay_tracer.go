@@ -0,0 +1,289 @@
+package tracers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+// Parity trace types, as requested by trace_call, trace_callMany and
+// trace_replayBlockTransactions.
+const (
+	TraceTypeTrace     = "trace"
+	TraceTypeVMTrace   = "vmTrace"
+	TraceTypeStateDiff = "stateDiff"
+)
+
+// ParityReplayConfig is the config of a ParityReplayTracer.
+type ParityReplayConfig struct {
+	TraceTypes []string `json:"traceTypes"`
+}
+
+// ParityReplayResult is the parity result of replaying a transaction, in
+// which the trace types that were not requested are nil.
+type ParityReplayResult struct {
+	Output          hexutil.Bytes                        `json:"output"`
+	StateDiff       map[common.Address]*StateDiffAccount `json:"stateDiff"`
+	Trace           []json.RawMessage                    `json:"trace"`
+	VMTrace         *VMTrace                             `json:"vmTrace"`
+	TransactionHash *common.Hash                         `json:"transactionHash,omitempty"`
+}
+
+// StateDiffAccount is the parity stateDiff of an account. Every field is
+// either "=" if unchanged, {"+": value} if the account was created,
+// {"-": value} if it was destroyed or {"*": {"from": value, "to": value}}.
+type StateDiffAccount struct {
+	Balance interface{}                 `json:"balance"`
+	Code    interface{}                 `json:"code"`
+	Nonce   interface{}                 `json:"nonce"`
+	Storage map[common.Hash]interface{} `json:"storage"`
+}
+
+// StateDiffTracer is the tracer of the pre and post states of a transaction,
+// as the native prestate tracer in diff mode.
+type StateDiffTracer interface {
+	vm.Tracer
+	GetResult() (json.RawMessage, error)
+}
+
+// ParityReplayTracer runs the tracers of the requested parity trace types:
+// a ParityBlockTracer for trace, a ParityVMTracer for vmTrace and a
+// StateDiffTracer for stateDiff.
+type ParityReplayTracer struct {
+	trace     *ParityBlockTracer
+	vmTrace   *ParityVMTracer
+	stateDiff StateDiffTracer
+	tracers   []vm.Tracer
+
+	output []byte
+	txHash common.Hash
+}
+
+// NewParityReplayTracer returns a tracer for the trace types of the JSON
+// encoded ParityReplayConfig, building the StateDiffTracer with newStateDiff
+// if stateDiff is requested.
+func NewParityReplayTracer(config json.RawMessage, newStateDiff func() (StateDiffTracer, error)) (*ParityReplayTracer, error) {
+	var cfg ParityReplayConfig
+	if len(config) > 0 {
+		if err := json.Unmarshal(config, &cfg); err != nil {
+			return nil, err
+		}
+	}
+	t := &ParityReplayTracer{}
+	for _, typ := range cfg.TraceTypes {
+		switch typ {
+		case TraceTypeTrace:
+			if t.trace == nil {
+				t.trace = &ParityBlockTracer{}
+				t.tracers = append(t.tracers, t.trace)
+			}
+		case TraceTypeVMTrace:
+			if t.vmTrace == nil {
+				t.vmTrace = &ParityVMTracer{}
+				t.tracers = append(t.tracers, t.vmTrace)
+			}
+		case TraceTypeStateDiff:
+			if t.stateDiff == nil {
+				stateDiff, err := newStateDiff()
+				if err != nil {
+					return nil, err
+				}
+				t.stateDiff = stateDiff
+				t.tracers = append(t.tracers, t.stateDiff)
+			}
+		default:
+			return nil, fmt.Errorf("unknown trace type %q", typ)
+		}
+	}
+	return t, nil
+}
+
+// CaptureTxStart implements the vm.TxTracer interface.
+func (t *ParityReplayTracer) CaptureTxStart(gasLimit uint64) {
+	if tracer, ok := t.stateDiff.(vm.TxTracer); ok {
+		tracer.CaptureTxStart(gasLimit)
+	}
+}
+
+// CaptureTxEnd implements the vm.TxTracer interface.
+func (t *ParityReplayTracer) CaptureTxEnd(restGas uint64) {
+	if tracer, ok := t.stateDiff.(vm.TxTracer); ok {
+		tracer.CaptureTxEnd(restGas)
+	}
+}
+
+// CaptureStart implements the ParityReplayTracer interface to initialize the tracing operation.
+func (t *ParityReplayTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.txHash = env.StateDB.TxHashETH()
+	for _, tracer := range t.tracers {
+		if err := tracer.CaptureStart(env, from, to, create, input, gas, value); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CaptureState implements the ParityReplayTracer interface to trace a single step of VM execution.
+func (t *ParityReplayTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) (vm.HookAfter, error) {
+	var hook vm.HookAfter
+	for _, tracer := range t.tracers {
+		after, captureErr := tracer.CaptureState(env, pc, op, gas, cost, memory, stack, contract, depth, err)
+		if captureErr != nil {
+			return nil, captureErr
+		}
+		// Only the ParityVMTracer has something to do after the op
+		if after != nil {
+			hook = after
+		}
+	}
+	return hook, nil
+}
+
+// CaptureFault implements the ParityReplayTracer interface to trace an execution fault
+// while running an opcode.
+func (t *ParityReplayTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	for _, tracer := range t.tracers {
+		if captureErr := tracer.CaptureFault(env, pc, op, gas, cost, memory, stack, contract, depth, err); captureErr != nil {
+			return captureErr
+		}
+	}
+	return nil
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *ParityReplayTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	t.output = common.CopyBytes(output)
+	for _, tracer := range t.tracers {
+		if captureErr := tracer.CaptureEnd(output, gasUsed, d, err); captureErr != nil {
+			return captureErr
+		}
+	}
+	return nil
+}
+
+// GetResult returns the output of the transaction and the requested traces.
+func (t *ParityReplayTracer) GetResult() (*ParityReplayResult, error) {
+	result := &ParityReplayResult{Output: t.output}
+	if t.txHash != (common.Hash{}) {
+		result.TransactionHash = &t.txHash
+	}
+	var err error
+	if t.trace != nil {
+		if result.Trace, err = t.trace.GetTraces(); err != nil {
+			return nil, err
+		}
+	}
+	if t.vmTrace != nil {
+		if result.VMTrace, err = t.vmTrace.GetResult(); err != nil {
+			return nil, err
+		}
+	}
+	if t.stateDiff != nil {
+		raw, err := t.stateDiff.GetResult()
+		if err != nil {
+			return nil, err
+		}
+		if result.StateDiff, err = toStateDiff(raw); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// prestateAccount is an account of the prestate tracer result, in which the
+// fields are nil if unchanged.
+type prestateAccount struct {
+	Balance *hexutil.Big                `json:"balance"`
+	Code    *hexutil.Bytes              `json:"code"`
+	Nonce   *uint64                     `json:"nonce"`
+	Storage map[common.Hash]common.Hash `json:"storage"`
+}
+
+// toStateDiff converts the pre and post states of the prestate tracer in diff
+// mode into a parity stateDiff.
+func toStateDiff(raw json.RawMessage) (map[common.Address]*StateDiffAccount, error) {
+	var prestate struct {
+		Pre  map[common.Address]*prestateAccount `json:"pre"`
+		Post map[common.Address]*prestateAccount `json:"post"`
+	}
+	if err := json.Unmarshal(raw, &prestate); err != nil {
+		return nil, err
+	}
+	stateDiff := make(map[common.Address]*StateDiffAccount)
+	for addr, pre := range prestate.Pre {
+		post, ok := prestate.Post[addr]
+		if !ok {
+			stateDiff[addr] = accountDiff("-", pre)
+			continue
+		}
+		diff := &StateDiffAccount{
+			Balance: fieldDiff(pre.balance(), post.Balance != nil, post.balance()),
+			Code:    fieldDiff(pre.code(), post.Code != nil, post.code()),
+			Nonce:   fieldDiff(pre.nonce(), post.Nonce != nil, post.nonce()),
+			Storage: make(map[common.Hash]interface{}),
+		}
+		for key, from := range pre.Storage {
+			diff.Storage[key] = map[string]interface{}{"*": map[string]interface{}{"from": from, "to": post.Storage[key]}}
+		}
+		for key, to := range post.Storage {
+			diff.Storage[key] = map[string]interface{}{"*": map[string]interface{}{"from": pre.Storage[key], "to": to}}
+		}
+		stateDiff[addr] = diff
+	}
+	for addr, post := range prestate.Post {
+		if _, ok := prestate.Pre[addr]; !ok {
+			stateDiff[addr] = accountDiff("+", post)
+		}
+	}
+	return stateDiff, nil
+}
+
+// fieldDiff returns the parity diff of a field changed from from to to, or
+// "=" if it did not change.
+func fieldDiff(from interface{}, changed bool, to interface{}) interface{} {
+	if !changed {
+		return "="
+	}
+	return map[string]interface{}{"*": map[string]interface{}{"from": from, "to": to}}
+}
+
+// accountDiff returns the parity diff of a created or destroyed account,
+// depending on sign.
+func accountDiff(sign string, account *prestateAccount) *StateDiffAccount {
+	diff := &StateDiffAccount{
+		Balance: map[string]interface{}{sign: account.balance()},
+		Code:    map[string]interface{}{sign: account.code()},
+		Nonce:   map[string]interface{}{sign: account.nonce()},
+		Storage: make(map[common.Hash]interface{}),
+	}
+	for key, value := range account.Storage {
+		diff.Storage[key] = map[string]interface{}{sign: value}
+	}
+	return diff
+}
+
+func (a *prestateAccount) balance() *hexutil.Big {
+	if a.Balance == nil {
+		return new(hexutil.Big)
+	}
+	return a.Balance
+}
+
+func (a *prestateAccount) code() hexutil.Bytes {
+	if a.Code == nil {
+		return hexutil.Bytes{}
+	}
+	return *a.Code
+}
+
+func (a *prestateAccount) nonce() hexutil.Uint64 {
+	if a.Nonce == nil {
+		return 0
+	}
+	return hexutil.Uint64(*a.Nonce)
+}
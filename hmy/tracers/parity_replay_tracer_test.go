@@ -0,0 +1,68 @@
+package tracers
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+func TestToStateDiff(t *testing.T) {
+	var (
+		changed = common.HexToAddress("0x01")
+		created = common.HexToAddress("0x02")
+		slot    = common.HexToHash("0x01")
+	)
+	raw := json.RawMessage(`{
+		"pre": {"0x0000000000000000000000000000000000000001": {"balance": "0x10", "nonce": 1, "storage": {"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002"}}},
+		"post": {
+			"0x0000000000000000000000000000000000000001": {"balance": "0x8", "storage": {"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000003"}},
+			"0x0000000000000000000000000000000000000002": {"balance": "0x8", "code": "0x00", "nonce": 1}
+		}
+	}`)
+	diff, err := toStateDiff(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	have, _ := json.Marshal(diff[changed])
+	want := `{"balance":{"*":{"from":"0x10","to":"0x8"}},"code":"=","nonce":"=","storage":{"0x0000000000000000000000000000000000000000000000000000000000000001":{"*":{"from":"0x0000000000000000000000000000000000000000000000000000000000000002","to":"0x0000000000000000000000000000000000000000000000000000000000000003"}}}}`
+	if string(have) != want {
+		t.Errorf("wrong diff of the changed account\nhave %s\nwant %s", have, want)
+	}
+	have, _ = json.Marshal(diff[created])
+	want = `{"balance":{"+":"0x8"},"code":{"+":"0x00"},"nonce":{"+":"0x1"},"storage":{}}`
+	if string(have) != want {
+		t.Errorf("wrong diff of the created account\nhave %s\nwant %s", have, want)
+	}
+	if len(diff[changed].Storage) != 1 || diff[changed].Storage[slot] == nil {
+		t.Errorf("wrong storage diff: %v", diff[changed].Storage)
+	}
+}
+
+func TestNewParityReplayTracer(t *testing.T) {
+	built := 0
+	newStateDiff := func() (StateDiffTracer, error) {
+		built++
+		return &stateDiffStub{}, nil
+	}
+	tracer, err := NewParityReplayTracer(json.RawMessage(`{"traceTypes": ["trace", "stateDiff", "stateDiff"]}`), newStateDiff)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if built != 1 || len(tracer.tracers) != 2 || tracer.trace == nil || tracer.vmTrace != nil {
+		t.Errorf("unexpected tracers %+v, %v state diff tracers", tracer, built)
+	}
+	if _, err := NewParityReplayTracer(json.RawMessage(`{"traceTypes": ["stateDiffs"]}`), newStateDiff); err == nil {
+		t.Error("expected error for an unknown trace type")
+	}
+}
+
+// stateDiffStub is a StateDiffTracer recording nothing.
+type stateDiffStub struct {
+	vm.Tracer
+}
+
+func (s *stateDiffStub) GetResult() (json.RawMessage, error) {
+	return json.RawMessage(`{"pre": {}, "post": {}}`), nil
+}
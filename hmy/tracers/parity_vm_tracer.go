@@ -0,0 +1,174 @@
+package tracers
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/core/vm"
+)
+
+// VMTrace is the parity vmTrace of a call frame, the ops it executed
+// along with the frames of the calls they made.
+type VMTrace struct {
+	Code hexutil.Bytes  `json:"code"`
+	Ops  []*VMOperation `json:"ops"`
+}
+
+// VMOperation is a single op of a VMTrace.
+type VMOperation struct {
+	Cost uint64      `json:"cost"`
+	Ex   *VMExecuted `json:"ex"`
+	Pc   uint64      `json:"pc"`
+	Sub  *VMTrace    `json:"sub"`
+}
+
+// VMExecuted holds the effects of an op, nil if the op failed.
+type VMExecuted struct {
+	Mem   *VMMemoryDiff  `json:"mem"`
+	Push  []*hexutil.Big `json:"push"`
+	Store *VMStorageDiff `json:"store"`
+	Used  uint64         `json:"used"`
+}
+
+// VMMemoryDiff is the memory written by an op.
+type VMMemoryDiff struct {
+	Off  uint64        `json:"off"`
+	Data hexutil.Bytes `json:"data"`
+}
+
+// VMStorageDiff is the storage slot written by an op.
+type VMStorageDiff struct {
+	Key *hexutil.Big `json:"key"`
+	Val *hexutil.Big `json:"val"`
+}
+
+// ParityVMTracer builds the parity vmTrace of a transaction.
+type ParityVMTracer struct {
+	root   *VMTrace
+	frames []*VMTrace
+}
+
+// pushCount returns the number of stack items reported as pushed by op. DUP
+// and SWAP report all the items they touched, as parity does.
+func pushCount(op vm.OpCode) int {
+	switch {
+	case op == vm.PUSH0 || op.IsPush():
+		return 1
+	case op >= vm.DUP1 && op <= vm.DUP16:
+		return int(op-vm.DUP1) + 2
+	case op >= vm.SWAP1 && op <= vm.SWAP16:
+		return int(op-vm.SWAP1) + 2
+	case op >= vm.LOG0 && op <= vm.LOG4:
+		return 0
+	}
+	switch op {
+	case vm.STOP, vm.POP, vm.MSTORE, vm.MSTORE8, vm.SSTORE, vm.TSTORE, vm.JUMP, vm.JUMPI, vm.JUMPDEST,
+		vm.CALLDATACOPY, vm.CODECOPY, vm.EXTCODECOPY, vm.RETURNDATACOPY, vm.MCOPY,
+		vm.RETURN, vm.REVERT, vm.SELFDESTRUCT:
+		return 0
+	}
+	return 1
+}
+
+// memoryWritten returns the stack positions of the offset and the size of the
+// memory written by op, or false if op does not write memory.
+func memoryWritten(op vm.OpCode) (int, int, bool) {
+	switch op {
+	case vm.CALLDATACOPY, vm.CODECOPY, vm.RETURNDATACOPY, vm.MCOPY:
+		return 0, 2, true
+	case vm.EXTCODECOPY:
+		return 1, 3, true
+	case vm.CALL, vm.CALLCODE:
+		return 5, 6, true
+	case vm.DELEGATECALL, vm.STATICCALL:
+		return 4, 5, true
+	}
+	return 0, 0, false
+}
+
+// CaptureStart implements the ParityVMTracer interface to initialize the tracing operation.
+func (t *ParityVMTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	code := input
+	if !create {
+		code = env.StateDB.GetCode(to)
+	}
+	t.root = &VMTrace{Code: common.CopyBytes(code), Ops: []*VMOperation{}}
+	t.frames = []*VMTrace{t.root}
+	return nil
+}
+
+// CaptureState implements the ParityVMTracer interface to trace a single step of VM execution.
+func (t *ParityVMTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) (vm.HookAfter, error) {
+	// The outermost frame is at depth 1, a deeper op starts the frame of the
+	// call made by the last op of its parent frame
+	for depth < len(t.frames) && len(t.frames) > 1 {
+		t.frames = t.frames[:len(t.frames)-1]
+	}
+	if depth > len(t.frames) {
+		parent := t.frames[len(t.frames)-1]
+		sub := &VMTrace{Code: common.CopyBytes(contract.Code), Ops: []*VMOperation{}}
+		if n := len(parent.Ops); n > 0 {
+			parent.Ops[n-1].Sub = sub
+		}
+		t.frames = append(t.frames, sub)
+	}
+	frame := t.frames[len(t.frames)-1]
+	operation := &VMOperation{Pc: pc, Cost: cost}
+	frame.Ops = append(frame.Ops, operation)
+	if err != nil {
+		return nil, nil
+	}
+
+	// Collect what the op is going to write before it runs, and the rest once
+	// it has run
+	var (
+		memOff, memSize int64
+		store           *VMStorageDiff
+	)
+	switch op {
+	case vm.MSTORE:
+		memOff, memSize = stack.Back(0).Int64(), 32
+	case vm.MSTORE8:
+		memOff, memSize = stack.Back(0).Int64(), 1
+	case vm.SSTORE:
+		store = &VMStorageDiff{
+			Key: (*hexutil.Big)(new(big.Int).Set(stack.Back(0))),
+			Val: (*hexutil.Big)(new(big.Int).Set(stack.Back(1))),
+		}
+	default:
+		if off, size, ok := memoryWritten(op); ok {
+			memOff, memSize = stack.Back(off).Int64(), stack.Back(size).Int64()
+		}
+	}
+	pushes := pushCount(op)
+	return func(memory *vm.Memory, stack *vm.Stack) {
+		ex := &VMExecuted{Push: []*hexutil.Big{}, Store: store, Used: contract.Gas}
+		if len(stack.Data()) >= pushes {
+			for i := pushes - 1; i >= 0; i-- {
+				ex.Push = append(ex.Push, (*hexutil.Big)(new(big.Int).Set(stack.Back(i))))
+			}
+		}
+		if memSize > 0 && int64(memory.Len()) >= memOff+memSize {
+			ex.Mem = &VMMemoryDiff{Off: uint64(memOff), Data: memory.GetCopy(memOff, memSize)}
+		}
+		operation.Ex = ex
+	}, nil
+}
+
+// CaptureFault implements the ParityVMTracer interface to trace an execution fault
+// while running an opcode.
+func (t *ParityVMTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd is called after the call finishes to finalize the tracing.
+func (t *ParityVMTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) error {
+	return nil
+}
+
+// GetResult returns the vmTrace of the outermost call frame.
+func (t *ParityVMTracer) GetResult() (*VMTrace, error) {
+	return t.root, nil
+}
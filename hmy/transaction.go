@@ -8,6 +8,7 @@ import (
 	"github.com/harmony-one/harmony/core/rawdb"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy/tracers"
 )
 
 // SendTx ...
@@ -59,6 +60,11 @@ func (hmy *Harmony) GetTransactionsHistory(address, txType, order string) ([]com
 	return hmy.NodeAPI.GetTransactionsHistory(address, txType, order)
 }
 
+// GetInternalTransactionsHistory returns a page of the list of internal transactions of address.
+func (hmy *Harmony) GetInternalTransactionsHistory(address, txType, order string, page, size int) ([]*tracers.InternalTransaction, error) {
+	return hmy.NodeAPI.GetInternalTransactionsHistory(address, txType, order, page, size)
+}
+
 // GetAccountNonce returns the nonce value of the given address for the given block number
 func (hmy *Harmony) GetAccountNonce(
 	ctx context.Context, address common.Address, blockNum rpc.BlockNumber) (uint64, error) {
@@ -0,0 +1,187 @@
+package hmyclient
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/accounts/abi/bind"
+	"github.com/harmony-one/harmony/core/types"
+)
+
+// filterNamespace is the namespace of the filter API, which has no hmyv2
+// version.
+const filterNamespace = "hmy"
+
+var (
+	_ bind.ContractBackend = (*Client)(nil)
+	_ bind.DeployBackend   = (*Client)(nil)
+)
+
+// CodeAt returns the contract code of the given account at the given block
+// number. The latest code is returned if blockNumber is nil.
+func (hc *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	var result hexutil.Bytes
+	err := hc.c.CallContext(ctx, &result, "hmyv2_getCode", account.Hex(), toBlockNumOrHashArg(blockNumber))
+	return result, err
+}
+
+// CallContract executes a message call transaction, which is directly
+// executed in the VM of the node, but never mined into the blockchain. The
+// call is executed on the latest state if blockNumber is nil.
+func (hc *Client) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	var hex hexutil.Bytes
+	err := hc.c.CallContext(ctx, &hex, "hmyv2_call", toCallArg(msg), toBlockNumOrHashArg(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+	return hex, nil
+}
+
+// PendingCodeAt returns the contract code of the given account in the
+// pending state.
+func (hc *Client) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	var result hexutil.Bytes
+	err := hc.c.CallContext(ctx, &result, "hmyv2_getCode", account.Hex(), "pending")
+	return result, err
+}
+
+// PendingNonceAt returns the account nonce of the given account in the
+// pending state. This is the nonce that should be used for the next
+// transaction.
+func (hc *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	var result uint64
+	err := hc.c.CallContext(ctx, &result, "hmyv2_getTransactionCount", account.Hex(), "pending")
+	return result, err
+}
+
+// SuggestGasPrice retrieves the currently suggested gas price to allow a
+// timely execution of a transaction.
+func (hc *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	var result uint64
+	if err := hc.c.CallContext(ctx, &result, "hmyv2_gasPrice"); err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetUint64(result), nil
+}
+
+// EstimateGas tries to estimate the gas needed to execute a specific
+// transaction based on the current pending state of the backend blockchain.
+func (hc *Client) EstimateGas(ctx context.Context, msg ethereum.CallMsg) (uint64, error) {
+	var hex hexutil.Uint64
+	err := hc.c.CallContext(ctx, &hex, "hmyv2_estimateGas", toCallArg(msg))
+	if err != nil {
+		return 0, err
+	}
+	return uint64(hex), nil
+}
+
+// SendTransaction injects the signed plain transaction into the pending pool
+// for execution.
+func (hc *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	data, err := tx.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	var hash common.Hash
+	return hc.c.CallContext(ctx, &hash, "hmyv2_sendRawTransaction", hexutil.Bytes(data))
+}
+
+// TransactionReceipt returns the receipt of the plain transaction with the
+// given hash, ethereum.NotFound if the transaction is not mined yet.
+func (hc *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	r, err := hc.TxReceipt(ctx, txHash)
+	if err != nil {
+		return nil, err
+	}
+	receipt := &types.Receipt{
+		Type:              r.Type,
+		PostState:         r.Root,
+		Status:            uint64(r.Status),
+		CumulativeGasUsed: r.CumulativeGasUsed,
+		Bloom:             r.LogsBloom,
+		Logs:              r.Logs,
+		TxHash:            r.TransactionHash,
+		ContractAddress:   r.ContractAddress,
+		GasUsed:           r.GasUsed,
+	}
+	if receipt.Logs == nil {
+		receipt.Logs = []*types.Log{}
+	}
+	return receipt, nil
+}
+
+// FilterLogs executes a filter query.
+func (hc *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	var result []types.Log
+	arg, err := toFilterArg(q)
+	if err != nil {
+		return nil, err
+	}
+	err = hc.c.CallContext(ctx, &result, filterNamespace+"_getLogs", arg)
+	return result, err
+}
+
+// SubscribeFilterLogs subscribes to the results of a streaming filter query.
+func (hc *Client) SubscribeFilterLogs(
+	ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log,
+) (ethereum.Subscription, error) {
+	arg, err := toFilterArg(q)
+	if err != nil {
+		return nil, err
+	}
+	return hc.c.Subscribe(ctx, filterNamespace, ch, "logs", arg)
+}
+
+func toFilterArg(q ethereum.FilterQuery) (interface{}, error) {
+	arg := map[string]interface{}{
+		"address": q.Addresses,
+		"topics":  q.Topics,
+	}
+	if q.BlockHash != nil {
+		arg["blockHash"] = *q.BlockHash
+		if q.FromBlock != nil || q.ToBlock != nil {
+			return nil, fmt.Errorf("cannot specify both BlockHash and FromBlock/ToBlock")
+		}
+	} else {
+		if q.FromBlock == nil {
+			arg["fromBlock"] = "0x0"
+		} else {
+			arg["fromBlock"] = toBlockNumOrHashArg(q.FromBlock)
+		}
+		arg["toBlock"] = toBlockNumOrHashArg(q.ToBlock)
+	}
+	return arg, nil
+}
+
+func toCallArg(msg ethereum.CallMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"to":   msg.To,
+	}
+	if len(msg.Data) > 0 {
+		arg["data"] = hexutil.Bytes(msg.Data)
+	}
+	if msg.Value != nil {
+		arg["value"] = (*hexutil.Big)(msg.Value)
+	}
+	if msg.Gas != 0 {
+		arg["gas"] = hexutil.Uint64(msg.Gas)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	if msg.GasFeeCap != nil {
+		arg["maxFeePerGas"] = (*hexutil.Big)(msg.GasFeeCap)
+	}
+	if msg.GasTipCap != nil {
+		arg["maxPriorityFeePerGas"] = (*hexutil.Big)(msg.GasTipCap)
+	}
+	if msg.AccessList != nil {
+		arg["accessList"] = msg.AccessList
+	}
+	return arg
+}
@@ -0,0 +1,434 @@
+// Package hmyclient provides a client for the Harmony RPC API, with typed
+// wrappers of the hmyv2 blockchain, transaction, pool and staking methods and
+// of the log and head subscriptions.
+package hmyclient
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/harmony-one/harmony/eth/rpc"
+	rpc_common "github.com/harmony-one/harmony/rpc/common"
+	v2 "github.com/harmony-one/harmony/rpc/v2"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+// Client defines typed wrappers for the Harmony RPC API.
+type Client struct {
+	c *rpc.Client
+}
+
+// Dial connects a client to the given URL.
+func Dial(rawurl string) (*Client, error) {
+	return DialContext(context.Background(), rawurl)
+}
+
+// DialContext connects a client to the given URL with the given context.
+func DialContext(ctx context.Context, rawurl string) (*Client, error) {
+	c, err := rpc.DialContext(ctx, rawurl)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(c), nil
+}
+
+// NewClient creates a client that uses the given RPC client.
+func NewClient(c *rpc.Client) *Client {
+	return &Client{c}
+}
+
+// Close closes the underlying RPC connection.
+func (hc *Client) Close() {
+	hc.c.Close()
+}
+
+// Client returns the underlying RPC client, for the methods without a
+// typed wrapper.
+func (hc *Client) Client() *rpc.Client {
+	return hc.c
+}
+
+// Blockchain Access
+
+// ChainID retrieves the chain ID for transaction replay protection.
+func (hc *Client) ChainID(ctx context.Context) (*big.Int, error) {
+	var result *big.Int
+	if err := hc.c.CallContext(ctx, &result, "hmyv2_chainId"); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ShardID returns the shard of the node.
+func (hc *Client) ShardID(ctx context.Context) (uint32, error) {
+	var result uint32
+	err := hc.c.CallContext(ctx, &result, "hmyv2_getShardID")
+	return result, err
+}
+
+// BlockNumber returns the most recent block number.
+func (hc *Client) BlockNumber(ctx context.Context) (uint64, error) {
+	var result uint64
+	err := hc.c.CallContext(ctx, &result, "hmyv2_blockNumber")
+	return result, err
+}
+
+// Epoch returns the epoch of the most recent block.
+func (hc *Client) Epoch(ctx context.Context) (uint64, error) {
+	var result uint64
+	err := hc.c.CallContext(ctx, &result, "hmyv2_getEpoch")
+	return result, err
+}
+
+// EpochLastBlock returns the number of the last block of the epoch.
+func (hc *Client) EpochLastBlock(ctx context.Context, epoch uint64) (uint64, error) {
+	var result uint64
+	err := hc.c.CallContext(ctx, &result, "hmyv2_epochLastBlock", epoch)
+	return result, err
+}
+
+// BlockByNumber returns the block with its plain and staking transactions in
+// full. The latest block is returned if number is nil.
+func (hc *Client) BlockByNumber(ctx context.Context, number *big.Int) (*v2.BlockWithFullTx, error) {
+	var block *v2.BlockWithFullTx
+	err := hc.c.CallContext(ctx, &block, "hmyv2_getBlockByNumber", toBlockNumArg(number), fullBlockArgs)
+	return block, notFoundIfNil(block == nil, err)
+}
+
+// BlockByHash returns the block with its plain and staking transactions in
+// full.
+func (hc *Client) BlockByHash(ctx context.Context, hash common.Hash) (*v2.BlockWithFullTx, error) {
+	var block *v2.BlockWithFullTx
+	err := hc.c.CallContext(ctx, &block, "hmyv2_getBlockByHash", hash, fullBlockArgs)
+	return block, notFoundIfNil(block == nil, err)
+}
+
+// BlockWithTxHashesByNumber returns the block with the hashes of its
+// transactions only. The latest block is returned if number is nil.
+func (hc *Client) BlockWithTxHashesByNumber(ctx context.Context, number *big.Int) (*v2.BlockWithTxHash, error) {
+	var block *v2.BlockWithTxHash
+	err := hc.c.CallContext(ctx, &block, "hmyv2_getBlockByNumber", toBlockNumArg(number), hashBlockArgs)
+	return block, notFoundIfNil(block == nil, err)
+}
+
+// BlocksByRange returns the blocks from start to end included, with their
+// plain and staking transactions in full.
+func (hc *Client) BlocksByRange(ctx context.Context, start, end *big.Int) ([]*v2.BlockWithFullTx, error) {
+	var blocks []*v2.BlockWithFullTx
+	err := hc.c.CallContext(ctx, &blocks, "hmyv2_getBlocks", toBlockNumArg(start), toBlockNumArg(end), fullBlockArgs)
+	return blocks, err
+}
+
+// BlockReceipts returns the receipts of the plain transactions of the block.
+func (hc *Client) BlockReceipts(ctx context.Context, hash common.Hash) ([]*v2.TxReceipt, error) {
+	var receipts []*v2.TxReceipt
+	err := hc.c.CallContext(ctx, &receipts, "hmyv2_getBlockReceipts", hash)
+	return receipts, err
+}
+
+// BlockSigners returns the one addresses of the validators who signed the
+// block.
+func (hc *Client) BlockSigners(ctx context.Context, number *big.Int) ([]string, error) {
+	var signers []string
+	err := hc.c.CallContext(ctx, &signers, "hmyv2_getBlockSigners", toBlockNumArg(number))
+	return signers, err
+}
+
+// BalanceAt returns the balance in Atto of the account at the given block
+// number. The latest balance is returned if blockNumber is nil.
+func (hc *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var result *big.Int
+	if err := hc.c.CallContext(ctx, &result, "hmyv2_getBalanceByBlockNumber", account.Hex(), toBlockNumArg(blockNumber)); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// StorageAt returns the value of key in the contract storage of the given
+// account at the given block number. The latest value is returned if
+// blockNumber is nil.
+func (hc *Client) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	var result hexutil.Bytes
+	err := hc.c.CallContext(ctx, &result, "hmyv2_getStorageAt", account.Hex(), key.Hex(), toBlockNumOrHashArg(blockNumber))
+	return result, err
+}
+
+// InSync returns whether the shard chain of the node is in sync.
+func (hc *Client) InSync(ctx context.Context) (bool, error) {
+	var result bool
+	err := hc.c.CallContext(ctx, &result, "hmyv2_inSync")
+	return result, err
+}
+
+// BeaconInSync returns whether the beacon chain of the node is in sync.
+func (hc *Client) BeaconInSync(ctx context.Context) (bool, error) {
+	var result bool
+	err := hc.c.CallContext(ctx, &result, "hmyv2_beaconInSync")
+	return result, err
+}
+
+// Transactions
+
+// NonceAt returns the nonce of the account at the given block number. The
+// latest nonce is returned if blockNumber is nil.
+func (hc *Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	var result uint64
+	err := hc.c.CallContext(ctx, &result, "hmyv2_getAccountNonce", account.Hex(), toBlockNumArg(blockNumber))
+	return result, err
+}
+
+// TransactionByHash returns the plain transaction with the given hash.
+func (hc *Client) TransactionByHash(ctx context.Context, hash common.Hash) (*v2.Transaction, error) {
+	var tx *v2.Transaction
+	err := hc.c.CallContext(ctx, &tx, "hmyv2_getTransactionByHash", hash)
+	return tx, notFoundIfNil(tx == nil, err)
+}
+
+// StakingTransactionByHash returns the staking transaction with the given
+// hash.
+func (hc *Client) StakingTransactionByHash(ctx context.Context, hash common.Hash) (*v2.StakingTransaction, error) {
+	var tx *v2.StakingTransaction
+	err := hc.c.CallContext(ctx, &tx, "hmyv2_getStakingTransactionByHash", hash)
+	return tx, notFoundIfNil(tx == nil, err)
+}
+
+// TransactionInBlock returns the plain transaction at the given index of the
+// block.
+func (hc *Client) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*v2.Transaction, error) {
+	var tx *v2.Transaction
+	err := hc.c.CallContext(ctx, &tx, "hmyv2_getTransactionByBlockHashAndIndex", blockHash, index)
+	return tx, notFoundIfNil(tx == nil, err)
+}
+
+// TransactionCount returns the number of plain transactions of the block.
+func (hc *Client) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	var result uint
+	err := hc.c.CallContext(ctx, &result, "hmyv2_getBlockTransactionCountByHash", blockHash)
+	return result, err
+}
+
+// TxReceipt returns the receipt of the plain transaction with the given hash.
+func (hc *Client) TxReceipt(ctx context.Context, hash common.Hash) (*v2.TxReceipt, error) {
+	var receipt *v2.TxReceipt
+	err := hc.c.CallContext(ctx, &receipt, "hmyv2_getTransactionReceipt", hash)
+	return receipt, notFoundIfNil(receipt == nil, err)
+}
+
+// StakingTxReceipt returns the receipt of the staking transaction with the
+// given hash.
+func (hc *Client) StakingTxReceipt(ctx context.Context, hash common.Hash) (*v2.StakingTxReceipt, error) {
+	var receipt *v2.StakingTxReceipt
+	err := hc.c.CallContext(ctx, &receipt, "hmyv2_getTransactionReceipt", hash)
+	return receipt, notFoundIfNil(receipt == nil, err)
+}
+
+// CXReceiptByHash returns the cross shard receipt of the transaction with
+// the given hash.
+func (hc *Client) CXReceiptByHash(ctx context.Context, hash common.Hash) (*v2.CxReceipt, error) {
+	var receipt *v2.CxReceipt
+	err := hc.c.CallContext(ctx, &receipt, "hmyv2_getCXReceiptByHash", hash)
+	return receipt, notFoundIfNil(receipt == nil, err)
+}
+
+// TransactionsHistory returns the hashes of the plain transactions of an
+// address, from the explorer of the node.
+func (hc *Client) TransactionsHistory(ctx context.Context, args TxHistoryArgs) ([]common.Hash, error) {
+	args.FullTx = false
+	var result struct {
+		Transactions []common.Hash `json:"transactions"`
+	}
+	err := hc.c.CallContext(ctx, &result, "hmyv2_getTransactionsHistory", args)
+	return result.Transactions, err
+}
+
+// StakingTransactionsHistory returns the hashes of the staking transactions
+// of an address, from the explorer of the node.
+func (hc *Client) StakingTransactionsHistory(ctx context.Context, args TxHistoryArgs) ([]common.Hash, error) {
+	args.FullTx = false
+	var result struct {
+		StakingTransactions []common.Hash `json:"staking_transactions"`
+	}
+	err := hc.c.CallContext(ctx, &result, "hmyv2_getStakingTransactionsHistory", args)
+	return result.StakingTransactions, err
+}
+
+// InternalTransactionsHistory returns the internal transactions of an
+// address, from the explorer of the node.
+func (hc *Client) InternalTransactionsHistory(ctx context.Context, args TxHistoryArgs) ([]*v2.InternalTransaction, error) {
+	var result struct {
+		Transactions []*v2.InternalTransaction `json:"transactions"`
+	}
+	err := hc.c.CallContext(ctx, &result, "hmyv2_getInternalTransactionsHistory", args)
+	return result.Transactions, err
+}
+
+// Pool
+
+// SendStakingTransaction injects the signed staking transaction into the
+// pending pool for execution.
+func (hc *Client) SendStakingTransaction(ctx context.Context, tx *staking.StakingTransaction) error {
+	data, err := rlp.EncodeToBytes(tx)
+	if err != nil {
+		return err
+	}
+	var hash common.Hash
+	return hc.c.CallContext(ctx, &hash, "hmyv2_sendRawStakingTransaction", hexutil.Bytes(data))
+}
+
+// PendingTransactions returns the plain transactions of the pending pool.
+func (hc *Client) PendingTransactions(ctx context.Context) ([]*v2.Transaction, error) {
+	var txs []*v2.Transaction
+	err := hc.c.CallContext(ctx, &txs, "hmyv2_pendingTransactions")
+	return txs, err
+}
+
+// PendingStakingTransactions returns the staking transactions of the pending
+// pool.
+func (hc *Client) PendingStakingTransactions(ctx context.Context) ([]*v2.StakingTransaction, error) {
+	var txs []*v2.StakingTransaction
+	err := hc.c.CallContext(ctx, &txs, "hmyv2_pendingStakingTransactions")
+	return txs, err
+}
+
+// PoolStats returns the number of executable and non executable
+// transactions of the pending pool.
+func (hc *Client) PoolStats(ctx context.Context) (*PoolStats, error) {
+	var stats PoolStats
+	if err := hc.c.CallContext(ctx, &stats, "hmyv2_getPoolStats"); err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
+
+// NumPendingCXReceipts returns the number of cross shard receipts waiting
+// to be included.
+func (hc *Client) NumPendingCXReceipts(ctx context.Context) (int, error) {
+	var result int
+	err := hc.c.CallContext(ctx, &result, "hmyv2_getNumPendingCXReceipts")
+	return result, err
+}
+
+// Staking
+
+// TotalStaking returns the total amount staked in Atto.
+func (hc *Client) TotalStaking(ctx context.Context) (*big.Int, error) {
+	var result *big.Int
+	if err := hc.c.CallContext(ctx, &result, "hmyv2_getTotalStaking"); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ElectedValidatorAddresses returns the one addresses of the validators
+// elected in the current committee.
+func (hc *Client) ElectedValidatorAddresses(ctx context.Context) ([]string, error) {
+	var result []string
+	err := hc.c.CallContext(ctx, &result, "hmyv2_getElectedValidatorAddresses")
+	return result, err
+}
+
+// AllValidatorAddresses returns the one addresses of all the validators.
+func (hc *Client) AllValidatorAddresses(ctx context.Context) ([]string, error) {
+	var result []string
+	err := hc.c.CallContext(ctx, &result, "hmyv2_getAllValidatorAddresses")
+	return result, err
+}
+
+// ValidatorInformation returns the validator with the given address.
+func (hc *Client) ValidatorInformation(ctx context.Context, validator common.Address) (*ValidatorInformation, error) {
+	var info *ValidatorInformation
+	err := hc.c.CallContext(ctx, &info, "hmyv2_getValidatorInformation", validator.Hex())
+	return info, notFoundIfNil(info == nil, err)
+}
+
+// AllValidatorInformation returns a page of all the validators, pages are
+// of 100 validators. All the validators are returned if page is -1.
+func (hc *Client) AllValidatorInformation(ctx context.Context, page int) ([]*ValidatorInformation, error) {
+	var infos []*ValidatorInformation
+	err := hc.c.CallContext(ctx, &infos, "hmyv2_getAllValidatorInformation", page)
+	return infos, err
+}
+
+// DelegationsByDelegator returns the delegations of the delegator.
+func (hc *Client) DelegationsByDelegator(ctx context.Context, delegator common.Address) ([]*Delegation, error) {
+	var delegations []*Delegation
+	err := hc.c.CallContext(ctx, &delegations, "hmyv2_getDelegationsByDelegator", delegator.Hex())
+	return delegations, err
+}
+
+// DelegationsByValidator returns the delegations to the validator.
+func (hc *Client) DelegationsByValidator(ctx context.Context, validator common.Address) ([]*Delegation, error) {
+	var delegations []*Delegation
+	err := hc.c.CallContext(ctx, &delegations, "hmyv2_getDelegationsByValidator", validator.Hex())
+	return delegations, err
+}
+
+// DelegationByDelegatorAndValidator returns the delegation of the delegator
+// to the validator.
+func (hc *Client) DelegationByDelegatorAndValidator(
+	ctx context.Context, delegator, validator common.Address,
+) (*Delegation, error) {
+	var delegation *Delegation
+	err := hc.c.CallContext(ctx, &delegation, "hmyv2_getDelegationByDelegatorAndValidator",
+		delegator.Hex(), validator.Hex(),
+	)
+	return delegation, notFoundIfNil(delegation == nil, err)
+}
+
+// AvailableRedelegationBalance returns the undelegated amount in Atto of the
+// delegator which can be delegated again.
+func (hc *Client) AvailableRedelegationBalance(ctx context.Context, delegator common.Address) (*big.Int, error) {
+	var result *big.Int
+	if err := hc.c.CallContext(ctx, &result, "hmyv2_getAvailableRedelegationBalance", delegator.Hex()); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Subscriptions
+
+// SubscribeNewHead subscribes to the headers of the new blocks.
+func (hc *Client) SubscribeNewHead(ctx context.Context, ch chan<- *Header) (ethereum.Subscription, error) {
+	return hc.c.Subscribe(ctx, filterNamespace, ch, "newHeads")
+}
+
+// SubscribePendingTransactions subscribes to the hashes of the transactions
+// entering the pending pool.
+func (hc *Client) SubscribePendingTransactions(ctx context.Context, ch chan<- common.Hash) (ethereum.Subscription, error) {
+	return hc.c.Subscribe(ctx, filterNamespace, ch, "newPendingTransactions")
+}
+
+var (
+	fullBlockArgs = rpc_common.BlockArgs{FullTx: true, InclStaking: true}
+	hashBlockArgs = rpc_common.BlockArgs{InclStaking: true}
+)
+
+// toBlockNumArg returns the hmyv2 block number argument of number, latest if
+// number is nil.
+func toBlockNumArg(number *big.Int) interface{} {
+	if number == nil {
+		return "latest"
+	}
+	return number.Int64()
+}
+
+// toBlockNumOrHashArg returns the block number or hash argument of number,
+// latest if number is nil.
+func toBlockNumOrHashArg(number *big.Int) string {
+	if number == nil {
+		return "latest"
+	}
+	return hexutil.EncodeBig(number)
+}
+
+// notFoundIfNil returns ethereum.NotFound for a null result.
+func notFoundIfNil(isNil bool, err error) error {
+	if err == nil && isNil {
+		return ethereum.NotFound
+	}
+	return err
+}
@@ -0,0 +1,252 @@
+package hmyclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/eth/rpc"
+	v2 "github.com/harmony-one/harmony/rpc/v2"
+)
+
+var (
+	testKey, _  = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr    = crypto.PubkeyToAddress(testKey.PublicKey)
+	testChainID = big.NewInt(2)
+	testTxHash  = common.HexToHash("0x01")
+	testLog     = types.Log{
+		Address:     common.HexToAddress("0x1234"),
+		Topics:      []common.Hash{common.HexToHash("0x02")},
+		Data:        []byte{0x03},
+		BlockNumber: 7,
+		TxHash:      testTxHash,
+	}
+)
+
+// testHmyService is the hmyv2 API of the test server.
+type testHmyService struct {
+	sent []*types.Transaction
+	call map[string]interface{}
+}
+
+func (s *testHmyService) BlockNumber() uint64 {
+	return 42
+}
+
+func (s *testHmyService) GetBlockByNumber(number json.RawMessage, args map[string]interface{}) (interface{}, error) {
+	if string(number) != "7" || args["fullTx"] != true {
+		return nil, errors.New("unexpected arguments")
+	}
+	return &v2.BlockWithFullTx{
+		Number: big.NewInt(7),
+		Epoch:  big.NewInt(1),
+		Transactions: []*v2.Transaction{
+			{Hash: testTxHash, Value: big.NewInt(100), From: "one1test"},
+		},
+	}, nil
+}
+
+func (s *testHmyService) GetTransactionReceipt(hash common.Hash) *v2.TxReceipt {
+	if hash != testTxHash {
+		return nil
+	}
+	return &v2.TxReceipt{
+		TransactionHash: hash,
+		BlockNumber:     7,
+		GasUsed:         21000,
+		ContractAddress: common.HexToAddress("0x1234"),
+		Logs:            []*types.Log{&testLog},
+		Status:          1,
+	}
+}
+
+func (s *testHmyService) GetTransactionCount(address string, block string) (uint64, error) {
+	if address != testAddr.Hex() || block != "pending" {
+		return 0, errors.New("unexpected arguments")
+	}
+	return 5, nil
+}
+
+func (s *testHmyService) GasPrice() uint64 {
+	return 100e9
+}
+
+func (s *testHmyService) Call(args map[string]interface{}, block string) hexutil.Bytes {
+	s.call = args
+	return hexutil.Bytes{0x2a}
+}
+
+func (s *testHmyService) SendRawTransaction(encoded hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(encoded); err != nil {
+		return common.Hash{}, err
+	}
+	s.sent = append(s.sent, tx)
+	return tx.Hash(), nil
+}
+
+func (s *testHmyService) GetDelegationsByDelegator(address string) []map[string]interface{} {
+	return []map[string]interface{}{{
+		"validator_address": "one1validator",
+		"delegator_address": "one1delegator",
+		"amount":            big.NewInt(1000),
+		"reward":            big.NewInt(10),
+		"Undelegations":     []map[string]interface{}{{"Amount": big.NewInt(5), "Epoch": big.NewInt(3)}},
+	}}
+}
+
+// testFilterService is the filter API of the test server.
+type testFilterService struct{}
+
+func (s *testFilterService) GetLogs(crit map[string]interface{}) ([]*types.Log, error) {
+	if crit["fromBlock"] != "0x0" || crit["toBlock"] != "latest" {
+		return nil, errors.New("unexpected criteria")
+	}
+	return []*types.Log{&testLog}, nil
+}
+
+func (s *testFilterService) Logs(ctx context.Context, crit map[string]interface{}) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	go notifier.Notify(sub.ID, &testLog)
+	return sub, nil
+}
+
+func newTestClient(t *testing.T) (*Client, *testHmyService) {
+	t.Helper()
+	hmy := new(testHmyService)
+	server := rpc.NewServer()
+	if err := server.RegisterName("hmyv2", hmy, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.RegisterName(filterNamespace, new(testFilterService), nil); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(server.Stop)
+	client := NewClient(rpc.DialInProc(server))
+	t.Cleanup(client.Close)
+	return client, hmy
+}
+
+func TestClientTypedResults(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	if number, err := client.BlockNumber(ctx); err != nil || number != 42 {
+		t.Fatalf("BlockNumber: %v, %v", number, err)
+	}
+	block, err := client.BlockByNumber(ctx, big.NewInt(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if block.Number.Uint64() != 7 || len(block.Transactions) != 1 ||
+		block.Transactions[0].Hash != testTxHash || block.Transactions[0].Value.Uint64() != 100 {
+		t.Errorf("unexpected block %+v", block)
+	}
+	receipt, err := client.TxReceipt(ctx, testTxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != 1 || receipt.GasUsed != 21000 || len(receipt.Logs) != 1 {
+		t.Errorf("unexpected receipt %+v", receipt)
+	}
+	if _, err := client.TxReceipt(ctx, common.HexToHash("0x99")); err != ethereum.NotFound {
+		t.Errorf("have error %v, want %v", err, ethereum.NotFound)
+	}
+	delegations, err := client.DelegationsByDelegator(ctx, testAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(delegations) != 1 || delegations[0].Amount.Uint64() != 1000 ||
+		len(delegations[0].Undelegations) != 1 || delegations[0].Undelegations[0].Epoch.Uint64() != 3 {
+		t.Errorf("unexpected delegations %+v", delegations)
+	}
+}
+
+func TestClientContractBackend(t *testing.T) {
+	client, hmy := newTestClient(t)
+	ctx := context.Background()
+
+	nonce, err := client.PendingNonceAt(ctx, testAddr)
+	if err != nil || nonce != 5 {
+		t.Fatalf("PendingNonceAt: %v, %v", nonce, err)
+	}
+	price, err := client.SuggestGasPrice(ctx)
+	if err != nil || price.Cmp(big.NewInt(100e9)) != 0 {
+		t.Fatalf("SuggestGasPrice: %v, %v", price, err)
+	}
+
+	to := common.HexToAddress("0x1234")
+	out, err := client.CallContract(ctx, ethereum.CallMsg{From: testAddr, To: &to, Data: []byte{0x01}}, nil)
+	if err != nil || len(out) != 1 || out[0] != 0x2a {
+		t.Fatalf("CallContract: %x, %v", out, err)
+	}
+	if hmy.call["data"] != "0x01" || hmy.call["to"] != strings.ToLower(to.Hex()) {
+		t.Errorf("unexpected call arguments %v", hmy.call)
+	}
+
+	tx, err := types.SignTx(
+		types.NewTransaction(nonce, to, 0, big.NewInt(1), 21000, price, nil),
+		types.NewEIP155Signer(testChainID), testKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := client.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	if len(hmy.sent) != 1 || hmy.sent[0].Hash() != tx.Hash() {
+		t.Fatalf("transaction not sent")
+	}
+
+	receipt, err := client.TransactionReceipt(ctx, testTxHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful || receipt.ContractAddress != to || len(receipt.Logs) != 1 {
+		t.Errorf("unexpected receipt %+v", receipt)
+	}
+}
+
+func TestClientLogs(t *testing.T) {
+	client, _ := newTestClient(t)
+	ctx := context.Background()
+
+	logs, err := client.FilterLogs(ctx, ethereum.FilterQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(logs) != 1 || logs[0].TxHash != testTxHash || logs[0].BlockNumber != 7 {
+		t.Errorf("unexpected logs %+v", logs)
+	}
+	hash := common.HexToHash("0x03")
+	if _, err := client.FilterLogs(ctx, ethereum.FilterQuery{BlockHash: &hash, FromBlock: big.NewInt(1)}); err == nil {
+		t.Error("expected error for block hash and range")
+	}
+
+	ch := make(chan types.Log)
+	sub, err := client.SubscribeFilterLogs(ctx, ethereum.FilterQuery{}, ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sub.Unsubscribe()
+	select {
+	case log := <-ch:
+		if log.Address != testLog.Address || log.TxHash != testTxHash {
+			t.Errorf("unexpected log %+v", log)
+		}
+	case err := <-sub.Err():
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,101 @@
+package hmyclient
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/numeric"
+)
+
+// TxHistoryArgs are the arguments of the transactions history queries.
+type TxHistoryArgs struct {
+	Address   string `json:"address"`
+	PageIndex uint32 `json:"pageIndex"`
+	PageSize  uint32 `json:"pageSize"`
+	FullTx    bool   `json:"fullTx"`
+	TxType    string `json:"txType"`
+	Order     string `json:"order"`
+}
+
+// Header is the header of a new block sent by the newHeads subscription.
+type Header struct {
+	ParentHash  common.Hash    `json:"parentHash"`
+	Miner       common.Address `json:"miner"`
+	StateRoot   common.Hash    `json:"stateRoot"`
+	TxRoot      common.Hash    `json:"transactionsRoot"`
+	ReceiptRoot common.Hash    `json:"receiptsRoot"`
+	Number      *hexutil.Big   `json:"number"`
+	GasLimit    hexutil.Uint64 `json:"gasLimit"`
+	GasUsed     hexutil.Uint64 `json:"gasUsed"`
+	Time        *hexutil.Big   `json:"timestamp"`
+	Extra       hexutil.Bytes  `json:"extraData"`
+	MixDigest   common.Hash    `json:"mixHash"`
+	Hash        common.Hash    `json:"hash"`
+	ViewID      *big.Int       `json:"viewID"`
+	Epoch       *big.Int       `json:"epoch"`
+	ShardID     uint32         `json:"shardID"`
+	BaseFee     *hexutil.Big   `json:"baseFeePerGas,omitempty"`
+}
+
+// PoolStats are the number of transactions of the pending pool.
+type PoolStats struct {
+	Executable    uint64 `json:"executable-count"`
+	NonExecutable uint64 `json:"non-executable-count"`
+}
+
+// Delegation is a delegation of a delegator to a validator, with one
+// addresses.
+type Delegation struct {
+	ValidatorAddress string         `json:"validator_address"`
+	DelegatorAddress string         `json:"delegator_address"`
+	Amount           *big.Int       `json:"amount"`
+	Reward           *big.Int       `json:"reward"`
+	Undelegations    []Undelegation `json:"Undelegations"`
+}
+
+// Undelegation is an amount undelegated at an epoch.
+type Undelegation struct {
+	Amount *big.Int `json:"Amount"`
+	Epoch  *big.Int `json:"Epoch"`
+}
+
+// ValidatorDelegation is a delegation of the validator information.
+type ValidatorDelegation struct {
+	DelegatorAddress string         `json:"delegator-address"`
+	Amount           *big.Int       `json:"amount"`
+	Reward           *big.Int       `json:"reward"`
+	Undelegations    []Undelegation `json:"undelegations"`
+}
+
+// Validator is the validator of the validator information, with one
+// addresses.
+type Validator struct {
+	Address              string                `json:"address"`
+	BLSPublicKeys        []string              `json:"bls-public-keys"`
+	LastEpochInCommittee *big.Int              `json:"last-epoch-in-committee"`
+	MinSelfDelegation    *big.Int              `json:"min-self-delegation"`
+	MaxTotalDelegation   *big.Int              `json:"max-total-delegation"`
+	Rate                 numeric.Dec           `json:"rate"`
+	MaxRate              numeric.Dec           `json:"max-rate"`
+	MaxChangeRate        numeric.Dec           `json:"max-change-rate"`
+	UpdateHeight         *big.Int              `json:"update-height"`
+	Name                 string                `json:"name"`
+	Identity             string                `json:"identity"`
+	Website              string                `json:"website"`
+	SecurityContact      string                `json:"security-contact"`
+	Details              string                `json:"details"`
+	CreationHeight       *big.Int              `json:"creation-height"`
+	Delegations          []ValidatorDelegation `json:"delegations"`
+}
+
+// ValidatorInformation is a validator with its stake and election status.
+type ValidatorInformation struct {
+	Validator            Validator    `json:"validator"`
+	TotalDelegated       *big.Int     `json:"total-delegation"`
+	CurrentlyInCommittee bool         `json:"currently-in-committee"`
+	EPoSStatus           string       `json:"epos-status"`
+	EPoSWinningStake     *numeric.Dec `json:"epos-winning-stake"`
+	BootedStatus         *string      `json:"booted-status"`
+	ActiveStatus         string       `json:"active-status"`
+}
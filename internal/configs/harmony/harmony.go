@@ -38,6 +38,8 @@ type HarmonyConfig struct {
 	GPO        GasPriceOracleConfig
 	Preimage   *PreimageConfig
 	Cache      CacheConfig
+	Health     HealthConfig
+	Ancient    AncientConfig
 }
 
 func (hc HarmonyConfig) ToRPCServerConfig() nodeconfig.RPCServerConfig {
@@ -73,27 +75,50 @@ func (hc HarmonyConfig) ToRPCServerConfig() nodeconfig.RPCServerConfig {
 			Dur("updated", evmCallTimeout).
 			Msg("Sanitizing invalid evm_call timeout")
 	}
+	healthMaxHeadAge, err := time.ParseDuration(hc.Health.MaxHeadAge)
+	if err != nil {
+		healthMaxHeadAge, _ = time.ParseDuration(nodeconfig.DefaultHealthMaxHeadAge)
+		utils.Logger().Warn().
+			Str("provided", hc.Health.MaxHeadAge).
+			Dur("updated", healthMaxHeadAge).
+			Msg("Sanitizing invalid health max head age")
+	}
 	return nodeconfig.RPCServerConfig{
-		HTTPEnabled:        hc.HTTP.Enabled,
-		HTTPIp:             hc.HTTP.IP,
-		HTTPPort:           hc.HTTP.Port,
-		HTTPAuthPort:       hc.HTTP.AuthPort,
-		HTTPTimeoutRead:    readTimeout,
-		HTTPTimeoutWrite:   writeTimeout,
-		HTTPTimeoutIdle:    idleTimeout,
-		WSEnabled:          hc.WS.Enabled,
-		WSIp:               hc.WS.IP,
-		WSPort:             hc.WS.Port,
-		WSAuthPort:         hc.WS.AuthPort,
-		DebugEnabled:       hc.RPCOpt.DebugEnabled,
-		PreimagesEnabled:   hc.RPCOpt.PreimagesEnabled,
-		EthRPCsEnabled:     hc.RPCOpt.EthRPCsEnabled,
-		StakingRPCsEnabled: hc.RPCOpt.StakingRPCsEnabled,
-		LegacyRPCsEnabled:  hc.RPCOpt.LegacyRPCsEnabled,
-		RpcFilterFile:      hc.RPCOpt.RpcFilterFile,
-		RateLimiterEnabled: hc.RPCOpt.RateLimterEnabled,
-		RequestsPerSecond:  hc.RPCOpt.RequestsPerSecond,
-		EvmCallTimeout:     evmCallTimeout,
+		HTTPEnabled:             hc.HTTP.Enabled,
+		HTTPIp:                  hc.HTTP.IP,
+		HTTPPort:                hc.HTTP.Port,
+		HTTPAuthPort:            hc.HTTP.AuthPort,
+		HTTPAuthJWTSecretFile:   hc.HTTP.AuthJWTSecretFile,
+		HTTPGraphQLEnabled:      hc.HTTP.GraphQLEnabled,
+		HTTPTimeoutRead:         readTimeout,
+		HTTPTimeoutWrite:        writeTimeout,
+		HTTPTimeoutIdle:         idleTimeout,
+		WSEnabled:               hc.WS.Enabled,
+		WSIp:                    hc.WS.IP,
+		WSPort:                  hc.WS.Port,
+		WSAuthPort:              hc.WS.AuthPort,
+		WSAuthJWTSecretFile:     hc.WS.AuthJWTSecretFile,
+		DebugEnabled:            hc.RPCOpt.DebugEnabled,
+		PreimagesEnabled:        hc.RPCOpt.PreimagesEnabled,
+		EthRPCsEnabled:          hc.RPCOpt.EthRPCsEnabled,
+		StakingRPCsEnabled:      hc.RPCOpt.StakingRPCsEnabled,
+		LegacyRPCsEnabled:       hc.RPCOpt.LegacyRPCsEnabled,
+		RpcFilterFile:           hc.RPCOpt.RpcFilterFile,
+		RateLimiterEnabled:      hc.RPCOpt.RateLimterEnabled,
+		RequestsPerSecond:       hc.RPCOpt.RequestsPerSecond,
+		EvmCallTimeout:          evmCallTimeout,
+		ClientRequestsPerSecond: hc.RPCOpt.ClientRequestsPerSecond,
+		APIKeyRequestsPerSecond: hc.RPCOpt.APIKeyRequestsPerSecond,
+		APIKeys:                 hc.RPCOpt.APIKeys,
+		MethodCosts:             hc.RPCOpt.MethodCosts,
+		BatchItemLimit:          hc.RPCOpt.BatchItemLimit,
+		BatchResponseMaxSize:    hc.RPCOpt.BatchResponseMaxSize,
+		HealthEnabled:           hc.Health.Enabled,
+		HealthMaxHeadAge:        healthMaxHeadAge,
+		HealthMaxBlocksBehind:   hc.Health.MaxBlocksBehind,
+		HealthMinPeers:          hc.Health.MinPeers,
+		HealthConsensus:         hc.Health.Consensus,
+		HealthDBWritable:        hc.Health.DBWritable,
 	}
 }
 
@@ -136,6 +161,7 @@ type GeneralConfig struct {
 	IsBeaconArchival       bool
 	IsOffline              bool
 	DataDir                string
+	DBEngine               string // "leveldb" or "pebble", the engine of the existing shard databases if empty
 	TraceEnable            bool
 	EnablePruneBeaconChain bool
 	RunElasticMode         bool
@@ -262,22 +288,25 @@ type SysConfig struct {
 }
 
 type HttpConfig struct {
-	Enabled        bool
-	IP             string
-	Port           int
-	AuthPort       int
-	RosettaEnabled bool
-	RosettaPort    int
-	ReadTimeout    string
-	WriteTimeout   string
-	IdleTimeout    string
+	Enabled           bool
+	IP                string
+	Port              int
+	AuthPort          int
+	AuthJWTSecretFile string // Hex encoded JWT secret for the auth port, generated if missing (default: no auth)
+	RosettaEnabled    bool
+	RosettaPort       int
+	ReadTimeout       string
+	WriteTimeout      string
+	IdleTimeout       string
+	GraphQLEnabled    bool // Serve GraphQL queries at /graphql of the RPC port
 }
 
 type WsConfig struct {
-	Enabled  bool
-	IP       string
-	Port     int
-	AuthPort int
+	Enabled           bool
+	IP                string
+	Port              int
+	AuthPort          int
+	AuthJWTSecretFile string // Hex encoded JWT secret for the auth port, generated if missing (default: no auth)
 }
 
 type RpcOptConfig struct {
@@ -290,6 +319,13 @@ type RpcOptConfig struct {
 	RequestsPerSecond  int    // for RPC rate limiter
 	EvmCallTimeout     string // Timeout for eth_call
 	PreimagesEnabled   bool   // Expose preimage API
+	// Per client limits of the RPC requests
+	ClientRequestsPerSecond int            // Rate limit of each client IP, 0 to disable
+	APIKeyRequestsPerSecond int            // Rate limit of each API key, 0 to disable
+	APIKeys                 []string       `toml:",omitempty"` // API keys, sent in the X-API-Key header, limited per key instead of per IP
+	MethodCosts             map[string]int `toml:",omitempty"` // Rate limit cost of the RPC methods, on top of the default ones
+	BatchItemLimit          int            // Maximum number of requests in a batch, 0 for no limit
+	BatchResponseMaxSize    int            // Maximum size in bytes of a batch response, 0 for no limit
 }
 
 type DevnetConfig struct {
@@ -317,6 +353,21 @@ type CacheConfig struct {
 	SnapshotWait    bool          // Wait for snapshot construction on startup
 }
 
+type HealthConfig struct {
+	Enabled         bool   // Serve /health and /ready at the RPC HTTP port
+	MaxHeadAge      string // Max age of the head block for the node to be ready, 0s to disable
+	MaxBlocksBehind uint64 // Max blocks behind the peers of the shard and beacon chains for the node to be ready
+	MinPeers        int    // Min connected peers for the node to be ready, 0 to disable
+	Consensus       bool   // Require validators to have signed the latest block to be ready
+	DBWritable      bool   // Require the chain database to be writable to be healthy
+}
+
+type AncientConfig struct {
+	Enabled   bool   // Move blocks older than the threshold from leveldb into the ancient store
+	Dir       string // Root directory of the shard ancient stores, the shard database directories if empty
+	Threshold uint64 // Number of recent blocks kept in leveldb
+}
+
 type PreimageConfig struct {
 	ImportFrom    string
 	ExportTo      string
@@ -340,7 +391,7 @@ type PrometheusConfig struct {
 type SyncConfig struct {
 	// TODO: Remove this bool after stream sync is fully up.
 	Enabled              bool             // enable the stream sync protocol
-	SyncMode             uint32           // sync mode (default:Full sync, 1: Fast Sync, 2: Snap Sync(not implemented yet))
+	SyncMode             uint32           // sync mode (default:Full sync, 1: Fast Sync, 2: Snap Sync)
 	Downloader           bool             // start the sync downloader client
 	StagedSync           bool             // use staged sync
 	StagedSyncCfg        StagedSyncConfig // staged sync configurations
@@ -19,53 +19,75 @@ func TestToRPCServerConfig(t *testing.T) {
 		{
 			input: HarmonyConfig{
 				HTTP: HttpConfig{
-					Enabled:        true,
-					RosettaEnabled: false,
-					IP:             "127.0.0.1",
-					Port:           nodeconfig.DefaultRPCPort,
-					AuthPort:       nodeconfig.DefaultAuthRPCPort,
-					RosettaPort:    nodeconfig.DefaultRosettaPort,
-					ReadTimeout:    "-1",
-					WriteTimeout:   "-2",
-					IdleTimeout:    "-3",
+					Enabled:           true,
+					RosettaEnabled:    false,
+					IP:                "127.0.0.1",
+					Port:              nodeconfig.DefaultRPCPort,
+					AuthPort:          nodeconfig.DefaultAuthRPCPort,
+					AuthJWTSecretFile: "./.hmy/jwt.hex",
+					RosettaPort:       nodeconfig.DefaultRosettaPort,
+					ReadTimeout:       "-1",
+					WriteTimeout:      "-2",
+					IdleTimeout:       "-3",
 				},
 				WS: WsConfig{
-					Enabled:  true,
-					IP:       "127.0.0.1",
-					Port:     nodeconfig.DefaultWSPort,
-					AuthPort: nodeconfig.DefaultAuthWSPort,
+					Enabled:           true,
+					IP:                "127.0.0.1",
+					Port:              nodeconfig.DefaultWSPort,
+					AuthPort:          nodeconfig.DefaultAuthWSPort,
+					AuthJWTSecretFile: "./.hmy/jwt.hex",
 				},
 				RPCOpt: RpcOptConfig{
-					DebugEnabled:       false,
-					EthRPCsEnabled:     true,
-					StakingRPCsEnabled: true,
-					LegacyRPCsEnabled:  true,
-					RpcFilterFile:      "./.hmy/rpc_filter.txt",
-					RateLimterEnabled:  true,
-					RequestsPerSecond:  nodeconfig.DefaultRPCRateLimit,
-					EvmCallTimeout:     "-4",
+					DebugEnabled:            false,
+					EthRPCsEnabled:          true,
+					StakingRPCsEnabled:      true,
+					LegacyRPCsEnabled:       true,
+					RpcFilterFile:           "./.hmy/rpc_filter.txt",
+					RateLimterEnabled:       true,
+					RequestsPerSecond:       nodeconfig.DefaultRPCRateLimit,
+					EvmCallTimeout:          "-4",
+					ClientRequestsPerSecond: 100,
+					APIKeys:                 []string{"key"},
+					BatchItemLimit:          nodeconfig.DefaultRPCBatchItemLimit,
+				},
+				Health: HealthConfig{
+					Enabled:         true,
+					MaxHeadAge:      "-5",
+					MaxBlocksBehind: 10,
+					MinPeers:        3,
+					DBWritable:      true,
 				},
 			},
 			output: nodeconfig.RPCServerConfig{
-				HTTPEnabled:        true,
-				HTTPIp:             "127.0.0.1",
-				HTTPPort:           nodeconfig.DefaultRPCPort,
-				HTTPAuthPort:       nodeconfig.DefaultAuthRPCPort,
-				HTTPTimeoutRead:    30 * time.Second,
-				HTTPTimeoutWrite:   30 * time.Second,
-				HTTPTimeoutIdle:    120 * time.Second,
-				WSEnabled:          true,
-				WSIp:               "127.0.0.1",
-				WSPort:             nodeconfig.DefaultWSPort,
-				WSAuthPort:         nodeconfig.DefaultAuthWSPort,
-				DebugEnabled:       false,
-				EthRPCsEnabled:     true,
-				StakingRPCsEnabled: true,
-				LegacyRPCsEnabled:  true,
-				RpcFilterFile:      "./.hmy/rpc_filter.txt",
-				RateLimiterEnabled: true,
-				RequestsPerSecond:  nodeconfig.DefaultRPCRateLimit,
-				EvmCallTimeout:     5 * time.Second,
+				HTTPEnabled:             true,
+				HTTPIp:                  "127.0.0.1",
+				HTTPPort:                nodeconfig.DefaultRPCPort,
+				HTTPAuthPort:            nodeconfig.DefaultAuthRPCPort,
+				HTTPAuthJWTSecretFile:   "./.hmy/jwt.hex",
+				HTTPTimeoutRead:         30 * time.Second,
+				HTTPTimeoutWrite:        30 * time.Second,
+				HTTPTimeoutIdle:         120 * time.Second,
+				WSEnabled:               true,
+				WSIp:                    "127.0.0.1",
+				WSPort:                  nodeconfig.DefaultWSPort,
+				WSAuthPort:              nodeconfig.DefaultAuthWSPort,
+				WSAuthJWTSecretFile:     "./.hmy/jwt.hex",
+				DebugEnabled:            false,
+				EthRPCsEnabled:          true,
+				StakingRPCsEnabled:      true,
+				LegacyRPCsEnabled:       true,
+				RpcFilterFile:           "./.hmy/rpc_filter.txt",
+				RateLimiterEnabled:      true,
+				RequestsPerSecond:       nodeconfig.DefaultRPCRateLimit,
+				EvmCallTimeout:          5 * time.Second,
+				ClientRequestsPerSecond: 100,
+				APIKeys:                 []string{"key"},
+				BatchItemLimit:          nodeconfig.DefaultRPCBatchItemLimit,
+				HealthEnabled:           true,
+				HealthMaxHeadAge:        time.Minute,
+				HealthMaxBlocksBehind:   10,
+				HealthMinPeers:          3,
+				HealthDBWritable:        true,
 			},
 		},
 	}
@@ -130,7 +130,7 @@ type ConfigType struct {
 	DNSZone          string
 	isArchival       map[uint32]bool
 	WebHooks         struct {
-		Hooks *webhooks.Hooks
+		Dispatcher *webhooks.Dispatcher
 	}
 	TraceEnable bool
 }
@@ -142,6 +142,9 @@ type RPCServerConfig struct {
 	HTTPPort     int
 	HTTPAuthPort int
 
+	HTTPAuthJWTSecretFile string
+	HTTPGraphQLEnabled    bool
+
 	HTTPTimeoutRead  time.Duration
 	HTTPTimeoutWrite time.Duration
 	HTTPTimeoutIdle  time.Duration
@@ -151,6 +154,8 @@ type RPCServerConfig struct {
 	WSPort     int
 	WSAuthPort int
 
+	WSAuthJWTSecretFile string
+
 	DebugEnabled bool
 
 	PreimagesEnabled   bool
@@ -164,6 +169,20 @@ type RPCServerConfig struct {
 	RequestsPerSecond  int
 
 	EvmCallTimeout time.Duration
+
+	ClientRequestsPerSecond int
+	APIKeyRequestsPerSecond int
+	APIKeys                 []string
+	MethodCosts             map[string]int
+	BatchItemLimit          int
+	BatchResponseMaxSize    int
+
+	HealthEnabled         bool
+	HealthMaxHeadAge      time.Duration
+	HealthMaxBlocksBehind uint64
+	HealthMinPeers        int
+	HealthConsensus       bool
+	HealthDBWritable      bool
 }
 
 // RosettaServerConfig is the config for the rosetta server
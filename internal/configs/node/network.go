@@ -57,6 +57,8 @@ const (
 	DefaultHTTPTimeoutIdle  = "120s"
 	// DefaultEvmCallTimeout is the default timeout for evm call
 	DefaultEvmCallTimeout = "5s"
+	// DefaultHealthMaxHeadAge is the default max age of the head block for the node to be ready
+	DefaultHealthMaxHeadAge = "1m"
 	// DefaultWSPort is the default port for web socket endpoint. The actual port used is
 	DefaultWSPort = 9800
 	// DefaultAuthWSPort is the default port for web socket auth endpoint. The actual port used is
@@ -82,6 +84,10 @@ const (
 const (
 	// DefaultRateLimit for RPC, the number of requests per second
 	DefaultRPCRateLimit = 1000
+	// DefaultRPCBatchItemLimit is the maximum number of requests in a RPC batch
+	DefaultRPCBatchItemLimit = 1000
+	// DefaultRPCBatchResponseMaxSize is the maximum size in bytes of a RPC batch response
+	DefaultRPCBatchResponseMaxSize = 25 * 1000 * 1000
 )
 
 const (
@@ -77,6 +77,10 @@ var (
 		BlockGas30MEpoch:                      big.NewInt(1673), // 2023-11-02 17:30:00+00:00
 		MaxRateEpoch:                          EpochTBD,
 		DevnetExternalEpoch:                   EpochTBD,
+		BerlinEpoch:                           EpochTBD,
+		LondonEpoch:                           EpochTBD,
+		ShanghaiEpoch:                         EpochTBD,
+		CancunEpoch:                           EpochTBD,
 	}
 
 	// TestnetChainConfig contains the chain parameters to run a node on the harmony test network.
@@ -122,6 +126,10 @@ var (
 		BlockGas30MEpoch:                      big.NewInt(2176), // 2023-10-12 10:00:00+00:00
 		MaxRateEpoch:                          EpochTBD,
 		DevnetExternalEpoch:                   EpochTBD,
+		BerlinEpoch:                           EpochTBD,
+		LondonEpoch:                           EpochTBD,
+		ShanghaiEpoch:                         EpochTBD,
+		CancunEpoch:                           EpochTBD,
 	}
 	// PangaeaChainConfig contains the chain parameters for the Pangaea network.
 	// All features except for CrossLink are enabled at launch.
@@ -167,6 +175,10 @@ var (
 		BlockGas30MEpoch:                      big.NewInt(0),
 		MaxRateEpoch:                          EpochTBD,
 		DevnetExternalEpoch:                   EpochTBD,
+		BerlinEpoch:                           EpochTBD,
+		LondonEpoch:                           EpochTBD,
+		ShanghaiEpoch:                         EpochTBD,
+		CancunEpoch:                           EpochTBD,
 	}
 
 	// PartnerChainConfig contains the chain parameters for the Partner network.
@@ -213,6 +225,10 @@ var (
 		BlockGas30MEpoch:                      big.NewInt(7),
 		MaxRateEpoch:                          EpochTBD,
 		DevnetExternalEpoch:                   big.NewInt(144),
+		BerlinEpoch:                           EpochTBD,
+		LondonEpoch:                           EpochTBD,
+		ShanghaiEpoch:                         EpochTBD,
+		CancunEpoch:                           EpochTBD,
 	}
 
 	// StressnetChainConfig contains the chain parameters for the Stress test network.
@@ -259,6 +275,10 @@ var (
 		BlockGas30MEpoch:                      big.NewInt(0),
 		MaxRateEpoch:                          EpochTBD,
 		DevnetExternalEpoch:                   EpochTBD,
+		BerlinEpoch:                           EpochTBD,
+		LondonEpoch:                           EpochTBD,
+		ShanghaiEpoch:                         EpochTBD,
+		CancunEpoch:                           EpochTBD,
 	}
 
 	// LocalnetChainConfig contains the chain parameters to run for local development.
@@ -304,6 +324,10 @@ var (
 		BlockGas30MEpoch:                      big.NewInt(0),
 		MaxRateEpoch:                          EpochTBD,
 		DevnetExternalEpoch:                   EpochTBD,
+		BerlinEpoch:                           EpochTBD,
+		LondonEpoch:                           EpochTBD,
+		ShanghaiEpoch:                         EpochTBD,
+		CancunEpoch:                           EpochTBD,
 	}
 
 	// AllProtocolChanges ...
@@ -351,6 +375,10 @@ var (
 		big.NewInt(0),                      // BlockGas30M
 		big.NewInt(0),                      // MaxRateEpoch
 		big.NewInt(0),
+		big.NewInt(0), // BerlinEpoch
+		big.NewInt(0), // LondonEpoch
+		big.NewInt(0), // ShanghaiEpoch
+		big.NewInt(0), // CancunEpoch
 	}
 
 	// TestChainConfig ...
@@ -398,6 +426,10 @@ var (
 		big.NewInt(0),        // BlockGas30M
 		big.NewInt(0),        // MaxRateEpoch
 		big.NewInt(0),
+		big.NewInt(0), // BerlinEpoch
+		big.NewInt(0), // LondonEpoch
+		big.NewInt(0), // ShanghaiEpoch
+		big.NewInt(0), // CancunEpoch
 	}
 
 	// TestRules ...
@@ -568,6 +600,21 @@ type ChainConfig struct {
 
 	// MaxRateEpoch will make sure the validator max-rate is at least equal to the minRate + the validator max-rate-increase
 	MaxRateEpoch *big.Int `json:"max-rate-epoch,omitempty"`
+
+	// BerlinEpoch is the first epoch to accept EIP-2718 typed transactions with
+	// EIP-2930 access lists and to charge EIP-2929 cold/warm state access gas
+	BerlinEpoch *big.Int `json:"berlin-epoch,omitempty"`
+
+	// LondonEpoch is the first epoch to carry an EIP-1559 base fee in the block
+	// header and to accept dynamic fee transactions
+	LondonEpoch *big.Int `json:"london-epoch,omitempty"`
+
+	// ShanghaiEpoch is the first epoch to support the EIP-3855 PUSH0 opcode
+	ShanghaiEpoch *big.Int `json:"shanghai-epoch,omitempty"`
+
+	// CancunEpoch is the first epoch to support the EIP-1153 transient storage
+	// and the EIP-5656 MCOPY opcodes
+	CancunEpoch *big.Int `json:"cancun-epoch,omitempty"`
 }
 
 // String implements the fmt.Stringer interface.
@@ -636,6 +683,20 @@ func (c *ChainConfig) mustValid() {
 	// max rate (7%) fix is applied on or after hip30
 	require(c.MaxRateEpoch.Cmp(c.HIP30Epoch) >= 0,
 		"must satisfy: MaxRateEpoch >= HIP30Epoch")
+	// access lists are only signed with the ethereum compatible chain id
+	require(c.BerlinEpoch.Cmp(c.EthCompatibleEpoch) >= 0,
+		"must satisfy: BerlinEpoch >= EthCompatibleEpoch")
+	// the access list gas schedule builds on top of EIP-2200
+	require(c.BerlinEpoch.Cmp(c.IstanbulEpoch) >= 0,
+		"must satisfy: BerlinEpoch >= IstanbulEpoch")
+	// dynamic fee transactions are EIP-2718 typed transactions
+	require(c.LondonEpoch.Cmp(c.BerlinEpoch) >= 0,
+		"must satisfy: LondonEpoch >= BerlinEpoch")
+	// the instruction sets build on top of each other
+	require(c.ShanghaiEpoch.Cmp(c.LondonEpoch) >= 0,
+		"must satisfy: ShanghaiEpoch >= LondonEpoch")
+	require(c.CancunEpoch.Cmp(c.ShanghaiEpoch) >= 0,
+		"must satisfy: CancunEpoch >= ShanghaiEpoch")
 }
 
 // IsEIP155 returns whether epoch is either equal to the EIP155 fork epoch or greater.
@@ -835,6 +896,29 @@ func (c *ChainConfig) IsMaxRate(epoch *big.Int) bool {
 	return isForked(c.MaxRateEpoch, epoch)
 }
 
+// IsBerlin determines whether it is the epoch to accept EIP-2718 typed
+// transactions and apply the EIP-2929 gas schedule
+func (c *ChainConfig) IsBerlin(epoch *big.Int) bool {
+	return isForked(c.BerlinEpoch, epoch)
+}
+
+// IsLondon determines whether it is the epoch to charge the EIP-1559 base fee
+// and accept dynamic fee transactions
+func (c *ChainConfig) IsLondon(epoch *big.Int) bool {
+	return isForked(c.LondonEpoch, epoch)
+}
+
+// IsShanghai determines whether it is the epoch to support the PUSH0 opcode
+func (c *ChainConfig) IsShanghai(epoch *big.Int) bool {
+	return isForked(c.ShanghaiEpoch, epoch)
+}
+
+// IsCancun determines whether it is the epoch to support the transient
+// storage and MCOPY opcodes
+func (c *ChainConfig) IsCancun(epoch *big.Int) bool {
+	return isForked(c.CancunEpoch, epoch)
+}
+
 // During this epoch, shards 2 and 3 will start sending
 // their balances over to shard 0 or 1.
 func (c *ChainConfig) IsOneEpochBeforeHIP30(epoch *big.Int) bool {
@@ -899,6 +983,14 @@ type Rules struct {
 	// eip-155 chain id fix
 	IsChainIdFix bool
 	IsValidatorCodeFix bool
+	// eip-2718, eip-2929 and eip-2930
+	IsBerlin bool
+	// eip-1559, eip-3198 and eip-3529
+	IsLondon bool
+	// eip-3855
+	IsShanghai bool
+	// eip-1153 and eip-5656
+	IsCancun bool
 }
 
 // Rules ensures c's ChainID is not nil.
@@ -924,5 +1016,9 @@ func (c *ChainConfig) Rules(epoch *big.Int) Rules {
 		IsCrossShardXferPrecompile: c.IsCrossShardXferPrecompile(epoch),
 		IsChainIdFix:               c.IsChainIdFix(epoch),
 		IsValidatorCodeFix:         c.IsValidatorCodeFix(epoch),
+		IsBerlin:                   c.IsBerlin(epoch),
+		IsLondon:                   c.IsLondon(epoch),
+		IsShanghai:                 c.IsShanghai(epoch),
+		IsCancun:                   c.IsCancun(epoch),
 	}
 }
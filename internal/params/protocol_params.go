@@ -14,6 +14,12 @@ const (
 	TestGenesisGasLimit uint64 = 80000000 // A Gas limit in testing of the Genesis block (set same as current mainnet)
 	// MaximumExtraDataSize ...
 	MaximumExtraDataSize uint64 = 32 // Maximum size extra data may be after Genesis.
+	// BaseFeeChangeDenominator ...
+	BaseFeeChangeDenominator = 8 // Bounds the amount the base fee can change between blocks.
+	// ElasticityMultiplier ...
+	ElasticityMultiplier = 2 // Bounds the maximum gas limit an EIP-1559 block may have.
+	// InitialBaseFee ...
+	InitialBaseFee = 100000000000 // Base fee of the first EIP-1559 block, the 100 gwei default gas price.
 	// ExpByteGas ...
 	ExpByteGas uint64 = 10 // Times ceil(log256(exponent)) for the EXP instruction.
 	// SloadGas ...
@@ -120,6 +126,10 @@ const (
 	TxDataNonZeroGasFrontier uint64 = 68 // Per byte of data attached to a transaction that is not equal to zero. NOTE: Not payable on data of calls between transactions.
 	// TxDataNonZeroGasEIP2028 ...
 	TxDataNonZeroGasEIP2028 uint64 = 16 // Per byte of non zero data attached to a transaction after EIP 2028 (part in Istanbul)
+	// TxAccessListAddressGas ...
+	TxAccessListAddressGas uint64 = 2400 // Per address specified in EIP 2930 access list
+	// TxAccessListStorageKeyGas ...
+	TxAccessListStorageKeyGas uint64 = 1900 // Per storage key specified in EIP 2930 access list
 
 	// These have been changed during the course of the chain
 	CallGasFrontier              uint64 = 40  // Once per CALL operation & message call transaction.
@@ -136,6 +146,22 @@ const (
 	ExtcodeHashGasEIP1884        uint64 = 700  // Cost of EXTCODEHASH after EIP 1884 (part in Istanbul)
 	SelfdestructGasEIP150        uint64 = 5000 // Cost of SELFDESTRUCT post EIP 150 (Tangerine)
 
+	// EIP-2929 splits state access into cold (first touch within a transaction)
+	// and warm (every subsequent touch) costs
+	ColdAccountAccessCostEIP2929 uint64 = 2600 // COLD_ACCOUNT_ACCESS_COST
+	ColdSloadCostEIP2929         uint64 = 2100 // COLD_SLOAD_COST
+	WarmStorageReadCostEIP2929   uint64 = 100  // WARM_STORAGE_READ_COST
+
+	// In EIP-2200: SstoreResetGas was 5000.
+	// In EIP-2929: SstoreResetGas was changed to '5000 - COLD_SLOAD_COST'.
+	// In EIP-3529: SSTORE_CLEARS_SCHEDULE is defined as SSTORE_RESET_GAS + ACCESS_LIST_STORAGE_KEY_COST
+	// Which becomes: 5000 - 2100 + 1900 = 4800
+	SstoreClearsScheduleRefundEIP3529 uint64 = SstoreCleanGasEIP2200 - ColdSloadCostEIP2929 + TxAccessListStorageKeyGas
+
+	// The Refund Quotient is the cap on how much of the used gas can be refunded
+	RefundQuotient        uint64 = 2 // Prior to EIP-3529
+	RefundQuotientEIP3529 uint64 = 5 // After EIP-3529 (part of London)
+
 	// EXP has a dynamic portion depending on the size of the exponent
 	ExpByteFrontier uint64 = 10 // was set to 10 in Frontier
 	ExpByteEIP158   uint64 = 50 // was raised to 50 during Eip158 (Spurious Dragon)
@@ -14,7 +14,7 @@ type Registry struct {
 	mu          sync.Mutex
 	blockchain  core.BlockChain
 	beaconchain core.BlockChain
-	webHooks    *webhooks.Hooks
+	webHooks    *webhooks.Dispatcher
 	txPool      *core.TxPool
 	cxPool      *core.CxPool
 	isBackup    bool
@@ -61,8 +61,8 @@ func (r *Registry) GetBeaconchain() core.BlockChain {
 	return r.beaconchain
 }
 
-// SetWebHooks sets the webhooks to registry.
-func (r *Registry) SetWebHooks(hooks *webhooks.Hooks) *Registry {
+// SetWebHooks sets the webhooks dispatcher to registry.
+func (r *Registry) SetWebHooks(hooks *webhooks.Dispatcher) *Registry {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -70,8 +70,8 @@ func (r *Registry) SetWebHooks(hooks *webhooks.Hooks) *Registry {
 	return r
 }
 
-// GetWebHooks gets the webhooks from registry.
-func (r *Registry) GetWebHooks() *webhooks.Hooks {
+// GetWebHooks gets the webhooks dispatcher from registry.
+func (r *Registry) GetWebHooks() *webhooks.Dispatcher {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"path"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/harmony-one/harmony/internal/shardchain/leveldb_shard"
@@ -12,11 +14,13 @@ import (
 	"github.com/harmony-one/harmony/core/rawdb"
 
 	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/pkg/errors"
 )
 
 const (
 	LDBDirPrefix      = "harmony_db"
 	LDBShardDirPrefix = "harmony_sharddb"
+	AncientDirName    = "ancient"
 )
 
 // DBFactory is a blockchain database factory.
@@ -28,13 +32,81 @@ type DBFactory interface {
 
 // LDBFactory is a LDB-backed blockchain database factory.
 type LDBFactory struct {
-	RootDir string // directory in which to put shard databases in.
+	RootDir    string // directory in which to put shard databases in.
+	Ancient    bool   // whether to move old blocks into an ancient store.
+	AncientDir string // directory in which to put shard ancient stores in, RootDir if empty.
 }
 
 // NewChainDB returns a new LDB for the blockchain for given shard.
 func (f *LDBFactory) NewChainDB(shardID uint32) (ethdb.Database, error) {
-	dir := path.Join(f.RootDir, fmt.Sprintf("%s_%d", LDBDirPrefix, shardID))
-	return rawdb.NewLevelDBDatabase(dir, 256, 1024, "", false)
+	return openChainDB(rawdb.DBLeveldb, f.RootDir, shardID, f.Ancient, f.AncientDir)
+}
+
+// PDBFactory is a Pebble-backed blockchain database factory.
+type PDBFactory struct {
+	RootDir    string // directory in which to put shard databases in.
+	Ancient    bool   // whether to move old blocks into an ancient store.
+	AncientDir string // directory in which to put shard ancient stores in, RootDir if empty.
+}
+
+// NewChainDB returns a new Pebble database for the blockchain for given shard.
+func (f *PDBFactory) NewChainDB(shardID uint32) (ethdb.Database, error) {
+	return openChainDB(rawdb.DBPebble, f.RootDir, shardID, f.Ancient, f.AncientDir)
+}
+
+// openChainDB opens the database of the given shard in rootDir with the given
+// engine, and its ancient store in ancientRootDir if ancient is set.
+func openChainDB(engine, rootDir string, shardID uint32, ancient bool, ancientRootDir string) (ethdb.Database, error) {
+	o := rawdb.OpenOptions{
+		Type:      engine,
+		Directory: ChainDBDir(rootDir, shardID),
+		Cache:     256,
+		Handles:   1024,
+	}
+	if ancient {
+		o.AncientsDirectory = ChainAncientDir(rootDir, ancientRootDir, shardID)
+	}
+	return rawdb.Open(o)
+}
+
+// ChainDBDir returns the directory of the database of the given shard in rootDir.
+func ChainDBDir(rootDir string, shardID uint32) string {
+	return path.Join(rootDir, fmt.Sprintf("%s_%d", LDBDirPrefix, shardID))
+}
+
+// ChainAncientDir returns the directory of the ancient store of the given
+// shard, which is inside its database in rootDir unless ancientRootDir is set.
+func ChainAncientDir(rootDir, ancientRootDir string, shardID uint32) string {
+	if ancientRootDir == "" {
+		return path.Join(ChainDBDir(rootDir, shardID), AncientDirName)
+	}
+	return path.Join(ChainDBDir(ancientRootDir, shardID), AncientDirName)
+}
+
+// DetectDBEngine returns the engine of the existing shard databases in
+// rootDir, or an empty string if there are none. Only the directories named
+// after a shard are considered, not the ones left by a database conversion.
+func DetectDBEngine(rootDir string) (string, error) {
+	dirs, err := filepath.Glob(path.Join(rootDir, LDBDirPrefix+"_*"))
+	if err != nil {
+		return "", err
+	}
+	var engine string
+	for _, dir := range dirs {
+		shardID := strings.TrimPrefix(filepath.Base(dir), LDBDirPrefix+"_")
+		if _, err := strconv.ParseUint(shardID, 10, 32); err != nil {
+			continue
+		}
+		found := rawdb.PreexistingDatabase(dir)
+		if found == "" {
+			continue
+		}
+		if engine != "" && found != engine {
+			return "", errors.Errorf("found both %s and %s shard databases in %s", engine, found, rootDir)
+		}
+		engine = found
+	}
+	return engine, nil
 }
 
 // MemDBFactory is a memory-backed blockchain database factory.
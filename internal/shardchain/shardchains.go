@@ -105,6 +105,9 @@ func (sc *CollectionImpl) ShardChain(shardID uint32, options ...core.Options) (c
 			Disabled:  true,
 			Preimages: true,
 		}
+		if sc.harmonyconfig != nil {
+			cacheConfig.AncientThreshold = sc.harmonyconfig.Ancient.Threshold
+		}
 		utils.Logger().Info().
 			Uint32("shardID", shardID).
 			Msg("disable cache, running in archival mode")
@@ -112,13 +115,14 @@ func (sc *CollectionImpl) ShardChain(shardID uint32, options ...core.Options) (c
 		hc := sc.harmonyconfig
 		if hc != nil {
 			cacheConfig = &core.CacheConfig{
-				Disabled:      hc.Cache.Disabled,
-				TrieNodeLimit: hc.Cache.TrieNodeLimit,
-				TrieTimeLimit: hc.Cache.TrieTimeLimit,
-				TriesInMemory: hc.Cache.TriesInMemory,
-				SnapshotLimit: hc.Cache.SnapshotLimit,
-				SnapshotWait:  hc.Cache.SnapshotWait,
-				Preimages:     hc.Cache.Preimages,
+				Disabled:         hc.Cache.Disabled,
+				TrieNodeLimit:    hc.Cache.TrieNodeLimit,
+				TrieTimeLimit:    hc.Cache.TrieTimeLimit,
+				TriesInMemory:    hc.Cache.TriesInMemory,
+				SnapshotLimit:    hc.Cache.SnapshotLimit,
+				SnapshotWait:     hc.Cache.SnapshotWait,
+				Preimages:        hc.Cache.Preimages,
+				AncientThreshold: hc.Ancient.Threshold,
 			}
 		} else {
 			cacheConfig = nil
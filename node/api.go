@@ -1,6 +1,8 @@
 package node
 
 import (
+	"net/http"
+
 	"github.com/harmony-one/harmony/consensus/quorum"
 	"github.com/harmony-one/harmony/consensus/votepower"
 	"github.com/harmony-one/harmony/core/types"
@@ -12,6 +14,7 @@ import (
 	hmy_rpc "github.com/harmony-one/harmony/rpc"
 	rpc_common "github.com/harmony-one/harmony/rpc/common"
 	"github.com/harmony-one/harmony/rpc/filters"
+	"github.com/harmony-one/harmony/rpc/graphql"
 	"github.com/libp2p/go-libp2p/core/peer"
 )
 
@@ -73,7 +76,16 @@ func (node *Node) StartRPC() error {
 	// Gather all the possible APIs to surface
 	apis := node.APIs(harmony)
 
-	return hmy_rpc.StartServers(harmony, apis, node.NodeConfig.RPCServer, node.HarmonyConfig.RPCOpt)
+	handlers := make(map[string]http.Handler)
+	if node.NodeConfig.RPCServer.HTTPGraphQLEnabled {
+		handler, err := graphql.NewHandler(harmony)
+		if err != nil {
+			return err
+		}
+		handlers[graphql.Path] = handler
+	}
+
+	return hmy_rpc.StartServers(harmony, apis, handlers, node.NodeConfig.RPCServer, node.HarmonyConfig.RPCOpt)
 }
 
 // StopRPC stop RPC service
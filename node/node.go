@@ -130,6 +130,10 @@ type Node struct {
 	// KeysToAddrs holds the addresses of bls keys run by the node
 	keysToAddrs      *lrucache.Cache[uint64, map[string]common.Address]
 	keysToAddrsMutex sync.Mutex
+	// belowThreshold holds whether the availability of the validators run by
+	// the node was below the threshold at the last block
+	belowThreshold      map[common.Address]bool
+	belowThresholdMutex sync.Mutex
 	// TransactionErrorSink contains error messages for any failed transaction, in memory only
 	TransactionErrorSink *types.TransactionErrorSink
 	// BroadcastInvalidTx flag is considered when adding pending tx to tx-pool
@@ -1034,6 +1038,7 @@ func New(
 		crosslinks:           crosslinks.New(),
 		syncID:               GenerateSyncID(),
 		keysToAddrs:          lrucache.NewCache[uint64, map[string]common.Address](10),
+		belowThreshold:       map[common.Address]bool{},
 	}
 	if consensusObj == nil {
 		panic("consensusObj is nil")
@@ -1146,12 +1151,7 @@ func New(
 				) {
 					return
 				}
-				if hooks := node.NodeConfig.WebHooks.Hooks; hooks != nil {
-					if s := hooks.Slashing; s != nil {
-						url := s.OnNoticeDoubleSign
-						go func() { webhooks.DoPost(url, &doubleSign) }()
-					}
-				}
+				node.NodeConfig.WebHooks.Dispatcher.Emit(webhooks.EventDoubleSign, &doubleSign)
 				if !node.IsRunningBeaconChain() {
 					go node.BroadcastSlash(&doubleSign)
 				} else {
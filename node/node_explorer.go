@@ -16,6 +16,7 @@ import (
 	"github.com/harmony-one/harmony/consensus/signature"
 	"github.com/harmony-one/harmony/core"
 	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/hmy/tracers"
 	"github.com/harmony-one/harmony/internal/utils"
 	"github.com/pkg/errors"
 )
@@ -257,6 +258,22 @@ func (node *Node) GetStakingTransactionsHistory(address, txType, order string) (
 	return txs, nil
 }
 
+// GetInternalTransactionsHistory returns a page of the list of internal transactions of address.
+func (node *Node) GetInternalTransactionsHistory(address, txType, order string, page, size int) ([]*tracers.InternalTransaction, error) {
+	exp, err := node.getExplorerService()
+	if err != nil {
+		return nil, err
+	}
+	match := func(tt explorer.TxType) bool {
+		return isTargetTxType(tt, txType)
+	}
+	txs, _, err := exp.GetInternalTxsByAccount(address, match, order == "DESC", page, size)
+	if err != nil {
+		return nil, err
+	}
+	return txs, nil
+}
+
 // GetTransactionsCount returns the number of regular transactions hashes of address for input type.
 func (node *Node) GetTransactionsCount(address, txType string) (uint64, error) {
 	exp, err := node.getExplorerService()
@@ -7,6 +7,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/harmony-one/harmony/crypto/bls"
 	"github.com/harmony-one/harmony/internal/utils/crosslinks"
@@ -372,8 +373,8 @@ func (node *Node) PostConsensusProcessing(newBlock *types.Block) error {
 	// Broadcast client requested missing cross shard receipts if there is any
 	BroadcastMissingCXReceipts(node.Consensus)
 
-	if h := node.NodeConfig.WebHooks.Hooks; h != nil {
-		if h.Availability != nil {
+	if h := node.NodeConfig.WebHooks.Dispatcher; h != nil {
+		if h.Subscribed(webhooks.EventAvailabilityBelowThreshold) {
 			for _, addr := range node.GetAddresses(newBlock.Epoch()) {
 				wrapper, err := node.Beaconchain().ReadValidatorInformation(addr)
 				if err != nil {
@@ -392,11 +393,8 @@ func (node *Node) PostConsensusProcessing(newBlock *types.Block) error {
 
 				computed.BlocksLeftInEpoch = lastBlockOfEpoch - node.Beaconchain().CurrentBlock().Header().Number().Uint64()
 
-				if err != nil && computed.IsBelowThreshold {
-					url := h.Availability.OnDroppedBelowThreshold
-					go func() {
-						webhooks.DoPost(url, computed)
-					}()
+				if node.crossedBelowThreshold(addr, computed.IsBelowThreshold) {
+					h.Emit(webhooks.EventAvailabilityBelowThreshold, computed)
 				}
 			}
 		}
@@ -404,6 +402,21 @@ func (node *Node) PostConsensusProcessing(newBlock *types.Block) error {
 	return nil
 }
 
+// crossedBelowThreshold records whether the availability of the validator is
+// below the threshold, and returns whether it just crossed from above to
+// below it, so that the event is sent once rather than at every block.
+func (node *Node) crossedBelowThreshold(addr common.Address, below bool) bool {
+	node.belowThresholdMutex.Lock()
+	defer node.belowThresholdMutex.Unlock()
+	wasBelow := node.belowThreshold[addr]
+	if below {
+		node.belowThreshold[addr] = true
+	} else {
+		delete(node.belowThreshold, addr)
+	}
+	return below && !wasBelow
+}
+
 // BootstrapConsensus is a goroutine to check number of peers and start the consensus
 func (node *Node) BootstrapConsensus() error {
 	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
@@ -219,3 +219,26 @@ func TestVerifyVRF(t *testing.T) {
 		t.Error("New vrf is not verified successfully:", err)
 	}
 }
+
+func TestCrossedBelowThreshold(t *testing.T) {
+	node := &Node{belowThreshold: map[common.Address]bool{}}
+	a, b := common.Address{1}, common.Address{2}
+	tests := []struct {
+		addr    common.Address
+		below   bool
+		crossed bool
+	}{
+		{a, false, false},
+		{a, true, true},
+		{b, true, true},
+		{a, true, false},
+		{a, false, false},
+		{a, true, true},
+		{b, true, false},
+	}
+	for i, test := range tests {
+		if crossed := node.crossedBelowThreshold(test.addr, test.below); crossed != test.crossed {
+			t.Errorf("Test %v: crossed %v, want %v", i, crossed, test.crossed)
+		}
+	}
+}
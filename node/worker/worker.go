@@ -82,6 +82,9 @@ func New(
 		Time(big.NewInt(timestamp)).
 		ShardID(chain.ShardID()).
 		Header()
+	if chain.Config().IsLondon(epoch) {
+		header.SetBaseFee(core.CalcBaseFee(chain.Config(), parent))
+	}
 	worker.makeCurrent(parent, header)
 
 	return worker
@@ -211,7 +214,7 @@ func (w *Worker) CommitTransactions(
 	}
 
 	// HARMONY TXNS
-	normalTxns := types.NewTransactionsByPriceAndNonce(w.current.signer, w.current.ethSigner, pendingNormal)
+	normalTxns := types.NewTransactionsByPriceAndNonce(w.current.signer, w.current.ethSigner, pendingNormal, w.current.header.BaseFee())
 
 	w.CommitSortedTransactions(normalTxns, coinbase)
 
@@ -371,6 +374,9 @@ func (w *Worker) UpdateCurrent() (Environment, error) {
 		Time(big.NewInt(timestamp)).
 		ShardID(w.chain.ShardID()).
 		Header()
+	if w.config.IsLondon(epoch) {
+		header.SetBaseFee(core.CalcBaseFee(w.config, parent))
+	}
 	return w.makeCurrent(parent, header)
 }
 
@@ -468,7 +468,7 @@ func (s *PublicBlockchainService) GetBlockReceipts(
 				if err != nil {
 					return nil, err
 				}
-				r, err = eth.NewReceipt(from, tx.ConvertToEth(), blockHash, block.NumberU64(), index, rmap[tx.Hash()])
+				r, err = eth.NewReceipt(from, tx.ConvertToEth(), blockHash, block.NumberU64(), index, rmap[tx.Hash()], block.BaseFee())
 			}
 		default:
 			return nil, ErrUnknownRPCVersion
@@ -12,8 +12,11 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/harmony-one/harmony/common/denominations"
 	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
 	"github.com/harmony-one/harmony/eth/rpc"
 	"github.com/harmony-one/harmony/hmy"
 	hmyCommon "github.com/harmony-one/harmony/internal/common"
@@ -81,6 +84,7 @@ func (s *PublicContractService) wait(limiter *rate.Limiter, ctx context.Context)
 // It doesn't make and changes in the state/blockchain and is useful to execute and retrieve values.
 func (s *PublicContractService) Call(
 	ctx context.Context, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash,
+	overrides *StateOverride, blockOverrides *BlockOverrides,
 ) (hexutil.Bytes, error) {
 	timer := DoMetricRPCRequest(Call)
 	defer DoRPCRequestDuration(Call, timer)
@@ -92,7 +96,7 @@ func (s *PublicContractService) Call(
 	}
 
 	// Execute call
-	result, err := DoEVMCall(ctx, s.hmy, args, blockNrOrHash, s.evmCallTimeout)
+	result, err := DoEVMCall(ctx, s.hmy, args, blockNrOrHash, overrides, blockOverrides, s.evmCallTimeout)
 	if err != nil {
 		return nil, err
 	}
@@ -158,7 +162,7 @@ func (s *PublicContractService) GetStorageAt(
 // DoEVMCall executes an EVM call
 func DoEVMCall(
 	ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash,
-	timeout time.Duration,
+	overrides *StateOverride, blockOverrides *BlockOverrides, timeout time.Duration,
 ) (core.ExecutionResult, error) {
 	defer func(start time.Time) {
 		utils.Logger().Debug().
@@ -172,9 +176,12 @@ func DoEVMCall(
 		DoMetricRPCQueryInfo(DoEvmCall, FailedNumber)
 		return core.ExecutionResult{}, err
 	}
-
 	// Create new call message
-	msg := args.ToMessage(hmy.RPCGasCap)
+	msg, err := args.ToMessage(hmy.RPCGasCap, header.BaseFee())
+	if err != nil {
+		DoMetricRPCQueryInfo(DoEvmCall, FailedNumber)
+		return core.ExecutionResult{}, err
+	}
 
 	// Setup context so it may be cancelled the call has completed
 	// or, in case of unmetered gas, setup a context with a timeout.
@@ -190,11 +197,19 @@ func DoEVMCall(
 	defer cancel()
 
 	// Get a new instance of the EVM.
-	evm, err := hmy.GetEVM(ctx, msg, state, header)
+	blockCtx := core.NewEVMContext(msg, header, hmy.BlockChain, nil)
+	blockOverrides.Apply(&blockCtx)
+	evm, err := hmy.GetEVM(ctx, msg, state, header, &blockCtx)
 	if err != nil {
 		DoMetricRPCQueryInfo(DoEvmCall, FailedNumber)
 		return core.ExecutionResult{}, err
 	}
+	// The overrides are applied after the balance of the sender is topped up
+	// by GetEVM, so that an overridden sender balance is kept
+	if err := overrides.Apply(state); err != nil {
+		DoMetricRPCQueryInfo(DoEvmCall, FailedNumber)
+		return core.ExecutionResult{}, err
+	}
 
 	// Wait for the context to be done and cancel the evm. Even if the
 	// EVM has finished, cancelling may be done (repeatedly)
@@ -221,3 +236,110 @@ func DoEVMCall(
 	// Response output is the same for all versions
 	return result, nil
 }
+
+// AccessListResult is the result of eth_createAccessList, the access list of
+// a call and the gas it used with that list.
+type AccessListResult struct {
+	AccessList *types.AccessList `json:"accessList"`
+	Error      string            `json:"error,omitempty"`
+	GasUsed    hexutil.Uint64    `json:"gasUsed"`
+}
+
+// CreateAccessList creates an access list for the given transaction, executed
+// on the state of the given block, the latest one by default.
+// If the transaction itself fails, the error is returned in the result along
+// with the access list gathered so far.
+func (s *PublicContractService) CreateAccessList(
+	ctx context.Context, args CallArgs, blockNrOrHash *rpc.BlockNumberOrHash,
+) (*AccessListResult, error) {
+	timer := DoMetricRPCRequest(CreateAccessList)
+	defer DoRPCRequestDuration(CreateAccessList, timer)
+
+	err := s.wait(s.limiterCall, ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(CreateAccessList, RateLimitedNumber)
+		return nil, err
+	}
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	acl, gasUsed, vmErr, err := AccessList(ctx, s.hmy, args, bNrOrHash)
+	if err != nil {
+		DoMetricRPCQueryInfo(CreateAccessList, FailedNumber)
+		return nil, err
+	}
+	result := &AccessListResult{AccessList: &acl, GasUsed: hexutil.Uint64(gasUsed)}
+	if vmErr != nil {
+		result.Error = vmErr.Error()
+	}
+	return result, nil
+}
+
+// AccessList creates an access list for the given transaction by executing
+// it with an access list tracer until the list stops growing. It returns the
+// list, the gas used with it and the VM error of the last execution.
+// Adapted from go-ethereum/internal/ethapi/api.go
+func AccessList(
+	ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash,
+) (acl types.AccessList, gasUsed uint64, vmErr error, err error) {
+	// Fetch state
+	db, header, err := hmy.StateAndHeaderByNumberOrHash(ctx, blockNrOrHash)
+	if db == nil || err != nil {
+		return nil, 0, nil, err
+	}
+	// If the gas amount is not set, default to RPC gas cap.
+	if args.Gas == nil {
+		tmp := hexutil.Uint64(hmy.RPCGasCap.Uint64())
+		args.Gas = &tmp
+	}
+	var from, to common.Address
+	if args.From != nil {
+		from = *args.From
+	}
+	if args.To != nil {
+		to = *args.To
+	} else {
+		to = crypto.CreateAddress(from, db.GetNonce(from))
+	}
+	// Retrieve the precompiles since they don't need to be added to the access list
+	precompiles := vm.ActivePrecompiles(hmy.BlockChain.Config().Rules(header.Epoch()))
+
+	// Create an initial tracer
+	prevTracer := vm.NewAccessListTracer(nil, from, to, precompiles)
+	if args.AccessList != nil {
+		prevTracer = vm.NewAccessListTracer(*args.AccessList, from, to, precompiles)
+	}
+	for {
+		// Retrieve the current access list to expand
+		accessList := prevTracer.AccessList()
+
+		// Copy the original db so we don't modify it
+		statedb := db.Copy()
+		// Set the accesslist to the last al
+		args.AccessList = &accessList
+		msg, err := args.ToMessage(hmy.RPCGasCap, header.BaseFee())
+		if err != nil {
+			return nil, 0, nil, err
+		}
+
+		// Apply the transaction with the access list tracer
+		tracer := vm.NewAccessListTracer(accessList, from, to, precompiles)
+		config := vm.Config{Tracer: tracer, Debug: true, NoBaseFee: true}
+		vmenv := vm.NewEVM(core.NewEVMContext(msg, header, hmy.BlockChain, nil), statedb, hmy.BlockChain.Config(), config)
+		res, err := core.ApplyMessage(vmenv, msg, new(core.GasPool).AddGas(msg.Gas()))
+		if err != nil {
+			return nil, 0, nil, fmt.Errorf("failed to apply transaction: %v", err)
+		}
+		if tracer.Equal(prevTracer) {
+			return accessList, res.UsedGas, res.VMErr, nil
+		}
+		prevTracer = tracer
+
+		select {
+		case <-ctx.Done():
+			return nil, 0, nil, ctx.Err()
+		default:
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/common/math"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/accounts/abi/bind/backends"
+	"github.com/harmony-one/harmony/common/denominations"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy"
+	commonRPC "github.com/harmony-one/harmony/rpc/common"
+)
+
+var (
+	testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr   = crypto.PubkeyToAddress(testKey.PublicKey)
+	testTo     = common.HexToAddress("0x1234")
+
+	// balanceOfCaller returns the balance of the caller
+	balanceOfCaller = hexutil.Bytes{
+		byte(vm.CALLER), byte(vm.BALANCE),
+		byte(vm.PUSH1), 0, byte(vm.MSTORE),
+		byte(vm.PUSH1), 32, byte(vm.PUSH1), 0, byte(vm.RETURN),
+	}
+)
+
+// testNodeAPI is the node of a test chain, serving it as both the shard and
+// the beacon chain.
+type testNodeAPI struct {
+	hmy.NodeAPI
+	chain core.BlockChain
+}
+
+func (n *testNodeAPI) Blockchain() core.BlockChain  { return n.chain }
+func (n *testNodeAPI) Beaconchain() core.BlockChain { return n.chain }
+func (n *testNodeAPI) GetConfig() commonRPC.Config  { return commonRPC.Config{} }
+
+// newTestHarmony returns the backend of a test chain of two empty blocks,
+// whose genesis funds testAddr.
+func newTestHarmony(t *testing.T) *hmy.Harmony {
+	t.Helper()
+	balance := new(big.Int).Mul(big.NewInt(denominations.One), big.NewInt(1000))
+	sim := backends.NewSimulatedBackend(core.GenesisAlloc{testAddr: {Balance: balance}}, 10000000)
+	t.Cleanup(func() { sim.Close() })
+	sim.Commit()
+	sim.Commit()
+
+	chain := sim.Blockchain()
+	poolConfig := core.DefaultTxPoolConfig
+	poolConfig.Journal = ""
+	pool := core.NewTxPool(poolConfig, chain.Config(), chain, types.NewTransactionErrorSink())
+	t.Cleanup(pool.Stop)
+	harmony := hmy.New(&testNodeAPI{chain: chain}, pool, nil, 0)
+	t.Cleanup(func() { harmony.BloomIndexer.Close() })
+	return harmony
+}
+
+func TestDoEVMCall_SenderBalanceOverride(t *testing.T) {
+	harmony := newTestHarmony(t)
+	latest := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	args := CallArgs{From: &testAddr, To: &testTo}
+
+	// the balance of the sender is topped up without an override
+	overrides := StateOverride{testTo: {Code: &balanceOfCaller}}
+	result, err := DoEVMCall(context.Background(), harmony, args, latest, &overrides, nil, 0)
+	if err != nil || result.VMErr != nil {
+		t.Fatalf("unexpected error %v, %v", err, result.VMErr)
+	}
+	if balance := new(big.Int).SetBytes(result.ReturnData); balance.Cmp(math.MaxBig256) != 0 {
+		t.Errorf("have balance %v, want %v", balance, math.MaxBig256)
+	}
+
+	// and is kept with an override
+	overridden := (*hexutil.Big)(big.NewInt(12345))
+	overrides[testAddr] = OverrideAccount{Balance: &overridden}
+	result, err = DoEVMCall(context.Background(), harmony, args, latest, &overrides, nil, 0)
+	if err != nil || result.VMErr != nil {
+		t.Fatalf("unexpected error %v, %v", err, result.VMErr)
+	}
+	if balance := new(big.Int).SetBytes(result.ReturnData); balance.Cmp(overridden.ToInt()) != 0 {
+		t.Errorf("have balance %v, want %v", balance, overridden)
+	}
+}
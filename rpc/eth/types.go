@@ -33,6 +33,7 @@ type Block struct {
 	TransactionsRoot common.Hash         `json:"transactionsRoot"`
 	ReceiptsRoot     common.Hash         `json:"receiptsRoot"`
 	Uncles           []common.Hash       `json:"uncles"`
+	BaseFee          *hexutil.Big        `json:"baseFeePerGas,omitempty"`
 }
 
 // BlockWithTxHash represents a block that will serialize to the RPC representation of a block
@@ -53,29 +54,36 @@ type BlockWithFullTx struct {
 
 // Transaction represents a transaction that will serialize to the RPC representation of a transaction
 type Transaction struct {
-	BlockHash        *common.Hash    `json:"blockHash"`
-	BlockNumber      *hexutil.Big    `json:"blockNumber"`
-	From             common.Address  `json:"from"`
-	Timestamp        hexutil.Uint64  `json:"timestamp"` // Not exposed by Ethereum anymore
-	Gas              hexutil.Uint64  `json:"gas"`
-	GasPrice         *hexutil.Big    `json:"gasPrice"`
-	Hash             common.Hash     `json:"hash"`
-	Input            hexutil.Bytes   `json:"input"`
-	Nonce            hexutil.Uint64  `json:"nonce"`
-	To               *common.Address `json:"to"`
-	TransactionIndex *hexutil.Uint64 `json:"transactionIndex"`
-	Value            *hexutil.Big    `json:"value"`
-	V                *hexutil.Big    `json:"v"`
-	R                *hexutil.Big    `json:"r"`
-	S                *hexutil.Big    `json:"s"`
+	BlockHash        *common.Hash      `json:"blockHash"`
+	BlockNumber      *hexutil.Big      `json:"blockNumber"`
+	From             common.Address    `json:"from"`
+	Timestamp        hexutil.Uint64    `json:"timestamp"` // Not exposed by Ethereum anymore
+	Gas              hexutil.Uint64    `json:"gas"`
+	GasPrice         *hexutil.Big      `json:"gasPrice"`
+	GasFeeCap        *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+	GasTipCap        *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
+	Hash             common.Hash       `json:"hash"`
+	Input            hexutil.Bytes     `json:"input"`
+	Nonce            hexutil.Uint64    `json:"nonce"`
+	To               *common.Address   `json:"to"`
+	TransactionIndex *hexutil.Uint64   `json:"transactionIndex"`
+	Value            *hexutil.Big      `json:"value"`
+	Type             hexutil.Uint64    `json:"type"`
+	ChainID          *hexutil.Big      `json:"chainId,omitempty"`
+	Accesses         *types.AccessList `json:"accessList,omitempty"`
+	V                *hexutil.Big      `json:"v"`
+	R                *hexutil.Big      `json:"r"`
+	S                *hexutil.Big      `json:"s"`
 }
 
 // NewTransaction returns a transaction that will serialize to the RPC
 // representation, with the given location metadata set (if available).
+// The baseFee of the including block turns the gas price of a mined
+// dynamic fee transaction into the effective one.
 // Note that all txs on Harmony are replay protected (post EIP155 epoch).
 func NewTransaction(
 	from common.Address, tx *types.EthTransaction, blockHash common.Hash,
-	blockNumber uint64, timestamp uint64, index uint64,
+	blockNumber uint64, timestamp uint64, index uint64, baseFee *big.Int,
 ) (*Transaction, error) {
 	v, r, s := tx.RawSignatureValues()
 
@@ -89,10 +97,23 @@ func NewTransaction(
 		To:        tx.To(),
 		Value:     (*hexutil.Big)(tx.Value()),
 		Timestamp: hexutil.Uint64(timestamp),
+		Type:      hexutil.Uint64(tx.Type()),
 		V:         (*hexutil.Big)(v),
 		R:         (*hexutil.Big)(r),
 		S:         (*hexutil.Big)(s),
 	}
+	if tx.Type() != types.LegacyTxType {
+		al := tx.AccessList()
+		result.Accesses = &al
+		result.ChainID = (*hexutil.Big)(tx.ChainID())
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		result.GasFeeCap = (*hexutil.Big)(tx.GasFeeCap())
+		result.GasTipCap = (*hexutil.Big)(tx.GasTipCap())
+		if blockHash != (common.Hash{}) && baseFee != nil {
+			result.GasPrice = (*hexutil.Big)(types.EffectiveGasPrice(tx.GasTipCap(), tx.GasFeeCap(), baseFee))
+		}
+	}
 	if blockHash != (common.Hash{}) {
 		result.BlockHash = &blockHash
 		result.BlockNumber = (*hexutil.Big)(new(big.Int).SetUint64(blockNumber))
@@ -102,7 +123,7 @@ func NewTransaction(
 }
 func NewTransactionFromTransaction(
 	tx *types.Transaction, blockHash common.Hash,
-	blockNumber uint64, timestamp uint64, index uint64,
+	blockNumber uint64, timestamp uint64, index uint64, baseFee *big.Int,
 ) (*Transaction, error) {
 	from, err := tx.SenderAddress()
 	if err != nil {
@@ -120,10 +141,23 @@ func NewTransactionFromTransaction(
 		To:        tx.To(),
 		Value:     (*hexutil.Big)(tx.Value()),
 		Timestamp: hexutil.Uint64(timestamp),
+		Type:      hexutil.Uint64(tx.Type()),
 		V:         (*hexutil.Big)(v),
 		R:         (*hexutil.Big)(r),
 		S:         (*hexutil.Big)(s),
 	}
+	if tx.Type() != types.LegacyTxType {
+		al := tx.AccessList()
+		result.Accesses = &al
+		result.ChainID = (*hexutil.Big)(tx.ChainID())
+	}
+	if tx.Type() == types.DynamicFeeTxType {
+		result.GasFeeCap = (*hexutil.Big)(tx.GasFeeCap())
+		result.GasTipCap = (*hexutil.Big)(tx.GasTipCap())
+		if blockHash != (common.Hash{}) && baseFee != nil {
+			result.GasPrice = (*hexutil.Big)(types.EffectiveGasPrice(tx.GasTipCap(), tx.GasFeeCap(), baseFee))
+		}
+	}
 	if blockHash != (common.Hash{}) {
 		result.BlockHash = &blockHash
 		result.BlockNumber = (*hexutil.Big)(new(big.Int).SetUint64(blockNumber))
@@ -132,8 +166,8 @@ func NewTransactionFromTransaction(
 	return result, nil
 }
 
-// NewReceipt returns the RPC data for a new receipt
-func NewReceipt(senderAddr common.Address, tx *types.EthTransaction, blockHash common.Hash, blockNumber, blockIndex uint64, receipt *types.Receipt) (map[string]interface{}, error) {
+// NewReceipt returns the RPC data for a new receipt, baseFee is the one of the including block
+func NewReceipt(senderAddr common.Address, tx *types.EthTransaction, blockHash common.Hash, blockNumber, blockIndex uint64, receipt *types.Receipt, baseFee *big.Int) (map[string]interface{}, error) {
 	ethTxHash := tx.Hash()
 	for i := range receipt.Logs {
 		// Override log txHash with receipt's
@@ -152,6 +186,8 @@ func NewReceipt(senderAddr common.Address, tx *types.EthTransaction, blockHash c
 		"contractAddress":   nil,
 		"logs":              receipt.Logs,
 		"logsBloom":         receipt.Bloom,
+		"type":              hexutil.Uint(tx.Type()),
+		"effectiveGasPrice": (*hexutil.Big)(types.EffectiveGasPrice(tx.GasTipCap(), tx.GasFeeCap(), baseFee)),
 	}
 
 	// Assign receipt status or post state.
@@ -201,6 +237,7 @@ func newBlock(b *types.Block) *Block {
 		TransactionsRoot: head.TxHash(),
 		ReceiptsRoot:     head.ReceiptHash(),
 		Uncles:           []common.Hash{},
+		BaseFee:          (*hexutil.Big)(head.BaseFee()),
 	}
 }
 
@@ -229,7 +266,7 @@ func blockWithFullTxFromBlock(b *types.Block) (*BlockWithFullTx, error) {
 		if err != nil {
 			return nil, err
 		}
-		fmtTx, err := NewTransaction(from, tx.ConvertToEth(), b.Hash(), b.NumberU64(), b.Time().Uint64(), uint64(idx))
+		fmtTx, err := NewTransaction(from, tx.ConvertToEth(), b.Hash(), b.NumberU64(), b.Time().Uint64(), uint64(idx), b.BaseFee())
 		if err != nil {
 			return nil, err
 		}
@@ -251,5 +288,13 @@ func NewTransactionFromBlockIndex(b *types.Block, index uint64) (*Transaction, e
 	if err != nil {
 		return nil, err
 	}
-	return NewTransaction(from, tx, b.Hash(), b.NumberU64(), b.Time().Uint64(), index)
+	return NewTransaction(from, tx, b.Hash(), b.NumberU64(), b.Time().Uint64(), index, b.BaseFee())
+}
+
+// FeeHistoryResult is the result of eth_feeHistory.
+type FeeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward,omitempty"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
 }
@@ -0,0 +1,981 @@
+// Package graphql serves the blockchain data of a node over GraphQL, with an
+// EIP-1767 style schema extended with the Harmony specific types.
+package graphql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/harmony-one/harmony/block"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy"
+	"github.com/harmony-one/harmony/rpc/filters"
+	"github.com/harmony-one/harmony/shard"
+	staking "github.com/harmony-one/harmony/staking/types"
+)
+
+// maxBlocksRange is the maximum number of blocks of a blocks query.
+const maxBlocksRange = 1024
+
+var (
+	errBlocksRange     = fmt.Errorf("blocks query must be smaller than size %d", maxBlocksRange)
+	errNotBeaconShard  = errors.New("cannot call this query on non beacon chain node")
+	errMissingReceipts = errors.New("missing receipts")
+)
+
+// Resolver is the resolver of the queries.
+type Resolver struct {
+	hmy *hmy.Harmony
+}
+
+// Block returns the block of a number or a hash, or the latest block.
+func (r *Resolver) Block(ctx context.Context, args struct {
+	Number *Long
+	Hash   *common.Hash
+}) (*Block, error) {
+	var (
+		blk *types.Block
+		err error
+	)
+	switch {
+	case args.Number != nil && args.Hash != nil:
+		return nil, errors.New("only one of number or hash must be specified")
+	case args.Hash != nil:
+		blk, err = r.hmy.GetBlock(ctx, *args.Hash)
+	case args.Number != nil:
+		blk, err = r.hmy.BlockByNumber(ctx, rpc.BlockNumber(*args.Number))
+	default:
+		blk = r.hmy.CurrentBlock()
+	}
+	if err != nil || blk == nil {
+		return nil, err
+	}
+	return &Block{hmy: r.hmy, block: blk}, nil
+}
+
+// Blocks returns the blocks from a number to another, or to the latest block.
+func (r *Resolver) Blocks(ctx context.Context, args struct {
+	From Long
+	To   *Long
+}) ([]*Block, error) {
+	to := r.hmy.CurrentBlock().Number().Int64()
+	if args.To != nil && int64(*args.To) < to {
+		to = int64(*args.To)
+	}
+	if to-int64(args.From) >= maxBlocksRange {
+		return nil, errBlocksRange
+	}
+	blocks := make([]*Block, 0)
+	for i := int64(args.From); i <= to; i++ {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		blk, err := r.hmy.BlockByNumber(ctx, rpc.BlockNumber(i))
+		if err != nil {
+			return nil, err
+		}
+		if blk == nil {
+			break
+		}
+		blocks = append(blocks, &Block{hmy: r.hmy, block: blk})
+	}
+	return blocks, nil
+}
+
+// Transaction returns the plain transaction of a hash, pending or included.
+func (r *Resolver) Transaction(ctx context.Context, args struct{ Hash common.Hash }) (*Transaction, error) {
+	tx, blockHash, _, index := rawdb.ReadTransaction(r.hmy.ChainDb(), args.Hash)
+	if tx == nil {
+		if tx, ok := r.hmy.TxPool.Get(args.Hash).(*types.Transaction); ok {
+			return &Transaction{hmy: r.hmy, tx: tx}, nil
+		}
+		return nil, nil
+	}
+	blk, err := r.hmy.GetBlock(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	return &Transaction{hmy: r.hmy, tx: tx, block: blk, index: index}, nil
+}
+
+// StakingTransaction returns the staking transaction of a hash, pending or
+// included.
+func (r *Resolver) StakingTransaction(ctx context.Context, args struct{ Hash common.Hash }) (*StakingTransaction, error) {
+	stx, blockHash, _, index := rawdb.ReadStakingTransaction(r.hmy.ChainDb(), args.Hash)
+	if stx == nil {
+		if stx, ok := r.hmy.TxPool.Get(args.Hash).(*staking.StakingTransaction); ok {
+			return &StakingTransaction{hmy: r.hmy, tx: stx}, nil
+		}
+		return nil, nil
+	}
+	blk, err := r.hmy.GetBlock(ctx, blockHash)
+	if err != nil {
+		return nil, err
+	}
+	return &StakingTransaction{hmy: r.hmy, tx: stx, block: blk, index: index}, nil
+}
+
+// CxReceipt returns the cross-shard receipt of the transaction of a hash.
+func (r *Resolver) CxReceipt(args struct{ Hash common.Hash }) *CXReceipt {
+	cx, blockHash, blockNumber, _ := rawdb.ReadCXReceipt(r.hmy.ChainDb(), args.Hash)
+	if cx == nil {
+		return nil
+	}
+	return &CXReceipt{cx: cx, blockHash: blockHash, blockNumber: blockNumber}
+}
+
+// FilterCriteria is a log filter within a range of blocks.
+type FilterCriteria struct {
+	FromBlock *Long
+	ToBlock   *Long
+	Addresses *[]Address
+	Topics    *[][]common.Hash
+}
+
+// Logs returns the logs matching a filter.
+func (r *Resolver) Logs(ctx context.Context, args struct{ Filter FilterCriteria }) ([]*Log, error) {
+	begin, end := rpc.LatestBlockNumber.Int64(), rpc.LatestBlockNumber.Int64()
+	if args.Filter.FromBlock != nil {
+		begin = int64(*args.Filter.FromBlock)
+	}
+	if args.Filter.ToBlock != nil {
+		end = int64(*args.Filter.ToBlock)
+	}
+	filter := filters.NewRangeFilter(r.hmy, begin, end, toAddresses(args.Filter.Addresses), toTopics(args.Filter.Topics), false)
+	return runFilter(ctx, r.hmy, filter)
+}
+
+// Account returns an account at a block number, or at the latest block.
+func (r *Resolver) Account(args struct {
+	Address     Address
+	BlockNumber *Long
+}) *Account {
+	blockNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if args.BlockNumber != nil {
+		blockNrOrHash = rpc.BlockNumberOrHashWithNumber(rpc.BlockNumber(*args.BlockNumber))
+	}
+	return &Account{hmy: r.hmy, address: common.Address(args.Address), blockNrOrHash: blockNrOrHash}
+}
+
+// GasPrice returns the suggested gas price.
+func (r *Resolver) GasPrice(ctx context.Context) (hexutil.Big, error) {
+	price, err := r.hmy.SuggestPrice(ctx)
+	return toBig(price), err
+}
+
+// ChainID returns the chain ID of the network.
+func (r *Resolver) ChainID() hexutil.Big {
+	return toBig(new(big.Int).SetUint64(r.hmy.ChainID))
+}
+
+// Shard returns the state of the shard of the node.
+func (r *Resolver) Shard() *Shard {
+	return &Shard{hmy: r.hmy, header: r.hmy.CurrentHeader()}
+}
+
+// Validator returns the information of a validator at the latest block.
+func (r *Resolver) Validator(args struct{ Address Address }) (*Validator, error) {
+	if r.hmy.ShardID != shard.BeaconChainShardID {
+		return nil, errNotBeaconShard
+	}
+	info, err := r.hmy.GetValidatorInformation(common.Address(args.Address), r.hmy.CurrentBlock())
+	if err != nil {
+		return nil, err
+	}
+	return &Validator{info: info}, nil
+}
+
+// ElectedValidators returns the addresses of the elected validators.
+func (r *Resolver) ElectedValidators() ([]common.Address, error) {
+	if r.hmy.ShardID != shard.BeaconChainShardID {
+		return nil, errNotBeaconShard
+	}
+	return r.hmy.GetElectedValidatorAddresses(), nil
+}
+
+// DelegationsByDelegator returns the delegations of a delegator.
+func (r *Resolver) DelegationsByDelegator(args struct{ Address Address }) ([]*Delegation, error) {
+	if r.hmy.ShardID != shard.BeaconChainShardID {
+		return nil, errNotBeaconShard
+	}
+	validators, delegations := r.hmy.GetDelegationsByDelegator(common.Address(args.Address))
+	result := make([]*Delegation, len(delegations))
+	for i := range delegations {
+		result[i] = &Delegation{validator: validators[i], delegation: delegations[i]}
+	}
+	return result, nil
+}
+
+// Account is an account at a particular block.
+type Account struct {
+	hmy           *hmy.Harmony
+	address       common.Address
+	blockNrOrHash rpc.BlockNumberOrHash
+}
+
+func (a *Account) Address() common.Address {
+	return a.address
+}
+
+func (a *Account) Balance(ctx context.Context) (hexutil.Big, error) {
+	balance, err := a.hmy.GetBalance(ctx, a.address, a.blockNrOrHash)
+	return toBig(balance), err
+}
+
+func (a *Account) TransactionCount(ctx context.Context) (Long, error) {
+	state, _, err := a.hmy.StateAndHeaderByNumberOrHash(ctx, a.blockNrOrHash)
+	if state == nil || err != nil {
+		return 0, err
+	}
+	return Long(state.GetNonce(a.address)), state.Error()
+}
+
+func (a *Account) Code(ctx context.Context) (hexutil.Bytes, error) {
+	state, _, err := a.hmy.StateAndHeaderByNumberOrHash(ctx, a.blockNrOrHash)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	return state.GetCode(a.address), state.Error()
+}
+
+func (a *Account) Storage(ctx context.Context, args struct{ Slot common.Hash }) (common.Hash, error) {
+	state, _, err := a.hmy.StateAndHeaderByNumberOrHash(ctx, a.blockNrOrHash)
+	if state == nil || err != nil {
+		return common.Hash{}, err
+	}
+	return state.GetState(a.address, args.Slot), state.Error()
+}
+
+// Block is a block of the shard.
+type Block struct {
+	hmy      *hmy.Harmony
+	block    *types.Block
+	receipts types.Receipts
+}
+
+// getReceipts returns the receipts of the plain transactions and then of the
+// staking transactions of the block.
+func (b *Block) getReceipts(ctx context.Context) (types.Receipts, error) {
+	if b.receipts != nil {
+		return b.receipts, nil
+	}
+	receipts, err := b.hmy.GetReceipts(ctx, b.block.Hash())
+	if err != nil {
+		return nil, err
+	}
+	if receipts == nil {
+		return nil, errMissingReceipts
+	}
+	b.receipts = receipts
+	return receipts, nil
+}
+
+func (b *Block) Number() Long {
+	return Long(b.block.NumberU64())
+}
+
+func (b *Block) Hash() common.Hash {
+	return b.block.Hash()
+}
+
+func (b *Block) Parent(ctx context.Context) (*Block, error) {
+	if b.block.NumberU64() == 0 {
+		return nil, nil
+	}
+	parent, err := b.hmy.GetBlock(ctx, b.block.ParentHash())
+	if err != nil || parent == nil {
+		return nil, err
+	}
+	return &Block{hmy: b.hmy, block: parent}, nil
+}
+
+func (b *Block) TransactionsRoot() common.Hash {
+	return b.block.TxHash()
+}
+
+func (b *Block) StateRoot() common.Hash {
+	return b.block.Root()
+}
+
+func (b *Block) ReceiptsRoot() common.Hash {
+	return b.block.ReceiptHash()
+}
+
+func (b *Block) OutgoingReceiptsRoot() common.Hash {
+	return b.block.OutgoingReceiptHash()
+}
+
+func (b *Block) Miner() common.Address {
+	return b.block.Coinbase()
+}
+
+func (b *Block) Leader() string {
+	return b.hmy.GetLeaderAddress(b.block.Coinbase(), b.block.Epoch())
+}
+
+func (b *Block) ExtraData() hexutil.Bytes {
+	return b.block.Extra()
+}
+
+func (b *Block) GasLimit() Long {
+	return Long(b.block.GasLimit())
+}
+
+func (b *Block) GasUsed() Long {
+	return Long(b.block.GasUsed())
+}
+
+func (b *Block) BaseFeePerGas() *hexutil.Big {
+	return (*hexutil.Big)(b.block.BaseFee())
+}
+
+func (b *Block) Timestamp() Long {
+	return Long(b.block.Time().Uint64())
+}
+
+func (b *Block) LogsBloom() hexutil.Bytes {
+	return b.block.Bloom().Bytes()
+}
+
+func (b *Block) Epoch() Long {
+	return Long(b.block.Epoch().Uint64())
+}
+
+func (b *Block) ShardID() Long {
+	return Long(b.block.ShardID())
+}
+
+func (b *Block) ViewID() Long {
+	return Long(b.block.Header().ViewID().Uint64())
+}
+
+func (b *Block) TransactionCount() Long {
+	return Long(len(b.block.Transactions()))
+}
+
+func (b *Block) Transactions() []*Transaction {
+	txs := b.block.Transactions()
+	result := make([]*Transaction, len(txs))
+	for i, tx := range txs {
+		result[i] = &Transaction{hmy: b.hmy, tx: tx, block: b.block, index: uint64(i), parent: b}
+	}
+	return result
+}
+
+func (b *Block) TransactionAt(args struct{ Index Long }) *Transaction {
+	txs := b.block.Transactions()
+	if int64(args.Index) >= int64(len(txs)) {
+		return nil
+	}
+	return &Transaction{hmy: b.hmy, tx: txs[args.Index], block: b.block, index: uint64(args.Index), parent: b}
+}
+
+func (b *Block) StakingTransactionCount() Long {
+	return Long(len(b.block.StakingTransactions()))
+}
+
+func (b *Block) StakingTransactions() []*StakingTransaction {
+	stxs := b.block.StakingTransactions()
+	result := make([]*StakingTransaction, len(stxs))
+	for i, stx := range stxs {
+		result[i] = &StakingTransaction{hmy: b.hmy, tx: stx, block: b.block, index: uint64(i), parent: b}
+	}
+	return result
+}
+
+func (b *Block) StakingTransactionAt(args struct{ Index Long }) *StakingTransaction {
+	stxs := b.block.StakingTransactions()
+	if int64(args.Index) >= int64(len(stxs)) {
+		return nil
+	}
+	return &StakingTransaction{hmy: b.hmy, tx: stxs[args.Index], block: b.block, index: uint64(args.Index), parent: b}
+}
+
+func (b *Block) IncomingReceipts() []*CXReceipt {
+	result := make([]*CXReceipt, 0)
+	for _, proof := range b.block.IncomingReceipts() {
+		for _, cx := range proof.Receipts {
+			result = append(result, &CXReceipt{cx: cx, blockHash: proof.Header.Hash(), blockNumber: proof.Header.Number().Uint64()})
+		}
+	}
+	return result
+}
+
+func (b *Block) OutgoingReceipts() ([]*CXReceipt, error) {
+	result := make([]*CXReceipt, 0)
+	numShards := shard.Schedule.InstanceForEpoch(b.block.Epoch()).NumShards()
+	for toShardID := uint32(0); toShardID < numShards; toShardID++ {
+		if toShardID == b.block.ShardID() {
+			continue
+		}
+		cxs, err := b.hmy.BlockChain.ReadCXReceipts(toShardID, b.block.NumberU64(), b.block.Hash())
+		if err != nil {
+			return nil, err
+		}
+		for _, cx := range cxs {
+			result = append(result, &CXReceipt{cx: cx, blockHash: b.block.Hash(), blockNumber: b.block.NumberU64()})
+		}
+	}
+	return result, nil
+}
+
+// BlockFilterCriteria is a log filter within a block.
+type BlockFilterCriteria struct {
+	Addresses *[]Address
+	Topics    *[][]common.Hash
+}
+
+func (b *Block) Logs(ctx context.Context, args struct{ Filter BlockFilterCriteria }) ([]*Log, error) {
+	filter := filters.NewBlockFilter(b.hmy, b.block.Hash(), toAddresses(args.Filter.Addresses), toTopics(args.Filter.Topics), false)
+	return runFilter(ctx, b.hmy, filter)
+}
+
+func (b *Block) Account(args struct{ Address Address }) *Account {
+	return &Account{
+		hmy:           b.hmy,
+		address:       common.Address(args.Address),
+		blockNrOrHash: rpc.BlockNumberOrHashWithHash(b.block.Hash(), false),
+	}
+}
+
+// Transaction is a plain transaction.
+type Transaction struct {
+	hmy   *hmy.Harmony
+	tx    *types.Transaction
+	block *types.Block // nil if pending
+	index uint64
+	// parent is the resolved block of the transaction, which caches the receipts
+	parent *Block
+}
+
+func (t *Transaction) getBlock() *Block {
+	if t.parent == nil && t.block != nil {
+		t.parent = &Block{hmy: t.hmy, block: t.block}
+	}
+	return t.parent
+}
+
+// getReceipt returns the receipt of the transaction, or nil if pending.
+func (t *Transaction) getReceipt(ctx context.Context) (*types.Receipt, error) {
+	if t.block == nil {
+		return nil, nil
+	}
+	receipts, err := t.getBlock().getReceipts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if t.index >= uint64(len(receipts)) {
+		return nil, errMissingReceipts
+	}
+	return receipts[t.index], nil
+}
+
+func (t *Transaction) Hash() common.Hash {
+	return t.tx.Hash()
+}
+
+func (t *Transaction) Nonce() Long {
+	return Long(t.tx.Nonce())
+}
+
+func (t *Transaction) Index() *Long {
+	if t.block == nil {
+		return nil
+	}
+	return newLong(t.index)
+}
+
+func (t *Transaction) From() (*Account, error) {
+	from, err := t.tx.SenderAddress()
+	if err != nil {
+		return nil, err
+	}
+	return t.account(from), nil
+}
+
+func (t *Transaction) To() *Account {
+	to := t.tx.To()
+	if to == nil {
+		return nil
+	}
+	return t.account(*to)
+}
+
+// account returns the account of addr at the block of the transaction, or at
+// the latest block if pending.
+func (t *Transaction) account(addr common.Address) *Account {
+	blockNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if t.block != nil {
+		blockNrOrHash = rpc.BlockNumberOrHashWithHash(t.block.Hash(), false)
+	}
+	return &Account{hmy: t.hmy, address: addr, blockNrOrHash: blockNrOrHash}
+}
+
+func (t *Transaction) Value() hexutil.Big {
+	return toBig(t.tx.Value())
+}
+
+func (t *Transaction) GasPrice() hexutil.Big {
+	return toBig(t.tx.GasPrice())
+}
+
+func (t *Transaction) Gas() Long {
+	return Long(t.tx.GasLimit())
+}
+
+func (t *Transaction) InputData() hexutil.Bytes {
+	return t.tx.Data()
+}
+
+func (t *Transaction) Block() *Block {
+	return t.getBlock()
+}
+
+func (t *Transaction) ShardID() Long {
+	return Long(t.tx.ShardID())
+}
+
+func (t *Transaction) ToShardID() Long {
+	return Long(t.tx.ToShardID())
+}
+
+func (t *Transaction) Status(ctx context.Context) (*Long, error) {
+	receipt, err := t.getReceipt(ctx)
+	if receipt == nil || err != nil {
+		return nil, err
+	}
+	return newLong(receipt.Status), nil
+}
+
+func (t *Transaction) GasUsed(ctx context.Context) (*Long, error) {
+	receipt, err := t.getReceipt(ctx)
+	if receipt == nil || err != nil {
+		return nil, err
+	}
+	return newLong(receipt.GasUsed), nil
+}
+
+func (t *Transaction) CumulativeGasUsed(ctx context.Context) (*Long, error) {
+	receipt, err := t.getReceipt(ctx)
+	if receipt == nil || err != nil {
+		return nil, err
+	}
+	return newLong(receipt.CumulativeGasUsed), nil
+}
+
+func (t *Transaction) CreatedContract(ctx context.Context) (*Account, error) {
+	receipt, err := t.getReceipt(ctx)
+	if receipt == nil || err != nil || t.tx.To() != nil {
+		return nil, err
+	}
+	return t.account(receipt.ContractAddress), nil
+}
+
+func (t *Transaction) Logs(ctx context.Context) (*[]*Log, error) {
+	receipt, err := t.getReceipt(ctx)
+	if receipt == nil || err != nil {
+		return nil, err
+	}
+	logs := newLogs(t.hmy, receipt.Logs)
+	return &logs, nil
+}
+
+func (t *Transaction) R() hexutil.Big {
+	_, r, _ := t.tx.RawSignatureValues()
+	return toBig(r)
+}
+
+func (t *Transaction) S() hexutil.Big {
+	_, _, s := t.tx.RawSignatureValues()
+	return toBig(s)
+}
+
+func (t *Transaction) V() hexutil.Big {
+	v, _, _ := t.tx.RawSignatureValues()
+	return toBig(v)
+}
+
+// StakingTransaction is a staking transaction.
+type StakingTransaction struct {
+	hmy    *hmy.Harmony
+	tx     *staking.StakingTransaction
+	block  *types.Block // nil if pending
+	index  uint64
+	parent *Block
+}
+
+func (t *StakingTransaction) getBlock() *Block {
+	if t.parent == nil && t.block != nil {
+		t.parent = &Block{hmy: t.hmy, block: t.block}
+	}
+	return t.parent
+}
+
+// getReceipt returns the receipt of the transaction, or nil if pending. The
+// receipts of the staking transactions follow the plain ones of the block.
+func (t *StakingTransaction) getReceipt(ctx context.Context) (*types.Receipt, error) {
+	if t.block == nil {
+		return nil, nil
+	}
+	receipts, err := t.getBlock().getReceipts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	index := t.index + uint64(len(t.block.Transactions()))
+	if index >= uint64(len(receipts)) {
+		return nil, errMissingReceipts
+	}
+	return receipts[index], nil
+}
+
+func (t *StakingTransaction) Hash() common.Hash {
+	return t.tx.Hash()
+}
+
+func (t *StakingTransaction) Nonce() Long {
+	return Long(t.tx.Nonce())
+}
+
+func (t *StakingTransaction) Index() *Long {
+	if t.block == nil {
+		return nil
+	}
+	return newLong(t.index)
+}
+
+func (t *StakingTransaction) From() (*Account, error) {
+	from, err := t.tx.SenderAddress()
+	if err != nil {
+		return nil, err
+	}
+	blockNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if t.block != nil {
+		blockNrOrHash = rpc.BlockNumberOrHashWithHash(t.block.Hash(), false)
+	}
+	return &Account{hmy: t.hmy, address: from, blockNrOrHash: blockNrOrHash}, nil
+}
+
+func (t *StakingTransaction) Type() string {
+	return t.tx.StakingType().String()
+}
+
+func (t *StakingTransaction) GasPrice() hexutil.Big {
+	return toBig(t.tx.GasPrice())
+}
+
+func (t *StakingTransaction) Gas() Long {
+	return Long(t.tx.GasLimit())
+}
+
+func (t *StakingTransaction) Block() *Block {
+	return t.getBlock()
+}
+
+func (t *StakingTransaction) Status(ctx context.Context) (*Long, error) {
+	receipt, err := t.getReceipt(ctx)
+	if receipt == nil || err != nil {
+		return nil, err
+	}
+	return newLong(receipt.Status), nil
+}
+
+func (t *StakingTransaction) GasUsed(ctx context.Context) (*Long, error) {
+	receipt, err := t.getReceipt(ctx)
+	if receipt == nil || err != nil {
+		return nil, err
+	}
+	return newLong(receipt.GasUsed), nil
+}
+
+func (t *StakingTransaction) Logs(ctx context.Context) (*[]*Log, error) {
+	receipt, err := t.getReceipt(ctx)
+	if receipt == nil || err != nil {
+		return nil, err
+	}
+	logs := newLogs(t.hmy, receipt.Logs)
+	return &logs, nil
+}
+
+// CXReceipt is a cross-shard receipt.
+type CXReceipt struct {
+	cx          *types.CXReceipt
+	blockHash   common.Hash
+	blockNumber uint64
+}
+
+func (c *CXReceipt) TransactionHash() common.Hash {
+	return c.cx.TxHash
+}
+
+func (c *CXReceipt) From() common.Address {
+	return c.cx.From
+}
+
+func (c *CXReceipt) To() *common.Address {
+	return c.cx.To
+}
+
+func (c *CXReceipt) ShardID() Long {
+	return Long(c.cx.ShardID)
+}
+
+func (c *CXReceipt) ToShardID() Long {
+	return Long(c.cx.ToShardID)
+}
+
+func (c *CXReceipt) Amount() hexutil.Big {
+	return toBig(c.cx.Amount)
+}
+
+func (c *CXReceipt) BlockHash() common.Hash {
+	return c.blockHash
+}
+
+func (c *CXReceipt) BlockNumber() Long {
+	return Long(c.blockNumber)
+}
+
+// Log is a log entry.
+type Log struct {
+	hmy *hmy.Harmony
+	log *types.Log
+}
+
+func newLogs(hmy *hmy.Harmony, logs []*types.Log) []*Log {
+	result := make([]*Log, len(logs))
+	for i, log := range logs {
+		result[i] = &Log{hmy: hmy, log: log}
+	}
+	return result
+}
+
+// runFilter returns the logs matching filter.
+func runFilter(ctx context.Context, hmy *hmy.Harmony, filter *filters.Filter) ([]*Log, error) {
+	logs, err := filter.Logs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return newLogs(hmy, logs), nil
+}
+
+func (l *Log) Index() Long {
+	return Long(l.log.Index)
+}
+
+func (l *Log) Account() *Account {
+	return &Account{
+		hmy:           l.hmy,
+		address:       l.log.Address,
+		blockNrOrHash: rpc.BlockNumberOrHashWithHash(l.log.BlockHash, false),
+	}
+}
+
+func (l *Log) Topics() []common.Hash {
+	return l.log.Topics
+}
+
+func (l *Log) Data() hexutil.Bytes {
+	return l.log.Data
+}
+
+func (l *Log) Transaction(ctx context.Context) (*Transaction, error) {
+	blk, err := l.hmy.GetBlock(ctx, l.log.BlockHash)
+	if err != nil || blk == nil {
+		return nil, err
+	}
+	txs := blk.Transactions()
+	if l.log.TxIndex >= uint(len(txs)) || txs[l.log.TxIndex].Hash() != l.log.TxHash {
+		return nil, nil
+	}
+	return &Transaction{hmy: l.hmy, tx: txs[l.log.TxIndex], block: blk, index: uint64(l.log.TxIndex)}, nil
+}
+
+// Validator is the information of a validator.
+type Validator struct {
+	info *staking.ValidatorRPCEnhanced
+}
+
+func (v *Validator) Address() common.Address {
+	return v.info.Wrapper.Address
+}
+
+func (v *Validator) BlsPublicKeys() []string {
+	keys := make([]string, len(v.info.Wrapper.SlotPubKeys))
+	for i, key := range v.info.Wrapper.SlotPubKeys {
+		keys[i] = key.Hex()
+	}
+	return keys
+}
+
+func (v *Validator) Name() string {
+	return v.info.Wrapper.Name
+}
+
+func (v *Validator) Identity() string {
+	return v.info.Wrapper.Identity
+}
+
+func (v *Validator) Website() string {
+	return v.info.Wrapper.Website
+}
+
+func (v *Validator) SecurityContact() string {
+	return v.info.Wrapper.SecurityContact
+}
+
+func (v *Validator) Details() string {
+	return v.info.Wrapper.Details
+}
+
+func (v *Validator) MinSelfDelegation() hexutil.Big {
+	return toBig(v.info.Wrapper.MinSelfDelegation)
+}
+
+func (v *Validator) MaxTotalDelegation() hexutil.Big {
+	return toBig(v.info.Wrapper.MaxTotalDelegation)
+}
+
+func (v *Validator) Rate() string {
+	return v.info.Wrapper.Rate.String()
+}
+
+func (v *Validator) MaxRate() string {
+	return v.info.Wrapper.MaxRate.String()
+}
+
+func (v *Validator) MaxChangeRate() string {
+	return v.info.Wrapper.MaxChangeRate.String()
+}
+
+func (v *Validator) LastEpochInCommittee() hexutil.Big {
+	return toBig(v.info.Wrapper.LastEpochInCommittee)
+}
+
+func (v *Validator) CreationHeight() hexutil.Big {
+	return toBig(v.info.Wrapper.CreationHeight)
+}
+
+func (v *Validator) TotalDelegation() hexutil.Big {
+	if v.info.TotalDelegated != nil {
+		return toBig(v.info.TotalDelegated)
+	}
+	return toBig(v.info.Wrapper.TotalDelegation())
+}
+
+func (v *Validator) CurrentlyInCommittee() bool {
+	return v.info.CurrentlyInCommittee
+}
+
+func (v *Validator) EposStatus() string {
+	return v.info.EPoSStatus
+}
+
+func (v *Validator) ActiveStatus() string {
+	return v.info.ActiveStatus
+}
+
+func (v *Validator) BootedStatus() *string {
+	return v.info.BootedStatus
+}
+
+func (v *Validator) Delegations() []*Delegation {
+	delegations := v.info.Wrapper.Delegations
+	result := make([]*Delegation, len(delegations))
+	for i := range delegations {
+		result[i] = &Delegation{validator: v.info.Wrapper.Address, delegation: &delegations[i]}
+	}
+	return result
+}
+
+// Delegation is a delegation to a validator.
+type Delegation struct {
+	validator  common.Address
+	delegation *staking.Delegation
+}
+
+func (d *Delegation) Validator() common.Address {
+	return d.validator
+}
+
+func (d *Delegation) Delegator() common.Address {
+	return d.delegation.DelegatorAddress
+}
+
+func (d *Delegation) Amount() hexutil.Big {
+	return toBig(d.delegation.Amount)
+}
+
+func (d *Delegation) Reward() hexutil.Big {
+	return toBig(d.delegation.Reward)
+}
+
+func (d *Delegation) Undelegations() []*Undelegation {
+	result := make([]*Undelegation, len(d.delegation.Undelegations))
+	for i := range d.delegation.Undelegations {
+		result[i] = &Undelegation{undelegation: &d.delegation.Undelegations[i]}
+	}
+	return result
+}
+
+// Undelegation is an undelegation of a delegation.
+type Undelegation struct {
+	undelegation *staking.Undelegation
+}
+
+func (u *Undelegation) Amount() hexutil.Big {
+	return toBig(u.undelegation.Amount)
+}
+
+func (u *Undelegation) Epoch() hexutil.Big {
+	return toBig(u.undelegation.Epoch)
+}
+
+// Shard is the state of the shard of the node.
+type Shard struct {
+	hmy    *hmy.Harmony
+	header *block.Header
+}
+
+func (s *Shard) ShardID() Long {
+	return Long(s.header.ShardID())
+}
+
+func (s *Shard) NumShards() Long {
+	return Long(shard.Schedule.InstanceForEpoch(s.header.Epoch()).NumShards())
+}
+
+func (s *Shard) Epoch() Long {
+	return Long(s.header.Epoch().Uint64())
+}
+
+func (s *Shard) BlockNumber() Long {
+	return Long(s.header.Number().Uint64())
+}
+
+func (s *Shard) EpochLastBlock() Long {
+	return Long(shard.Schedule.EpochLastBlock(s.header.Epoch().Uint64()))
+}
+
+func (s *Shard) IsStakingEpoch() bool {
+	return s.hmy.IsStakingEpoch(s.header.Epoch())
+}
+
+func (s *Shard) Committee() ([]common.Address, error) {
+	committee, err := s.hmy.GetValidators(s.header.Epoch())
+	if err != nil {
+		return nil, err
+	}
+	addresses := make([]common.Address, 0)
+	if committee != nil {
+		for _, slot := range committee.Slots {
+			addresses = append(addresses, slot.EcdsaAddress)
+		}
+	}
+	return addresses, nil
+}
@@ -0,0 +1,299 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/harmony-one/harmony/accounts/abi/bind/backends"
+	"github.com/harmony-one/harmony/common/denominations"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/hmy"
+	internal_common "github.com/harmony-one/harmony/internal/common"
+	"github.com/harmony-one/harmony/internal/params"
+	commonRPC "github.com/harmony-one/harmony/rpc/common"
+)
+
+var (
+	testKey, _ = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
+	testAddr   = crypto.PubkeyToAddress(testKey.PublicKey)
+	testTo     = common.HexToAddress("0x1234")
+)
+
+// testNodeAPI is the node of a test chain, serving it as both the shard and
+// the beacon chain.
+type testNodeAPI struct {
+	hmy.NodeAPI
+	chain core.BlockChain
+}
+
+func (n *testNodeAPI) Blockchain() core.BlockChain  { return n.chain }
+func (n *testNodeAPI) Beaconchain() core.BlockChain { return n.chain }
+func (n *testNodeAPI) GetConfig() commonRPC.Config  { return commonRPC.Config{} }
+
+type testChain struct {
+	sim    *backends.SimulatedBackend
+	hmy    *hmy.Harmony
+	schema *graphql.Schema
+	// tx is the transfer of block 1, followed by the empty block 2
+	tx *types.Transaction
+}
+
+func newTestChain(t *testing.T, shardID uint32) *testChain {
+	t.Helper()
+	balance := new(big.Int).Mul(big.NewInt(denominations.One), big.NewInt(1000))
+	sim := backends.NewSimulatedBackend(core.GenesisAlloc{testAddr: {Balance: balance}}, 10000000)
+	t.Cleanup(func() { sim.Close() })
+
+	ctx := context.Background()
+	gasPrice, err := sim.SuggestGasPrice(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tx, err := types.SignTx(
+		types.NewTransaction(0, testTo, 0, big.NewInt(1), params.TxGas, gasPrice, nil),
+		types.NewEIP155Signer(params.TestChainID), testKey,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.SendTransaction(ctx, tx); err != nil {
+		t.Fatal(err)
+	}
+	sim.Commit()
+	sim.Commit()
+
+	chain := sim.Blockchain()
+	poolConfig := core.DefaultTxPoolConfig
+	poolConfig.Journal = ""
+	pool := core.NewTxPool(poolConfig, chain.Config(), chain, types.NewTransactionErrorSink())
+	t.Cleanup(pool.Stop)
+	harmony := hmy.New(&testNodeAPI{chain: chain}, pool, nil, shardID)
+	t.Cleanup(func() { harmony.BloomIndexer.Close() })
+
+	s, err := newSchema(harmony)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &testChain{sim: sim, hmy: harmony, schema: s, tx: tx}
+}
+
+// query returns the JSON of the response to query.
+func (c *testChain) query(t *testing.T, query string) string {
+	t.Helper()
+	resp := c.schema.Exec(context.Background(), query, "", nil)
+	data, err := json.Marshal(resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(data)
+}
+
+func TestBlocks(t *testing.T) {
+	c := newTestChain(t, 0)
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{
+			query: `{ blocks(from: 1) { number } }`,
+			want:  `{"data":{"blocks":[{"number":1},{"number":2}]}}`,
+		},
+		{
+			query: `{ blocks(from: 0, to: 1) { number } }`,
+			want:  `{"data":{"blocks":[{"number":0},{"number":1}]}}`,
+		},
+		{
+			query: `{ blocks(from: 1, to: 10) { number transactionCount } }`,
+			want:  `{"data":{"blocks":[{"number":1,"transactionCount":1},{"number":2,"transactionCount":0}]}}`,
+		},
+		{
+			query: `{ blocks(from: 3) { number } }`,
+			want:  `{"data":{"blocks":[]}}`,
+		},
+	}
+	for i, test := range tests {
+		if have := c.query(t, test.query); have != test.want {
+			t.Errorf("test %d: have %s, want %s", i, have, test.want)
+		}
+	}
+}
+
+func TestBlocksRangeLimit(t *testing.T) {
+	c := newTestChain(t, 0)
+	for c.hmy.CurrentBlock().NumberU64() < maxBlocksRange {
+		c.sim.Commit()
+	}
+
+	want := fmt.Sprintf(`{"errors":[{"message":"%s","path":["blocks"]}],"data":null}`, errBlocksRange)
+	if have := c.query(t, `{ blocks(from: 0) { number } }`); have != want {
+		t.Errorf("have %s, want %s", have, want)
+	}
+	// the range is bounded by the latest block
+	if have := c.query(t, `{ blocks(from: 0, to: 1023) { number } }`); strings.Contains(have, "errors") {
+		t.Errorf("unexpected error for blocks 0 to 1023: %s", have)
+	}
+	resp := c.schema.Exec(context.Background(), `{ blocks(from: 1) { number } }`, "", nil)
+	if len(resp.Errors) != 0 {
+		t.Fatalf("unexpected errors %v", resp.Errors)
+	}
+	data, _ := json.Marshal(resp.Data)
+	var blocks struct{ Blocks []struct{ Number uint64 } }
+	if err := json.Unmarshal(data, &blocks); err != nil {
+		t.Fatal(err)
+	}
+	if len(blocks.Blocks) != maxBlocksRange {
+		t.Errorf("have %d blocks, want %d", len(blocks.Blocks), maxBlocksRange)
+	}
+}
+
+func TestQueryLimits(t *testing.T) {
+	c := newTestChain(t, 0)
+	nested := func(depth int) string {
+		return "{ block " + strings.Repeat("{ parent ", depth-1) + "{ number }" + strings.Repeat(" }", depth)
+	}
+	if have := c.query(t, nested(maxQueryDepth-1)); strings.Contains(have, "errors") {
+		t.Errorf("unexpected error for a query of depth %d: %s", maxQueryDepth, have)
+	}
+	if have := c.query(t, nested(maxQueryDepth+1)); !strings.Contains(have, "exceeds max depth") {
+		t.Errorf("have %s, want depth error", have)
+	}
+
+	// the fields of the elements of the lists are counted, the blocks query
+	// resolving 1 + 1024 * n fields
+	for c.hmy.CurrentBlock().NumberU64() < maxBlocksRange-1 {
+		c.sim.Commit()
+	}
+	fields := "number hash gasLimit gasUsed timestamp epoch shardID viewID transactionCount"
+	if have := c.query(t, `{ blocks(from: 0) { `+fields+` } }`); strings.Contains(have, "errors") {
+		t.Errorf("unexpected error for a query of %d fields: %.200s...", 1+maxBlocksRange*9, have)
+	}
+	want := fmt.Sprintf(`"message":"%s"`, errQueryFields)
+	if have := c.query(t, `{ blocks(from: 0) { `+fields+` stakingTransactionCount } }`); !strings.Contains(have, want) {
+		t.Errorf("have %.200s..., want error %s", have, want)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	c := newTestChain(t, 0)
+	h := &handler{schema: c.schema}
+	serve := func(r *http.Request) string {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, r)
+		return strings.TrimSpace(rec.Body.String())
+	}
+
+	body := `{"query":"query($n: Long) { block(number: $n) { number } }","variables":{"n":"0x1"}}`
+	want := `{"data":{"block":{"number":1}}}`
+	if have := serve(httptest.NewRequest(http.MethodPost, Path, strings.NewReader(body))); have != want {
+		t.Errorf("POST: have %s, want %s", have, want)
+	}
+
+	// the one1 addresses are accepted as input
+	bech32, err := internal_common.AddressToBech32(testTo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	query := url.Values{"query": {fmt.Sprintf(`{ account(address: "%s") { address } }`, bech32)}}
+	want = fmt.Sprintf(`{"data":{"account":{"address":"%s"}}}`, strings.ToLower(testTo.Hex()))
+	if have := serve(httptest.NewRequest(http.MethodGet, Path+"?"+query.Encode(), nil)); have != want {
+		t.Errorf("GET: have %s, want %s", have, want)
+	}
+
+	body = `{"query":"{ block(number: -1) { number } }"}`
+	if have := serve(httptest.NewRequest(http.MethodPost, Path, strings.NewReader(body))); !strings.Contains(have, "invalid Long -1") {
+		t.Errorf("negative number: have %s, want invalid Long error", have)
+	}
+
+	// the requests without data are bad requests
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, Path, strings.NewReader(`{"query":"{ blocks { number } }"}`)))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid query: have status code %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestBeaconQueries(t *testing.T) {
+	queries := []struct {
+		name  string
+		query string
+	}{
+		{"validator", fmt.Sprintf(`{ validator(address: "%s") { address } }`, testAddr.Hex())},
+		{"electedValidators", `{ electedValidators }`},
+		{"delegationsByDelegator", fmt.Sprintf(`{ delegationsByDelegator(address: "%s") { amount } }`, testAddr.Hex())},
+	}
+
+	// the queries fail on the nodes of the other shards
+	c := newTestChain(t, 1)
+	for _, q := range queries {
+		want := fmt.Sprintf(`"errors":[{"message":"%s","path":["%s"]}]`, errNotBeaconShard, q.name)
+		if have := c.query(t, q.query); !strings.Contains(have, want) {
+			t.Errorf("%s: have %s, want error %s", q.name, have, want)
+		}
+	}
+
+	c = newTestChain(t, 0)
+	want := `{"data":{"electedValidators":[],"delegationsByDelegator":[]}}`
+	query := fmt.Sprintf(`{ electedValidators delegationsByDelegator(address: "%s") { amount } }`, testAddr.Hex())
+	if have := c.query(t, query); have != want {
+		t.Errorf("have %s, want %s", have, want)
+	}
+}
+
+func TestReceipts(t *testing.T) {
+	c := newTestChain(t, 0)
+	hash := c.tx.Hash().Hex()
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{
+			query: fmt.Sprintf(`{ transaction(hash: "%s") { index status gasUsed cumulativeGasUsed block { number } } }`, hash),
+			want:  `{"data":{"transaction":{"index":0,"status":1,"gasUsed":21000,"cumulativeGasUsed":21000,"block":{"number":1}}}}`,
+		},
+		{
+			query: `{ block(number: 1) { transactions { status gasUsed logs { index } } } }`,
+			want:  `{"data":{"block":{"transactions":[{"status":1,"gasUsed":21000,"logs":[]}]}}}`,
+		},
+		{
+			query: fmt.Sprintf(`{ transaction(hash: "%s") { hash } }`, common.Hash{}.Hex()),
+			want:  `{"data":{"transaction":null}}`,
+		},
+		// the transfer is not cross-shard
+		{
+			query: fmt.Sprintf(`{ cxReceipt(hash: "%s") { amount } }`, hash),
+			want:  `{"data":{"cxReceipt":null}}`,
+		},
+		{
+			query: `{ block(number: 1) { incomingReceipts { amount } outgoingReceipts { amount } } }`,
+			want:  `{"data":{"block":{"incomingReceipts":[],"outgoingReceipts":[]}}}`,
+		},
+	}
+	for i, test := range tests {
+		if have := c.query(t, test.query); have != test.want {
+			t.Errorf("test %d: have %s, want %s", i, have, test.want)
+		}
+	}
+
+	// the receipt fields of the transactions of a block without receipts fail
+	c = newTestChain(t, 0)
+	blk := c.hmy.BlockChain.GetBlockByNumber(1)
+	if err := rawdb.DeleteReceipts(c.hmy.ChainDb(), blk.Hash(), blk.NumberU64()); err != nil {
+		t.Fatal(err)
+	}
+	want := fmt.Sprintf(`"message":"%s"`, errMissingReceipts)
+	if have := c.query(t, fmt.Sprintf(`{ transaction(hash: "%s") { status } }`, c.tx.Hash().Hex())); !strings.Contains(have, want) {
+		t.Errorf("have %s, want error %s", have, want)
+	}
+}
@@ -0,0 +1,117 @@
+package graphql
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	internal_common "github.com/harmony-one/harmony/internal/common"
+)
+
+// The Bytes32, Bytes and BigInt scalars of the schema are implemented by
+// common.Hash, hexutil.Bytes and hexutil.Big, and the outputs of the Address
+// scalar by common.Address.
+
+// Long is the Long scalar of the schema, a 64 bit unsigned integer.
+type Long int64
+
+// ImplementsGraphQLType returns whether Long implements the named type.
+func (l Long) ImplementsGraphQLType(name string) bool {
+	return name == "Long"
+}
+
+// UnmarshalGraphQL unmarshals the Long of a decimal or a hexadecimal input.
+func (l *Long) UnmarshalGraphQL(input interface{}) error {
+	var (
+		value int64
+		err   error
+	)
+	switch input := input.(type) {
+	case string:
+		if strings.HasPrefix(input, "0x") || strings.HasPrefix(input, "0X") {
+			var u uint64
+			if u, err = hexutil.DecodeUint64(input); err == nil && u > 1<<63-1 {
+				err = strconv.ErrRange
+			}
+			value = int64(u)
+		} else {
+			value, err = strconv.ParseInt(input, 10, 64)
+		}
+	case int32:
+		value = int64(input)
+	case int64:
+		value = input
+	case float64:
+		value = int64(input)
+		if float64(value) != input {
+			err = fmt.Errorf("invalid Long %v", input)
+		}
+	default:
+		err = fmt.Errorf("unexpected type %T for Long", input)
+	}
+	if err == nil && value < 0 {
+		err = fmt.Errorf("invalid Long %v", input)
+	}
+	*l = Long(value)
+	return err
+}
+
+// newLong returns a pointer to the Long of v.
+func newLong(v uint64) *Long {
+	l := Long(v)
+	return &l
+}
+
+// Address is the input of the Address scalar of the schema, accepting the
+// one1 bech32 addresses too.
+type Address common.Address
+
+// ImplementsGraphQLType returns whether Address implements the named type.
+func (a Address) ImplementsGraphQLType(name string) bool {
+	return name == "Address"
+}
+
+// UnmarshalGraphQL unmarshals the Address of a hexadecimal or a bech32 input.
+func (a *Address) UnmarshalGraphQL(input interface{}) error {
+	s, ok := input.(string)
+	if !ok {
+		return fmt.Errorf("unexpected type %T for Address", input)
+	}
+	addr, err := internal_common.ParseAddr(s)
+	if err != nil {
+		return err
+	}
+	*a = Address(addr)
+	return nil
+}
+
+// toAddresses returns the addresses of the Address inputs, nil if none.
+func toAddresses(addrs *[]Address) []common.Address {
+	if addrs == nil {
+		return nil
+	}
+	result := make([]common.Address, len(*addrs))
+	for i, addr := range *addrs {
+		result[i] = common.Address(addr)
+	}
+	return result
+}
+
+// toTopics returns the topics of the Bytes32 inputs, nil if none.
+func toTopics(topics *[][]common.Hash) [][]common.Hash {
+	if topics == nil {
+		return nil
+	}
+	return *topics
+}
+
+// toBig returns the BigInt of i, zero if nil.
+func toBig(i *big.Int) hexutil.Big {
+	if i == nil {
+		return hexutil.Big{}
+	}
+	return hexutil.Big(*i)
+}
@@ -0,0 +1,228 @@
+package graphql
+
+// schema is the EIP-1767 style schema of the blockchain, extended with the
+// staking transactions, cross-shard receipts, validators and shard info.
+const schema = `
+	# Bytes32 is a 32 byte binary string, represented as 0x-prefixed hexadecimal.
+	scalar Bytes32
+	# Address is a 20 byte Harmony address, represented as 0x-prefixed hexadecimal.
+	# The one1 bech32 addresses are accepted as input too.
+	scalar Address
+	# Bytes is an arbitrary length binary string, represented as 0x-prefixed hexadecimal.
+	scalar Bytes
+	# BigInt is a large integer, represented as 0x-prefixed hexadecimal.
+	scalar BigInt
+	# Long is a 64 bit unsigned integer. Hexadecimal strings are accepted as input too.
+	scalar Long
+
+	schema {
+		query: Query
+	}
+
+	# Account is an account at a particular block.
+	type Account {
+		address: Address!
+		balance: BigInt!
+		transactionCount: Long!
+		code: Bytes!
+		storage(slot: Bytes32!): Bytes32!
+	}
+
+	# Log is a log entry emitted by a contract.
+	type Log {
+		# index is the index of the log in its block.
+		index: Long!
+		# account is the account which emitted the log, at the block of the log.
+		account: Account!
+		topics: [Bytes32!]!
+		data: Bytes!
+		# transaction is the plain transaction which emitted the log, null for
+		# the logs of staking transactions.
+		transaction: Transaction
+	}
+
+	# Transaction is a plain transaction, which can be cross-shard.
+	type Transaction {
+		hash: Bytes32!
+		nonce: Long!
+		# index is the index of the transaction in its block, null if pending.
+		index: Long
+		from: Account!
+		# to is the destination of the transaction, null for contract creations.
+		to: Account
+		value: BigInt!
+		gasPrice: BigInt!
+		gas: Long!
+		inputData: Bytes!
+		# block is the block of the transaction, null if pending.
+		block: Block
+		shardID: Long!
+		toShardID: Long!
+		# status, gasUsed, cumulativeGasUsed, createdContract and logs are the
+		# fields of the receipt of the transaction, null if pending.
+		status: Long
+		gasUsed: Long
+		cumulativeGasUsed: Long
+		createdContract: Account
+		logs: [Log!]
+		r: BigInt!
+		s: BigInt!
+		v: BigInt!
+	}
+
+	# StakingTransaction is a staking directive of a validator or a delegator.
+	type StakingTransaction {
+		hash: Bytes32!
+		nonce: Long!
+		# index is the index of the transaction among the staking transactions
+		# of its block, null if pending.
+		index: Long
+		from: Account!
+		# type is the staking directive, such as CreateValidator or Delegate.
+		type: String!
+		gasPrice: BigInt!
+		gas: Long!
+		block: Block
+		status: Long
+		gasUsed: Long
+		logs: [Log!]
+	}
+
+	# CXReceipt is the receipt of a cross-shard transfer, sent by the source
+	# shard to the destination shard.
+	type CXReceipt {
+		transactionHash: Bytes32!
+		from: Address!
+		to: Address
+		shardID: Long!
+		toShardID: Long!
+		amount: BigInt!
+		# blockHash and blockNumber are the block of the source shard.
+		blockHash: Bytes32!
+		blockNumber: Long!
+	}
+
+	# Block is a block of the shard.
+	type Block {
+		number: Long!
+		hash: Bytes32!
+		parent: Block
+		transactionsRoot: Bytes32!
+		stateRoot: Bytes32!
+		receiptsRoot: Bytes32!
+		outgoingReceiptsRoot: Bytes32!
+		# miner is the coinbase of the block.
+		miner: Address!
+		# leader is the one1 address of the leader which proposed the block.
+		leader: String!
+		extraData: Bytes!
+		gasLimit: Long!
+		gasUsed: Long!
+		baseFeePerGas: BigInt
+		timestamp: Long!
+		logsBloom: Bytes!
+		epoch: Long!
+		shardID: Long!
+		viewID: Long!
+		transactionCount: Long!
+		transactions: [Transaction!]!
+		transactionAt(index: Long!): Transaction
+		stakingTransactionCount: Long!
+		stakingTransactions: [StakingTransaction!]!
+		stakingTransactionAt(index: Long!): StakingTransaction
+		# incomingReceipts are the cross-shard receipts credited in the block.
+		incomingReceipts: [CXReceipt!]!
+		# outgoingReceipts are the cross-shard receipts sent by the block.
+		outgoingReceipts: [CXReceipt!]!
+		logs(filter: BlockFilterCriteria!): [Log!]!
+		account(address: Address!): Account!
+	}
+
+	# BlockFilterCriteria is a log filter within a block.
+	input BlockFilterCriteria {
+		# addresses are the contracts to match, any contract if empty.
+		addresses: [Address!]
+		# topics are the topics to match by position, any topic if empty.
+		topics: [[Bytes32!]!]
+	}
+
+	# FilterCriteria is a log filter within a range of blocks.
+	input FilterCriteria {
+		# fromBlock and toBlock are the range of blocks, the latest block if null.
+		fromBlock: Long
+		toBlock: Long
+		addresses: [Address!]
+		topics: [[Bytes32!]!]
+	}
+
+	# Validator is the information of a validator at the latest block.
+	type Validator {
+		address: Address!
+		blsPublicKeys: [String!]!
+		name: String!
+		identity: String!
+		website: String!
+		securityContact: String!
+		details: String!
+		minSelfDelegation: BigInt!
+		maxTotalDelegation: BigInt!
+		rate: String!
+		maxRate: String!
+		maxChangeRate: String!
+		lastEpochInCommittee: BigInt!
+		creationHeight: BigInt!
+		totalDelegation: BigInt!
+		currentlyInCommittee: Boolean!
+		eposStatus: String!
+		activeStatus: String!
+		bootedStatus: String
+		delegations: [Delegation!]!
+	}
+
+	# Delegation is a delegation to a validator.
+	type Delegation {
+		validator: Address!
+		delegator: Address!
+		amount: BigInt!
+		reward: BigInt!
+		undelegations: [Undelegation!]!
+	}
+
+	type Undelegation {
+		amount: BigInt!
+		epoch: BigInt!
+	}
+
+	# Shard is the state of the shard of the node.
+	type Shard {
+		shardID: Long!
+		numShards: Long!
+		epoch: Long!
+		blockNumber: Long!
+		epochLastBlock: Long!
+		isStakingEpoch: Boolean!
+		# committee is the addresses of the slots of the committee of the epoch.
+		committee: [Address!]!
+	}
+
+	type Query {
+		# block is the block of a number or a hash, the latest block if both are null.
+		block(number: Long, hash: Bytes32): Block
+		# blocks is the blocks from a number to another, the latest block if null.
+		blocks(from: Long!, to: Long): [Block!]!
+		transaction(hash: Bytes32!): Transaction
+		stakingTransaction(hash: Bytes32!): StakingTransaction
+		cxReceipt(hash: Bytes32!): CXReceipt
+		logs(filter: FilterCriteria!): [Log!]!
+		# account is an account at a block number, the latest block if null.
+		account(address: Address!, blockNumber: Long): Account!
+		gasPrice: BigInt!
+		chainID: BigInt!
+		shard: Shard!
+		# validator, electedValidators and delegationsByDelegator are only
+		# served by the nodes of the beacon shard.
+		validator(address: Address!): Validator
+		electedValidators: [Address!]!
+		delegationsByDelegator(address: Address!): [Delegation!]!
+	}
+`
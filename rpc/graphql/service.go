@@ -0,0 +1,142 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/errors"
+	"github.com/graph-gophers/graphql-go/introspection"
+	"github.com/graph-gophers/graphql-go/trace"
+	"github.com/harmony-one/harmony/hmy"
+)
+
+// Path is the path of the GraphQL handler on the RPC HTTP server.
+const Path = "/graphql"
+
+const (
+	// maxRequestContentLength is the maximum size of the body of a request.
+	maxRequestContentLength = 1024 * 1024
+	// maxQueryDepth is the maximum nesting of the fields of a query.
+	maxQueryDepth = 16
+	// maxQueryFields is the maximum number of fields resolved by a query,
+	// counting the fields of every element of the lists.
+	maxQueryFields = 10000
+)
+
+var errQueryFields = fmt.Errorf("query must resolve at most %d fields", maxQueryFields)
+
+// NewHandler returns the http.Handler serving the GraphQL queries against hmy.
+func NewHandler(hmy *hmy.Harmony) (http.Handler, error) {
+	s, err := newSchema(hmy)
+	if err != nil {
+		return nil, err
+	}
+	return &handler{schema: s}, nil
+}
+
+// newSchema returns the schema resolved against hmy, with the limits on the
+// depth and the size of the queries.
+func newSchema(hmy *hmy.Harmony) (*graphql.Schema, error) {
+	return graphql.ParseSchema(schema, &Resolver{hmy: hmy},
+		graphql.MaxDepth(maxQueryDepth),
+		graphql.Tracer(fieldsLimiter{}),
+	)
+}
+
+// fieldsLimiter is the tracer of the queries which stops the resolution of
+// a query after maxQueryFields fields, whatever its depth.
+type fieldsLimiter struct {
+	trace.NoopTracer
+}
+
+// fieldsKey is the context key of the number of fields resolved by a query.
+type fieldsKey struct{}
+
+// TraceQuery implements trace.Tracer.
+func (fieldsLimiter) TraceQuery(ctx context.Context, queryString string, operationName string, variables map[string]interface{}, varTypes map[string]*introspection.Type) (context.Context, trace.TraceQueryFinishFunc) {
+	return context.WithValue(ctx, fieldsKey{}, new(int64)), func([]*errors.QueryError) {}
+}
+
+// TraceField implements trace.Tracer. The resolvers of the fields over the
+// limit are not run, as for a cancelled context.
+func (fieldsLimiter) TraceField(ctx context.Context, label, typeName, fieldName string, trivial bool, args map[string]interface{}) (context.Context, trace.TraceFieldFinishFunc) {
+	if fields, ok := ctx.Value(fieldsKey{}).(*int64); ok && atomic.AddInt64(fields, 1) > maxQueryFields {
+		ctx = exceededContext{ctx}
+	}
+	return ctx, func(*errors.QueryError) {}
+}
+
+// exceededContext is the context of a field over the limit of a query.
+type exceededContext struct {
+	context.Context
+}
+
+func (exceededContext) Err() error {
+	return errQueryFields
+}
+
+// handler serves the queries of a schema over HTTP.
+type handler struct {
+	schema *graphql.Schema
+}
+
+// request is a GraphQL request.
+type request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// ServeHTTP implements http.Handler. The query is either the query parameters
+// of a GET request or the JSON body of a POST request.
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var req request
+	switch r.Method {
+	case http.MethodGet:
+		q := r.URL.Query()
+		req.Query = q.Get("query")
+		req.OperationName = q.Get("operationName")
+		if vars := q.Get("variables"); vars != "" {
+			if err := json.Unmarshal([]byte(vars), &req.Variables); err != nil {
+				http.Error(w, "invalid variables: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+	case http.MethodPost:
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxRequestContentLength+1))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if len(body) > maxRequestContentLength {
+			http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		if r.Header.Get("Content-Type") == "application/graphql" {
+			req.Query = string(body)
+		} else if err := json.Unmarshal(body, &req); err != nil {
+			http.Error(w, "invalid request: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	resp := h.schema.Exec(r.Context(), req.Query, req.OperationName, req.Variables)
+	out, err := json.Marshal(resp)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if resp.Data == nil {
+		w.WriteHeader(http.StatusBadRequest)
+	}
+	w.Write(out)
+}
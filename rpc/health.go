@@ -0,0 +1,93 @@
+package rpc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/hmy"
+	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
+)
+
+// healthProbeKey is the key written and deleted by the database probe of the
+// health checks.
+var healthProbeKey = []byte("harmony-health-probe")
+
+// healthBackend is the health.Backend of the node behind hmy.
+type healthBackend struct {
+	hmy *hmy.Harmony
+}
+
+func (b healthBackend) ShardID() uint32 {
+	return b.hmy.ShardID
+}
+
+func (b healthBackend) Head() (uint64, time.Time) {
+	header := b.hmy.CurrentHeader()
+	return header.Number().Uint64(), time.Unix(header.Time().Int64(), 0)
+}
+
+func (b healthBackend) SyncStatus(shardID uint32) (bool, uint64, uint64) {
+	return b.hmy.NodeAPI.SyncStatus(shardID)
+}
+
+func (b healthBackend) ConnectedPeers() int {
+	_, connected, _ := b.hmy.NodeAPI.PeerConnectivity()
+	return connected
+}
+
+func (b healthBackend) IsValidator() bool {
+	cfg := nodeconfig.GetShardConfig(b.hmy.ShardID)
+	return cfg.Role() == nodeconfig.Validator && len(cfg.ConsensusPriKey) > 0
+}
+
+// SignedHead checks the keys of the node against the commit bitmap of the
+// head block, which is the one of its parent.
+func (b healthBackend) SignedHead() (bool, bool, error) {
+	bc := b.hmy.BlockChain
+	head := bc.CurrentHeader()
+	if head.Number().Sign() == 0 {
+		return false, false, nil
+	}
+	parent := bc.GetHeaderByHash(head.ParentHash())
+	if parent == nil {
+		return false, false, errors.New("parent of the head block not found")
+	}
+	state, err := bc.ReadShardState(parent.Epoch())
+	if err != nil {
+		return false, false, err
+	}
+	committee, err := state.FindCommitteeByID(bc.ShardID())
+	if err != nil {
+		return false, false, err
+	}
+	pubKeys, err := committee.BLSPublicKeys()
+	if err != nil {
+		return false, false, err
+	}
+	mask := bls.NewMask(pubKeys)
+	if err := mask.SetMask(head.LastCommitBitmap()); err != nil {
+		return false, false, err
+	}
+	elected := false
+	for _, key := range nodeconfig.GetShardConfig(b.hmy.ShardID).ConsensusPriKey {
+		signed, err := mask.KeyEnabled(key.Pub.Bytes)
+		if err != nil {
+			// key not in the committee
+			continue
+		}
+		if signed {
+			return true, true, nil
+		}
+		elected = true
+	}
+	return elected, false, nil
+}
+
+func (b healthBackend) ProbeDB() error {
+	db := b.hmy.ChainDb()
+	if err := db.Put(healthProbeKey, []byte{1}); err != nil {
+		return err
+	}
+	return db.Delete(healthProbeKey)
+}
@@ -0,0 +1,223 @@
+// Package health serves the health and readiness checks of the node, for the
+// load balancers and orchestrators in front of the RPC endpoints.
+//
+// The node is healthy when its database is writable, and ready when it is
+// also close enough to the head of the chain, connected to enough peers and,
+// for the validators, participating in the consensus.
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/harmony-one/harmony/shard"
+)
+
+const (
+	// HealthPath is the path of the health check on the RPC HTTP server.
+	HealthPath = "/health"
+	// ReadyPath is the path of the readiness check on the RPC HTTP server.
+	ReadyPath = "/ready"
+)
+
+// Names of the checks in the reports.
+const (
+	CheckDB         = "db"
+	CheckHeadAge    = "headAge"
+	CheckSync       = "sync"
+	CheckBeaconSync = "beaconSync"
+	CheckPeers      = "peers"
+	CheckConsensus  = "consensus"
+)
+
+const (
+	statusOK   = "ok"
+	statusFail = "fail"
+)
+
+// Config is the config of the checks. A zero MaxHeadAge or MinPeers disables
+// the check.
+type Config struct {
+	MaxHeadAge      time.Duration // Max age of the head block
+	MaxBlocksBehind uint64        // Max blocks behind the peers, of the shard and beacon chains
+	MinPeers        int           // Min connected peers
+	Consensus       bool          // Require the validators to sign the latest blocks
+	DBWritable      bool          // Probe the chain database with a write
+}
+
+// Backend is the node state the checks are run against.
+type Backend interface {
+	// ShardID returns the shard of the node.
+	ShardID() uint32
+	// Head returns the number and the timestamp of the head block.
+	Head() (uint64, time.Time)
+	// SyncStatus returns whether the chain of the shard is in sync, the height
+	// of the peers and the number of blocks the chain is behind them.
+	SyncStatus(shardID uint32) (bool, uint64, uint64)
+	// ConnectedPeers returns the number of connected peers.
+	ConnectedPeers() int
+	// IsValidator returns whether the node runs the consensus with BLS keys.
+	IsValidator() bool
+	// SignedHead returns whether any key of the node is in the committee which
+	// signed the parent of the head block, and whether any of them signed it.
+	SignedHead() (bool, bool, error)
+	// ProbeDB writes and deletes a key in the chain database.
+	ProbeDB() error
+}
+
+// Result is the result of a single check.
+type Result struct {
+	Status  string `json:"status"`
+	Message string `json:"message,omitempty"`
+}
+
+// Report is the result of the checks of an endpoint.
+type Report struct {
+	Status      string            `json:"status"`
+	ShardID     uint32            `json:"shardID"`
+	BlockNumber uint64            `json:"blockNumber"`
+	Checks      map[string]Result `json:"checks"`
+}
+
+// OK returns whether all the checks of the report passed.
+func (r *Report) OK() bool {
+	return r.Status == statusOK
+}
+
+// Checker runs the health and readiness checks.
+type Checker struct {
+	backend Backend
+	config  Config
+	now     func() time.Time
+}
+
+// NewChecker returns a Checker of the backend with the given config.
+func NewChecker(backend Backend, config Config) *Checker {
+	return &Checker{
+		backend: backend,
+		config:  config,
+		now:     time.Now,
+	}
+}
+
+// Health returns the report of the liveness checks.
+func (c *Checker) Health() *Report {
+	r := c.newReport()
+	if c.config.DBWritable {
+		r.add(CheckDB, c.checkDB())
+	}
+	return r
+}
+
+// Ready returns the report of the liveness and readiness checks.
+func (c *Checker) Ready() *Report {
+	r := c.Health()
+	if c.config.MaxHeadAge > 0 {
+		r.add(CheckHeadAge, c.checkHeadAge())
+	}
+	r.add(CheckSync, c.checkSync(r.ShardID))
+	if r.ShardID != shard.BeaconChainShardID {
+		r.add(CheckBeaconSync, c.checkSync(shard.BeaconChainShardID))
+	}
+	if c.config.MinPeers > 0 {
+		r.add(CheckPeers, c.checkPeers())
+	}
+	if c.config.Consensus && c.backend.IsValidator() {
+		r.add(CheckConsensus, c.checkConsensus())
+	}
+	return r
+}
+
+// Handlers returns the handlers of the checks, by path.
+func (c *Checker) Handlers() map[string]http.Handler {
+	return map[string]http.Handler{
+		HealthPath: c.handler(c.Health),
+		ReadyPath:  c.handler(c.Ready),
+	}
+}
+
+func (c *Checker) handler(check func() *Report) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodGet && req.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		r := check()
+		status := http.StatusOK
+		if !r.OK() {
+			status = http.StatusServiceUnavailable
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.WriteHeader(status)
+		if req.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(r)
+		}
+	})
+}
+
+func (c *Checker) newReport() *Report {
+	number, _ := c.backend.Head()
+	return &Report{
+		Status:      statusOK,
+		ShardID:     c.backend.ShardID(),
+		BlockNumber: number,
+		Checks:      make(map[string]Result),
+	}
+}
+
+func (r *Report) add(name string, err error) {
+	if err != nil {
+		r.Status = statusFail
+		r.Checks[name] = Result{Status: statusFail, Message: err.Error()}
+		return
+	}
+	r.Checks[name] = Result{Status: statusOK}
+}
+
+func (c *Checker) checkDB() error {
+	if err := c.backend.ProbeDB(); err != nil {
+		return fmt.Errorf("database is not writable: %v", err)
+	}
+	return nil
+}
+
+func (c *Checker) checkHeadAge() error {
+	_, timestamp := c.backend.Head()
+	if age := c.now().Sub(timestamp); age > c.config.MaxHeadAge {
+		return fmt.Errorf("head block is %v old, max %v", age.Truncate(time.Second), c.config.MaxHeadAge)
+	}
+	return nil
+}
+
+// checkSync follows the logic of hmy_inSync and hmy_beaconInSync, with
+// MaxBlocksBehind as the tolerance.
+func (c *Checker) checkSync(shardID uint32) error {
+	inSync, target, diff := c.backend.SyncStatus(shardID)
+	if !inSync && diff > c.config.MaxBlocksBehind {
+		return fmt.Errorf("shard %d is %d blocks behind the peers at %d, max %d", shardID, diff, target, c.config.MaxBlocksBehind)
+	}
+	return nil
+}
+
+func (c *Checker) checkPeers() error {
+	if peers := c.backend.ConnectedPeers(); peers < c.config.MinPeers {
+		return fmt.Errorf("%d connected peers, min %d", peers, c.config.MinPeers)
+	}
+	return nil
+}
+
+func (c *Checker) checkConsensus() error {
+	elected, signed, err := c.backend.SignedHead()
+	if err != nil {
+		return err
+	}
+	if elected && !signed {
+		return errors.New("no key of the node signed the latest block")
+	}
+	return nil
+}
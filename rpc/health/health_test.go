@@ -0,0 +1,177 @@
+package health
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type testBackend struct {
+	shardID   uint32
+	head      uint64
+	headTime  time.Time
+	behind    map[uint32]uint64
+	peers     int
+	validator bool
+	elected   bool
+	signed    bool
+	dbErr     error
+}
+
+func (b *testBackend) ShardID() uint32 { return b.shardID }
+
+func (b *testBackend) Head() (uint64, time.Time) { return b.head, b.headTime }
+
+func (b *testBackend) SyncStatus(shardID uint32) (bool, uint64, uint64) {
+	diff := b.behind[shardID]
+	return diff == 0, b.head + diff, diff
+}
+
+func (b *testBackend) ConnectedPeers() int { return b.peers }
+
+func (b *testBackend) IsValidator() bool { return b.validator }
+
+func (b *testBackend) SignedHead() (bool, bool, error) { return b.elected, b.signed, nil }
+
+func (b *testBackend) ProbeDB() error { return b.dbErr }
+
+var (
+	testNow    = time.Unix(1700000000, 0)
+	testConfig = Config{
+		MaxHeadAge:      time.Minute,
+		MaxBlocksBehind: 10,
+		MinPeers:        3,
+		Consensus:       true,
+		DBWritable:      true,
+	}
+)
+
+func newTestBackend() *testBackend {
+	return &testBackend{
+		shardID:   1,
+		head:      100,
+		headTime:  testNow.Add(-10 * time.Second),
+		behind:    map[uint32]uint64{},
+		peers:     5,
+		validator: true,
+		elected:   true,
+		signed:    true,
+	}
+}
+
+func newTestChecker(b *testBackend, config Config) *Checker {
+	c := NewChecker(b, config)
+	c.now = func() time.Time { return testNow }
+	return c
+}
+
+func TestReady(t *testing.T) {
+	tests := []struct {
+		update func(b *testBackend)
+		failed string
+	}{
+		{
+			update: func(b *testBackend) {},
+		},
+		{
+			update: func(b *testBackend) { b.headTime = testNow.Add(-2 * time.Minute) },
+			failed: CheckHeadAge,
+		},
+		{
+			update: func(b *testBackend) { b.behind[1] = 10 },
+		},
+		{
+			update: func(b *testBackend) { b.behind[1] = 11 },
+			failed: CheckSync,
+		},
+		{
+			update: func(b *testBackend) { b.behind[0] = 100 },
+			failed: CheckBeaconSync,
+		},
+		{
+			update: func(b *testBackend) { b.peers = 2 },
+			failed: CheckPeers,
+		},
+		{
+			update: func(b *testBackend) { b.signed = false },
+			failed: CheckConsensus,
+		},
+		{
+			update: func(b *testBackend) { b.elected, b.signed = false, false },
+		},
+		{
+			update: func(b *testBackend) { b.validator, b.signed = false, false },
+		},
+		{
+			update: func(b *testBackend) { b.dbErr = errors.New("read-only") },
+			failed: CheckDB,
+		},
+	}
+	for i, test := range tests {
+		b := newTestBackend()
+		test.update(b)
+		r := newTestChecker(b, testConfig).Ready()
+
+		if r.OK() != (test.failed == "") {
+			t.Errorf("Test %v: unexpected status %v: %+v", i, r.Status, r.Checks)
+		}
+		for name, result := range r.Checks {
+			if (result.Status == statusFail) != (name == test.failed) {
+				t.Errorf("Test %v: unexpected check %v result %+v", i, name, result)
+			}
+		}
+	}
+}
+
+func TestReadyChecks(t *testing.T) {
+	b := newTestBackend()
+	r := newTestChecker(b, testConfig).Ready()
+	for _, name := range []string{CheckDB, CheckHeadAge, CheckSync, CheckBeaconSync, CheckPeers, CheckConsensus} {
+		if _, ok := r.Checks[name]; !ok {
+			t.Errorf("missing check %v", name)
+		}
+	}
+
+	b.shardID = 0
+	r = newTestChecker(b, Config{}).Ready()
+	if len(r.Checks) != 1 || r.Checks[CheckSync].Status != statusOK {
+		t.Errorf("unexpected checks of the beacon shard without limits: %+v", r.Checks)
+	}
+}
+
+func TestHandlers(t *testing.T) {
+	b := newTestBackend()
+	b.headTime = testNow.Add(-time.Hour)
+	handlers := newTestChecker(b, testConfig).Handlers()
+
+	tests := []struct {
+		path   string
+		method string
+		status int
+	}{
+		{HealthPath, http.MethodGet, http.StatusOK},
+		{ReadyPath, http.MethodGet, http.StatusServiceUnavailable},
+		{ReadyPath, http.MethodHead, http.StatusServiceUnavailable},
+		{HealthPath, http.MethodPost, http.StatusMethodNotAllowed},
+	}
+	for i, test := range tests {
+		w := httptest.NewRecorder()
+		handlers[test.path].ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+		if w.Code != test.status {
+			t.Errorf("Test %v: status %v, want %v", i, w.Code, test.status)
+		}
+		if test.method != http.MethodGet || w.Code == http.StatusMethodNotAllowed {
+			continue
+		}
+		var r Report
+		if err := json.Unmarshal(w.Body.Bytes(), &r); err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		if r.ShardID != 1 || r.BlockNumber != 100 || r.OK() != (test.status == http.StatusOK) {
+			t.Errorf("Test %v: unexpected report %+v", i, r)
+		}
+	}
+}
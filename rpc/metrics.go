@@ -41,10 +41,12 @@ const (
 	SetNodeToBackupMode      = "SetNodeToBackupMode"
 
 	// contract
-	GetCode      = "GetCode"
-	GetStorageAt = "GetStorageAt"
-	Call         = "Call"
-	DoEvmCall    = "DoEVMCall"
+	GetCode          = "GetCode"
+	GetStorageAt     = "GetStorageAt"
+	Call             = "Call"
+	DoEvmCall        = "DoEVMCall"
+	SimulateV1       = "SimulateV1"
+	CreateAccessList = "CreateAccessList"
 
 	// net
 	PeerCount  = "PeerCount"
@@ -90,8 +92,12 @@ const (
 	TraceCall          = "TraceCall"
 
 	// tracer parity
-	Block       = "Block"
-	Transaction = "Transaction"
+	Block                   = "Block"
+	Transaction             = "Transaction"
+	Filter                  = "Filter"
+	ReplayBlockTransactions = "ReplayBlockTransactions"
+	ParityCall              = "ParityCall"
+	ParityCallMany          = "ParityCallMany"
 
 	// transaction
 	GetAccountNonce                            = "GetAccountNonce"
@@ -103,6 +109,7 @@ const (
 	GetStakingTransactionByHash                = "GetStakingTransactionByHash"
 	GetTransactionsHistory                     = "GetTransactionsHistory"
 	GetStakingTransactionsHistory              = "GetStakingTransactionsHistory"
+	GetInternalTransactionsHistory             = "GetInternalTransactionsHistory"
 	GetBlockTransactionCountByNumber           = "GetBlockTransactionCountByNumber"
 	GetBlockTransactionCountByHash             = "GetBlockTransactionCountByHash"
 	GetTransactionByBlockNumberAndIndex        = "GetTransactionByBlockNumberAndIndex"
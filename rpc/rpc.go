@@ -3,6 +3,7 @@ package rpc
 import (
 	"fmt"
 	"net"
+	"net/http"
 	"strings"
 
 	"github.com/harmony-one/harmony/eth/rpc"
@@ -11,6 +12,7 @@ import (
 	nodeconfig "github.com/harmony-one/harmony/internal/configs/node"
 	"github.com/harmony-one/harmony/internal/utils"
 	eth "github.com/harmony-one/harmony/rpc/eth"
+	"github.com/harmony-one/harmony/rpc/health"
 	v1 "github.com/harmony-one/harmony/rpc/v1"
 	v2 "github.com/harmony-one/harmony/rpc/v2"
 )
@@ -67,8 +69,9 @@ func (n Version) Namespace() string {
 	return HTTPModules[n]
 }
 
-// StartServers starts the http & ws servers
-func StartServers(hmy *hmy.Harmony, apis []rpc.API, config nodeconfig.RPCServerConfig, rpcOpt harmony.RpcOptConfig) error {
+// StartServers starts the http & ws servers, with the given handlers served
+// next to the RPC server of the HTTP endpoint.
+func StartServers(hmy *hmy.Harmony, apis []rpc.API, handlers map[string]http.Handler, config nodeconfig.RPCServerConfig, rpcOpt harmony.RpcOptConfig) error {
 	apis = append(apis, getAPIs(hmy, config)...)
 	authApis := append(apis, getAuthAPIs(hmy, config.DebugEnabled, config.RateLimiterEnabled, config.RequestsPerSecond)...)
 	if rpcOpt.PreimagesEnabled {
@@ -84,6 +87,14 @@ func StartServers(hmy *hmy.Harmony, apis []rpc.API, config nodeconfig.RPCServerC
 	} else {
 		rmf.ExposeAll()
 	}
+	limits := rpc.NewRequestLimits(rpc.LimitsConfig{
+		ClientRequestsPerSecond: config.ClientRequestsPerSecond,
+		APIKeyRequestsPerSecond: config.APIKeyRequestsPerSecond,
+		APIKeys:                 config.APIKeys,
+		MethodCosts:             config.MethodCosts,
+		BatchItemLimit:          config.BatchItemLimit,
+		BatchResponseMaxSize:    config.BatchResponseMaxSize,
+	})
 	if config.HTTPEnabled {
 		timeouts := rpc.HTTPTimeouts{
 			ReadTimeout:  config.HTTPTimeoutRead,
@@ -91,24 +102,32 @@ func StartServers(hmy *hmy.Harmony, apis []rpc.API, config nodeconfig.RPCServerC
 			IdleTimeout:  config.HTTPTimeoutIdle,
 		}
 		httpEndpoint = fmt.Sprintf("%v:%v", config.HTTPIp, config.HTTPPort)
-		if err := startHTTP(apis, &rmf, timeouts); err != nil {
+		if err := startHTTP(apis, &rmf, timeouts, limits, getHTTPHandlers(hmy, handlers, config, limits)); err != nil {
 			return err
 		}
 
 		httpAuthEndpoint = fmt.Sprintf("%v:%v", config.HTTPIp, config.HTTPAuthPort)
-		if err := startAuthHTTP(authApis, &rmf, timeouts); err != nil {
+		jwtSecret, err := obtainAuthJWTSecret(config.HTTPAuthJWTSecretFile)
+		if err != nil {
+			return err
+		}
+		if err := startAuthHTTP(authApis, &rmf, timeouts, limits, jwtSecret); err != nil {
 			return err
 		}
 	}
 
 	if config.WSEnabled {
 		wsEndpoint = fmt.Sprintf("%v:%v", config.WSIp, config.WSPort)
-		if err := startWS(apis, &rmf); err != nil {
+		if err := startWS(apis, &rmf, limits); err != nil {
 			return err
 		}
 
 		wsAuthEndpoint = fmt.Sprintf("%v:%v", config.WSIp, config.WSAuthPort)
-		if err := startAuthWS(authApis, &rmf); err != nil {
+		jwtSecret, err := obtainAuthJWTSecret(config.WSAuthJWTSecretFile)
+		if err != nil {
+			return err
+		}
+		if err := startAuthWS(authApis, &rmf, limits, jwtSecret); err != nil {
 			return err
 		}
 	}
@@ -214,9 +233,43 @@ func getAPIs(hmy *hmy.Harmony, config nodeconfig.RPCServerConfig) []rpc.API {
 	return publicAPIs
 }
 
-func startHTTP(apis []rpc.API, rmf *rpc.RpcMethodFilter, httpTimeouts rpc.HTTPTimeouts) (err error) {
+// getHTTPHandlers returns the handlers served next to the RPC server of the
+// HTTP endpoint: the given ones, limited as calls to the method named after
+// their path, and the health checks.
+func getHTTPHandlers(hmy *hmy.Harmony, extra map[string]http.Handler, config nodeconfig.RPCServerConfig, limits *rpc.RequestLimits) map[string]http.Handler {
+	handlers := make(map[string]http.Handler, len(extra))
+	for path, handler := range extra {
+		handlers[path] = limits.Handler(strings.TrimPrefix(path, "/"), handler)
+	}
+	if config.HealthEnabled {
+		checker := health.NewChecker(healthBackend{hmy: hmy}, health.Config{
+			MaxHeadAge:      config.HealthMaxHeadAge,
+			MaxBlocksBehind: config.HealthMaxBlocksBehind,
+			MinPeers:        config.HealthMinPeers,
+			Consensus:       config.HealthConsensus,
+			DBWritable:      config.HealthDBWritable,
+		})
+		for path, handler := range checker.Handlers() {
+			handlers[path] = handler
+		}
+	}
+	return handlers
+}
+
+// obtainAuthJWTSecret returns the JWT secret of an auth endpoint, or nil if
+// no secret file is configured and the endpoint is not authenticated.
+func obtainAuthJWTSecret(fileName string) ([]byte, error) {
+	fileName = strings.TrimSpace(fileName)
+	if len(fileName) == 0 {
+		utils.Logger().Warn().Msg("No JWT secret file configured, the auth endpoint is not authenticated")
+		return nil, nil
+	}
+	return rpc.ObtainJWTSecret(fileName)
+}
+
+func startHTTP(apis []rpc.API, rmf *rpc.RpcMethodFilter, httpTimeouts rpc.HTTPTimeouts, limits *rpc.RequestLimits, handlers map[string]http.Handler) (err error) {
 	httpListener, httpHandler, err = rpc.StartHTTPEndpoint(
-		httpEndpoint, apis, HTTPModules, rmf, httpOrigins, httpVirtualHosts, httpTimeouts,
+		httpEndpoint, apis, HTTPModules, rmf, httpOrigins, httpVirtualHosts, httpTimeouts, limits, nil, handlers,
 	)
 	if err != nil {
 		return err
@@ -231,9 +284,9 @@ func startHTTP(apis []rpc.API, rmf *rpc.RpcMethodFilter, httpTimeouts rpc.HTTPTi
 	return nil
 }
 
-func startAuthHTTP(apis []rpc.API, rmf *rpc.RpcMethodFilter, httpTimeouts rpc.HTTPTimeouts) (err error) {
+func startAuthHTTP(apis []rpc.API, rmf *rpc.RpcMethodFilter, httpTimeouts rpc.HTTPTimeouts, limits *rpc.RequestLimits, jwtSecret []byte) (err error) {
 	httpListener, httpHandler, err = rpc.StartHTTPEndpoint(
-		httpAuthEndpoint, apis, HTTPModules, rmf, httpOrigins, httpVirtualHosts, httpTimeouts,
+		httpAuthEndpoint, apis, HTTPModules, rmf, httpOrigins, httpVirtualHosts, httpTimeouts, limits, jwtSecret, nil,
 	)
 	if err != nil {
 		return err
@@ -243,13 +296,14 @@ func startAuthHTTP(apis []rpc.API, rmf *rpc.RpcMethodFilter, httpTimeouts rpc.HT
 		Str("url", fmt.Sprintf("http://%s", httpAuthEndpoint)).
 		Str("cors", strings.Join(httpOrigins, ",")).
 		Str("vhosts", strings.Join(httpVirtualHosts, ",")).
+		Bool("jwt", len(jwtSecret) > 0).
 		Msg("HTTP endpoint opened")
 	fmt.Printf("Started Auth-RPC server at: %v\n", httpAuthEndpoint)
 	return nil
 }
 
-func startWS(apis []rpc.API, rmf *rpc.RpcMethodFilter) (err error) {
-	wsListener, wsHandler, err = rpc.StartWSEndpoint(wsEndpoint, apis, WSModules, rmf, wsOrigins, true)
+func startWS(apis []rpc.API, rmf *rpc.RpcMethodFilter, limits *rpc.RequestLimits) (err error) {
+	wsListener, wsHandler, err = rpc.StartWSEndpoint(wsEndpoint, apis, WSModules, rmf, wsOrigins, true, limits, nil)
 	if err != nil {
 		return err
 	}
@@ -261,14 +315,15 @@ func startWS(apis []rpc.API, rmf *rpc.RpcMethodFilter) (err error) {
 	return nil
 }
 
-func startAuthWS(apis []rpc.API, rmf *rpc.RpcMethodFilter) (err error) {
-	wsListener, wsHandler, err = rpc.StartWSEndpoint(wsAuthEndpoint, apis, WSModules, rmf, wsOrigins, true)
+func startAuthWS(apis []rpc.API, rmf *rpc.RpcMethodFilter, limits *rpc.RequestLimits, jwtSecret []byte) (err error) {
+	wsListener, wsHandler, err = rpc.StartWSEndpoint(wsAuthEndpoint, apis, WSModules, rmf, wsOrigins, true, limits, jwtSecret)
 	if err != nil {
 		return err
 	}
 
 	utils.Logger().Info().
 		Str("url", fmt.Sprintf("ws://%s", wsListener.Addr())).
+		Bool("jwt", len(jwtSecret) > 0).
 		Msg("WebSocket endpoint opened")
 	fmt.Printf("Started Auth-WS server at: %v\n", wsAuthEndpoint)
 	return nil
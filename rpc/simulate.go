@@ -0,0 +1,449 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/harmony-one/harmony/block"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/types"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/eth/rpc"
+	"github.com/harmony-one/harmony/hmy"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maxSimulateBlocks is the maximum number of blocks simulated by a single
+	// eth_simulateV1 request
+	maxSimulateBlocks = 256
+
+	// simulateBlockTime is the number of seconds between two simulated blocks
+	// when their timestamp is not overridden
+	simulateBlockTime = 2
+
+	// errCodeVMError is the JSON error code of a simulated call failing for
+	// another reason than a revert
+	errCodeVMError = -32015
+)
+
+var (
+	// transferLogAddress is the address of the pseudo logs of native token
+	// transfers, as used by go-ethereum
+	transferLogAddress = common.HexToAddress("0xEeeeeEeeeEeEeeEeEeEeeEEEeeeeEeeeeeeeEEeE")
+	// transferTopic is the topic of the ERC20 Transfer event, used by the
+	// pseudo logs of native token transfers
+	transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+)
+
+// SimOpts are the arguments of eth_simulateV1.
+type SimOpts struct {
+	BlockStateCalls []SimBlock `json:"blockStateCalls"`
+	TraceTransfers  bool       `json:"traceTransfers"`
+	Validation      bool       `json:"validation"`
+}
+
+// SimBlock is a simulated block, with the overrides applied before its calls.
+type SimBlock struct {
+	BlockOverrides *BlockOverrides `json:"blockOverrides"`
+	StateOverrides *StateOverride  `json:"stateOverrides"`
+	Calls          []SimCallArgs   `json:"calls"`
+}
+
+// SimCallArgs are the arguments of a simulated call. The nonce is only
+// checked when validation is enabled, and defaults to the nonce of the sender.
+type SimCallArgs struct {
+	CallArgs
+	Nonce *hexutil.Uint64 `json:"nonce"`
+}
+
+// SimBlockResult is the result of a simulated block.
+type SimBlockResult struct {
+	Number        hexutil.Uint64   `json:"number"`
+	Hash          common.Hash      `json:"hash"`
+	ParentHash    common.Hash      `json:"parentHash"`
+	Timestamp     hexutil.Uint64   `json:"timestamp"`
+	GasLimit      hexutil.Uint64   `json:"gasLimit"`
+	GasUsed       hexutil.Uint64   `json:"gasUsed"`
+	Miner         common.Address   `json:"miner"`
+	BaseFeePerGas *hexutil.Big     `json:"baseFeePerGas,omitempty"`
+	Calls         []*SimCallResult `json:"calls"`
+}
+
+// SimCallResult is the result of a simulated call.
+type SimCallResult struct {
+	ReturnData hexutil.Bytes  `json:"returnData"`
+	Logs       []*types.Log   `json:"logs"`
+	GasUsed    hexutil.Uint64 `json:"gasUsed"`
+	Status     hexutil.Uint64 `json:"status"`
+	Error      *SimCallError  `json:"error,omitempty"`
+}
+
+// SimCallError is the error of a failed simulated call.
+type SimCallError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+	Data    string `json:"data,omitempty"`
+}
+
+// SimulateV1 executes the calls of a series of simulated blocks on top of the
+// given block, the latest one by default. Every block and call sees the state
+// changes of the ones before it, and nothing is broadcast.
+func (s *PublicContractService) SimulateV1(
+	ctx context.Context, opts SimOpts, blockNrOrHash *rpc.BlockNumberOrHash,
+) ([]*SimBlockResult, error) {
+	timer := DoMetricRPCRequest(SimulateV1)
+	defer DoRPCRequestDuration(SimulateV1, timer)
+
+	err := s.wait(s.limiterCall, ctx)
+	if err != nil {
+		DoMetricRPCQueryInfo(SimulateV1, RateLimitedNumber)
+		return nil, err
+	}
+	if len(opts.BlockStateCalls) == 0 {
+		DoMetricRPCQueryInfo(SimulateV1, FailedNumber)
+		return nil, errors.New("empty input")
+	}
+	if len(opts.BlockStateCalls) > maxSimulateBlocks {
+		DoMetricRPCQueryInfo(SimulateV1, FailedNumber)
+		return nil, fmt.Errorf("too many blocks: %d, the limit is %d", len(opts.BlockStateCalls), maxSimulateBlocks)
+	}
+
+	// Fetch state
+	bNrOrHash := rpc.BlockNumberOrHashWithNumber(rpc.LatestBlockNumber)
+	if blockNrOrHash != nil {
+		bNrOrHash = *blockNrOrHash
+	}
+	state, header, err := s.hmy.StateAndHeaderByNumberOrHash(ctx, bNrOrHash)
+	if state == nil || err != nil {
+		DoMetricRPCQueryInfo(SimulateV1, FailedNumber)
+		return nil, err
+	}
+
+	// Setup context so it may be cancelled when the simulation has completed
+	// or, in case of unmetered gas, setup a context with a timeout.
+	var cancel context.CancelFunc
+	if s.evmCallTimeout > 0 {
+		ctx, cancel = context.WithTimeout(ctx, s.evmCallTimeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	defer cancel()
+
+	sim := &simulator{
+		hmy:            s.hmy,
+		state:          state,
+		base:           header,
+		traceTransfers: opts.TraceTransfers,
+		validation:     opts.Validation,
+		timeout:        s.evmCallTimeout,
+	}
+	results, err := sim.execute(ctx, opts.BlockStateCalls)
+	if err != nil {
+		DoMetricRPCQueryInfo(SimulateV1, FailedNumber)
+		return nil, err
+	}
+	return results, nil
+}
+
+// simulator runs the simulated blocks of eth_simulateV1 on top of base.
+type simulator struct {
+	hmy            *hmy.Harmony
+	state          *state.DB
+	base           *block.Header
+	traceTransfers bool
+	validation     bool
+	timeout        time.Duration
+
+	// hashes are the hashes of the base block and of the simulated blocks,
+	// served to BLOCKHASH before the ones of the chain
+	hashes  map[uint64]common.Hash
+	getHash vm.GetHashFunc
+}
+
+// execute runs the simulated blocks one after the other.
+func (sim *simulator) execute(ctx context.Context, blocks []SimBlock) ([]*SimBlockResult, error) {
+	var (
+		config    = sim.hmy.BlockChain.Config()
+		factory   = blockfactory.NewFactory(config)
+		results   = make([]*SimBlockResult, 0, len(blocks))
+		parent    = sim.base
+		chainHash = core.GetHashFn(sim.base, sim.hmy.BlockChain)
+	)
+	sim.hashes = map[uint64]common.Hash{parent.Number().Uint64(): parent.Hash()}
+	sim.getHash = func(n uint64) common.Hash {
+		if hash, ok := sim.hashes[n]; ok {
+			return hash
+		}
+		return chainHash(n)
+	}
+	for i := range blocks {
+		// Each block follows its parent unless overridden, and the
+		// overrides can not go back in time
+		blockCtx := vm.Context{
+			BlockNumber: new(big.Int).Add(parent.Number(), common.Big1),
+			Time:        new(big.Int).Add(parent.Time(), big.NewInt(simulateBlockTime)),
+			GasLimit:    parent.GasLimit(),
+			Coinbase:    parent.Coinbase(),
+		}
+		if config.IsLondon(parent.Epoch()) {
+			blockCtx.BaseFee = core.CalcBaseFee(config, parent)
+		}
+		blocks[i].BlockOverrides.Apply(&blockCtx)
+		if blockCtx.BlockNumber.Cmp(parent.Number()) <= 0 {
+			return nil, fmt.Errorf("block numbers must be in order: %v <= %v", blockCtx.BlockNumber, parent.Number())
+		}
+		if blockCtx.Time.Cmp(parent.Time()) <= 0 {
+			return nil, fmt.Errorf("block timestamps must be in order: %v <= %v", blockCtx.Time, parent.Time())
+		}
+		if err := blocks[i].StateOverrides.Apply(sim.state); err != nil {
+			return nil, err
+		}
+		result, err := sim.processBlock(ctx, &blockCtx, blocks[i].Calls)
+		if err != nil {
+			return nil, fmt.Errorf("block %d: %v", i, err)
+		}
+
+		// The header of the block is the parent of the next one, its base
+		// fee following the gas used by the calls
+		header := factory.NewHeader(parent.Epoch()).With().
+			ParentHash(parent.Hash()).
+			Number(blockCtx.BlockNumber).
+			Time(blockCtx.Time).
+			GasLimit(blockCtx.GasLimit).
+			GasUsed(uint64(result.GasUsed)).
+			Coinbase(blockCtx.Coinbase).
+			ShardID(parent.ShardID()).
+			Header()
+		if blockCtx.BaseFee != nil {
+			header.SetBaseFee(blockCtx.BaseFee)
+		}
+		result.Hash, result.ParentHash = header.Hash(), header.ParentHash()
+		sim.hashes[header.Number().Uint64()] = result.Hash
+		results = append(results, result)
+		parent = header
+	}
+	return results, nil
+}
+
+// processBlock runs the calls of a simulated block.
+func (sim *simulator) processBlock(ctx context.Context, blockCtx *vm.Context, calls []SimCallArgs) (*SimBlockResult, error) {
+	var (
+		number  = blockCtx.BlockNumber.Uint64()
+		gp      = new(core.GasPool).AddGas(blockCtx.GasLimit)
+		gasUsed uint64
+		result  = &SimBlockResult{
+			Number:    hexutil.Uint64(number),
+			Timestamp: hexutil.Uint64(blockCtx.Time.Uint64()),
+			GasLimit:  hexutil.Uint64(blockCtx.GasLimit),
+			Miner:     blockCtx.Coinbase,
+			Calls:     make([]*SimCallResult, 0, len(calls)),
+		}
+	)
+	if blockCtx.BaseFee != nil {
+		result.BaseFeePerGas = (*hexutil.Big)(blockCtx.BaseFee)
+	}
+	for i, call := range calls {
+		msg, err := sim.toMessage(call, gp.Gas(), blockCtx.BaseFee)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %v", i, err)
+		}
+		// The simulated calls have no transaction, derive a hash to key
+		// their logs with
+		txHash := crypto.Keccak256Hash(blockCtx.BlockNumber.Bytes(), big.NewInt(int64(i)).Bytes())
+		sim.state.Prepare(txHash, common.Hash{}, i)
+
+		vmCtx := core.NewEVMContext(msg, sim.base, sim.hmy.BlockChain, nil)
+		vmCtx.GetHash = sim.getHash
+		vmCtx.BlockNumber = blockCtx.BlockNumber
+		vmCtx.Time = blockCtx.Time
+		vmCtx.GasLimit = blockCtx.GasLimit
+		vmCtx.Coinbase = blockCtx.Coinbase
+		vmCtx.BaseFee = blockCtx.BaseFee
+
+		vmConfig := *sim.hmy.BlockChain.GetVMConfig()
+		vmConfig.NoBaseFee = !sim.validation
+		var tracer *transferTracer
+		if sim.traceTransfers {
+			tracer = &transferTracer{state: sim.state, txHash: txHash, blockNumber: number}
+			vmConfig.Debug, vmConfig.Tracer = true, tracer
+		}
+		evm := vm.NewEVM(vmCtx, sim.state, sim.hmy.BlockChain.Config(), vmConfig)
+
+		// Wait for the context to be done and cancel the evm. Even if the
+		// EVM has finished, cancelling may be done (repeatedly)
+		go func() {
+			<-ctx.Done()
+			evm.Cancel()
+		}()
+		res, err := core.ApplyMessage(evm, msg, gp)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %v", i, err)
+		}
+		// If the timer caused an abort, return an appropriate error message
+		if evm.Cancelled() {
+			return nil, fmt.Errorf("execution aborted (timeout = %v)", sim.timeout)
+		}
+		// Finalize the state so the next call sees the changes of this one
+		sim.state.Finalise(true)
+		gasUsed += res.UsedGas
+
+		callResult := &SimCallResult{
+			ReturnData: res.ReturnData,
+			Logs:       sim.state.GetLogs(txHash, number, common.Hash{}),
+			GasUsed:    hexutil.Uint64(res.UsedGas),
+			Status:     hexutil.Uint64(types.ReceiptStatusSuccessful),
+		}
+		if tracer != nil {
+			callResult.Logs = tracer.withTransfers(callResult.Logs)
+		}
+		if callResult.Logs == nil {
+			callResult.Logs = []*types.Log{}
+		}
+		if res.Failed() {
+			callResult.Status = hexutil.Uint64(types.ReceiptStatusFailed)
+			callResult.Error = &SimCallError{Code: errCodeVMError, Message: res.VMErr.Error()}
+			if len(res.Revert()) > 0 {
+				revertErr := newRevertError(&res)
+				callResult.Error = &SimCallError{Code: revertErr.ErrorCode(), Message: revertErr.Error(), Data: revertErr.reason}
+			}
+		}
+		result.Calls = append(result.Calls, callResult)
+	}
+	result.GasUsed = hexutil.Uint64(gasUsed)
+	return result, nil
+}
+
+// toMessage converts a simulated call into a message, using the gas left in
+// the block if the call sets none. The nonce of the message is checked if
+// validation is enabled.
+func (sim *simulator) toMessage(call SimCallArgs, gasLeft uint64, baseFee *big.Int) (types.Message, error) {
+	if call.Gas == nil {
+		gas := hexutil.Uint64(gasLeft)
+		call.Gas = &gas
+	}
+	msg, err := call.ToMessage(sim.hmy.RPCGasCap, baseFee)
+	if err != nil || !sim.validation {
+		return msg, err
+	}
+	nonce := sim.state.GetNonce(msg.From())
+	if call.Nonce != nil {
+		nonce = uint64(*call.Nonce)
+	}
+	checked := types.NewMessage(msg.From(), msg.To(), nonce, msg.Value(), msg.Gas(), msg.GasPrice(), msg.Data(), true)
+	checked.SetGasFees(msg.GasPrice(), msg.GasFeeCap(), msg.GasTipCap())
+	checked.SetAccessList(msg.AccessList())
+	return checked, nil
+}
+
+// transferLog is a pseudo log of a native token transfer, along with the
+// number of logs emitted by the call before it.
+type transferLog struct {
+	position int
+	log      *types.Log
+}
+
+// transferTracer records the native token transfers of a simulated call as
+// pseudo ERC20 Transfer logs, dropping the ones of reverted frames.
+type transferTracer struct {
+	state       *state.DB
+	txHash      common.Hash
+	blockNumber uint64
+
+	transfers []transferLog
+	frames    []int // number of transfers at the start of each open frame
+}
+
+func (t *transferTracer) transfer(from, to common.Address, value *big.Int) {
+	if value == nil || value.Sign() == 0 {
+		return
+	}
+	t.transfers = append(t.transfers, transferLog{
+		position: len(t.state.GetLogs(t.txHash, t.blockNumber, common.Hash{})),
+		log: &types.Log{
+			Address: transferLogAddress,
+			Topics:  []common.Hash{transferTopic, common.BytesToHash(from.Bytes()), common.BytesToHash(to.Bytes())},
+			Data:    common.BigToHash(value).Bytes(),
+		},
+	})
+}
+
+// withTransfers merges the transfer logs into the logs of the call, in the
+// order they were emitted.
+func (t *transferTracer) withTransfers(logs []*types.Log) []*types.Log {
+	if len(t.transfers) == 0 {
+		return logs
+	}
+	merged := make([]*types.Log, 0, len(logs)+len(t.transfers))
+	next := 0
+	for _, transfer := range t.transfers {
+		for ; next < transfer.position && next < len(logs); next++ {
+			merged = append(merged, logs[next])
+		}
+		log := transfer.log
+		log.BlockNumber = t.blockNumber
+		log.TxHash = t.txHash
+		log.TxIndex = uint(t.state.TxIndex())
+		merged = append(merged, log)
+	}
+	merged = append(merged, logs[next:]...)
+	for i, log := range merged {
+		log.Index = uint(i)
+	}
+	return merged
+}
+
+// CaptureStart implements the vm.Tracer interface to record the value of the
+// outermost frame.
+func (t *transferTracer) CaptureStart(env *vm.EVM, from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	t.frames = append(t.frames[:0], 0)
+	t.transfer(from, to, value)
+	return nil
+}
+
+// CaptureState implements the vm.Tracer interface to record the balance sent
+// by self destructs.
+func (t *transferTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) (vm.HookAfter, error) {
+	if op == vm.SELFDESTRUCT && err == nil {
+		t.transfer(contract.Address(), common.BigToAddress(stack.Back(0)), env.StateDB.GetBalance(contract.Address()))
+	}
+	return nil, nil
+}
+
+// CaptureFault implements the vm.Tracer interface.
+func (t *transferTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd implements the vm.Tracer interface to drop the transfers of a
+// failed call.
+func (t *transferTracer) CaptureEnd(output []byte, gasUsed uint64, d time.Duration, err error) error {
+	if err != nil {
+		t.transfers = t.transfers[:0]
+	}
+	return nil
+}
+
+// CaptureEnter implements the vm.CallFrameTracer interface to record the
+// value of a frame.
+func (t *transferTracer) CaptureEnter(typ vm.OpCode, from common.Address, to common.Address, input []byte, gas uint64, value *big.Int) {
+	t.frames = append(t.frames, len(t.transfers))
+	t.transfer(from, to, value)
+}
+
+// CaptureExit implements the vm.CallFrameTracer interface to drop the
+// transfers of a failed frame.
+func (t *transferTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	start := t.frames[len(t.frames)-1]
+	t.frames = t.frames[:len(t.frames)-1]
+	if err != nil {
+		t.transfers = t.transfers[:start]
+	}
+}
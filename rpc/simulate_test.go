@@ -0,0 +1,228 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	blockfactory "github.com/harmony-one/harmony/block/factory"
+	"github.com/harmony-one/harmony/common/denominations"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/vm"
+	"github.com/harmony-one/harmony/internal/params"
+)
+
+var (
+	// balanceOfTo returns the balance of testTo
+	balanceOfTo = hexutil.Bytes{
+		byte(vm.PUSH2), 0x12, 0x34, byte(vm.BALANCE),
+		byte(vm.PUSH1), 0, byte(vm.MSTORE),
+		byte(vm.PUSH1), 32, byte(vm.PUSH1), 0, byte(vm.RETURN),
+	}
+
+	// parentHashes returns the hashes of the two blocks before the current one
+	parentHashes = hexutil.Bytes{
+		byte(vm.PUSH1), 1, byte(vm.NUMBER), byte(vm.SUB), byte(vm.BLOCKHASH),
+		byte(vm.PUSH1), 0, byte(vm.MSTORE),
+		byte(vm.PUSH1), 2, byte(vm.NUMBER), byte(vm.SUB), byte(vm.BLOCKHASH),
+		byte(vm.PUSH1), 32, byte(vm.MSTORE),
+		byte(vm.PUSH1), 64, byte(vm.PUSH1), 0, byte(vm.RETURN),
+	}
+)
+
+func TestSimulateV1_Chaining(t *testing.T) {
+	harmony := newTestHarmony(t)
+	s := &PublicContractService{hmy: harmony, version: Eth}
+	config := harmony.ChainConfig()
+	head := harmony.BlockChain.CurrentBlock().Header()
+	gwei := (*hexutil.Big)(big.NewInt(denominations.Nano))
+	value := (*hexutil.Big)(big.NewInt(5))
+
+	opts := SimOpts{BlockStateCalls: []SimBlock{
+		{
+			BlockOverrides: &BlockOverrides{BaseFee: gwei},
+			Calls:          []SimCallArgs{{CallArgs: CallArgs{From: &testAddr, To: &testTo, Value: value}}},
+		},
+		{
+			StateOverrides: &StateOverride{testAddr: {Code: &balanceOfTo}},
+			Calls:          []SimCallArgs{{CallArgs: CallArgs{From: &testTo, To: &testAddr}}},
+		},
+		{},
+	}}
+	results, err := s.SimulateV1(context.Background(), opts, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("unexpected number of blocks %v", len(results))
+	}
+	parentHash := head.Hash()
+	for i, result := range results {
+		if uint64(result.Number) != head.Number().Uint64()+uint64(i)+1 {
+			t.Errorf("block %v: unexpected number %v", i, result.Number)
+		}
+		if uint64(result.Timestamp) != head.Time().Uint64()+uint64(i+1)*simulateBlockTime {
+			t.Errorf("block %v: unexpected timestamp %v", i, result.Timestamp)
+		}
+		if result.ParentHash != parentHash {
+			t.Errorf("block %v: unexpected parent hash %x / %x", i, result.ParentHash, parentHash)
+		}
+		parentHash = result.Hash
+	}
+
+	// the balance sent by the first block is seen by the second one
+	if len(results[1].Calls) != 1 || results[1].Calls[0].Error != nil {
+		t.Fatalf("unexpected calls %+v", results[1].Calls)
+	}
+	if balance := new(big.Int).SetBytes(results[1].Calls[0].ReturnData); balance.Cmp(value.ToInt()) != 0 {
+		t.Errorf("have balance %v, want %v", balance, value)
+	}
+
+	// the base fee of a block follows the gas used by its parent
+	factory := blockfactory.NewFactory(config)
+	parent := factory.NewHeader(head.Epoch()).With().
+		GasLimit(head.GasLimit()).
+		GasUsed(uint64(results[0].GasUsed)).
+		BaseFee(gwei.ToInt()).
+		Header()
+	if uint64(results[0].GasUsed) != params.TxGas {
+		t.Errorf("unexpected gas used %v", results[0].GasUsed)
+	}
+	if results[1].BaseFeePerGas.ToInt().Cmp(core.CalcBaseFee(config, parent)) != 0 {
+		t.Errorf("unexpected base fee %v / %v", results[1].BaseFeePerGas, core.CalcBaseFee(config, parent))
+	}
+	if results[1].BaseFeePerGas.ToInt().Cmp(gwei.ToInt()) >= 0 {
+		t.Errorf("base fee %v not decreased", results[1].BaseFeePerGas)
+	}
+	if results[2].BaseFeePerGas.ToInt().Cmp(results[1].BaseFeePerGas.ToInt()) >= 0 {
+		t.Errorf("base fee %v not decreased after an empty block", results[2].BaseFeePerGas)
+	}
+}
+
+func TestSimulateV1_BlockOverrides(t *testing.T) {
+	harmony := newTestHarmony(t)
+	s := &PublicContractService{hmy: harmony, version: Eth}
+	head := harmony.BlockChain.CurrentBlock().Header()
+	number := (*hexutil.Big)(new(big.Int).Add(head.Number(), big.NewInt(10)))
+	time := hexutil.Uint64(head.Time().Uint64() + 100)
+	coinbase := common.HexToAddress("0xc0ffee")
+
+	opts := SimOpts{BlockStateCalls: []SimBlock{
+		{BlockOverrides: &BlockOverrides{Number: number, Time: &time, Coinbase: &coinbase}},
+		{},
+	}}
+	results, err := s.SimulateV1(context.Background(), opts, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the block after an overridden one follows it
+	if uint64(results[1].Number) != number.ToInt().Uint64()+1 || uint64(results[1].Timestamp) != uint64(time)+simulateBlockTime {
+		t.Errorf("unexpected block %v at %v", results[1].Number, results[1].Timestamp)
+	}
+	if results[0].Miner != coinbase || results[1].Miner != coinbase {
+		t.Errorf("unexpected miners %x, %x", results[0].Miner, results[1].Miner)
+	}
+	if baseFee := core.CalcBaseFee(harmony.ChainConfig(), head); results[0].BaseFeePerGas.ToInt().Cmp(baseFee) != 0 {
+		t.Errorf("unexpected base fee %v / %v", results[0].BaseFeePerGas, baseFee)
+	}
+
+	// the blocks can not go back in time
+	past := hexutil.Uint64(head.Time().Uint64())
+	tests := []struct {
+		overrides *BlockOverrides
+		err       string
+	}{
+		{&BlockOverrides{Number: (*hexutil.Big)(head.Number())}, "block numbers must be in order"},
+		{&BlockOverrides{Time: &past}, "block timestamps must be in order"},
+	}
+	for i, test := range tests {
+		opts := SimOpts{BlockStateCalls: []SimBlock{{BlockOverrides: test.overrides}}}
+		if _, err := s.SimulateV1(context.Background(), opts, nil); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("Test %v: unexpected error %v", i, err)
+		}
+	}
+}
+
+func TestSimulateV1_Validation(t *testing.T) {
+	harmony := newTestHarmony(t)
+	s := &PublicContractService{hmy: harmony, version: Eth}
+	baseFee := (*hexutil.Big)(big.NewInt(denominations.Nano))
+	nonce := hexutil.Uint64(5)
+
+	tests := []struct {
+		call SimCallArgs
+		err  string
+	}{
+		{SimCallArgs{CallArgs: CallArgs{From: &testAddr, To: &testTo}}, core.ErrFeeCapTooLow.Error()},
+		{SimCallArgs{CallArgs: CallArgs{From: &testAddr, To: &testTo, GasPrice: baseFee}, Nonce: &nonce}, core.ErrNonceTooHigh.Error()},
+	}
+	for i, test := range tests {
+		for _, validation := range []bool{false, true} {
+			opts := SimOpts{
+				BlockStateCalls: []SimBlock{{
+					BlockOverrides: &BlockOverrides{BaseFee: baseFee},
+					Calls:          []SimCallArgs{test.call},
+				}},
+				Validation: validation,
+			}
+			_, err := s.SimulateV1(context.Background(), opts, nil)
+			if validation && (err == nil || !strings.Contains(err.Error(), test.err)) {
+				t.Errorf("Test %v: unexpected error %v with validation", i, err)
+			}
+			if !validation && err != nil {
+				t.Errorf("Test %v: unexpected error %v without validation", i, err)
+			}
+		}
+	}
+
+	// the nonces of the calls with validation follow the ones before them
+	call := SimCallArgs{CallArgs: CallArgs{From: &testAddr, To: &testTo, GasPrice: baseFee}}
+	opts := SimOpts{
+		BlockStateCalls: []SimBlock{
+			{BlockOverrides: &BlockOverrides{BaseFee: baseFee}, Calls: []SimCallArgs{call, call}},
+			{Calls: []SimCallArgs{call}},
+		},
+		Validation: true,
+	}
+	if _, err := s.SimulateV1(context.Background(), opts, nil); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func TestSimulateV1_BlockHash(t *testing.T) {
+	harmony := newTestHarmony(t)
+	s := &PublicContractService{hmy: harmony, version: Eth}
+	head := harmony.BlockChain.CurrentBlock().Header()
+
+	call := SimCallArgs{CallArgs: CallArgs{From: &testAddr, To: &testTo}}
+	opts := SimOpts{BlockStateCalls: []SimBlock{
+		{StateOverrides: &StateOverride{testTo: {Code: &parentHashes}}, Calls: []SimCallArgs{call}},
+		{Calls: []SimCallArgs{call}},
+	}}
+	results, err := s.SimulateV1(context.Background(), opts, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the first block sees the chain, the second one the first block too
+	expected := [][2]common.Hash{
+		{head.Hash(), head.ParentHash()},
+		{results[0].Hash, head.Hash()},
+	}
+	for i, result := range results {
+		if len(result.Calls) != 1 || len(result.Calls[0].ReturnData) != 64 {
+			t.Fatalf("block %v: unexpected calls %+v", i, result.Calls)
+		}
+		data := result.Calls[0].ReturnData
+		for j, hash := range expected[i] {
+			if have := common.BytesToHash(data[32*j : 32*(j+1)]); have != hash {
+				t.Errorf("block %v: unexpected hash %v %x / %x", i, j+1, have, hash)
+			}
+		}
+	}
+	if results[0].Hash == (common.Hash{}) {
+		t.Error("empty hash of the simulated block")
+	}
+}
@@ -48,6 +48,14 @@ var (
 	ErrNotAvailable = errors.New("RPC not available yet")
 )
 
+// TraceCallConfig is the config for traceCall API. It holds one more
+// field to override the state for tracing.
+type TraceCallConfig struct {
+	hmy.TraceConfig
+	StateOverrides *StateOverride
+	BlockOverrides *BlockOverrides
+}
+
 // PublicTracerService provides an API to access Harmony's staking services.
 // It offers only methods that operate on public data that is freely available to anyone.
 type PublicTracerService struct {
@@ -177,7 +185,7 @@ func (s *PublicTracerService) TraceTransaction(ctx context.Context, hash common.
 // if the given transaction was added on top of the provided block and returns them as a JSON object.
 // You can provide -2 as a block number to trace on top of the pending block.
 // NOTE: Our version only supports block number as an input
-func (s *PublicTracerService) TraceCall(ctx context.Context, args CallArgs, blockNr rpc.BlockNumber, config *hmy.TraceConfig) (interface{}, error) {
+func (s *PublicTracerService) TraceCall(ctx context.Context, args CallArgs, blockNr rpc.BlockNumber, config *TraceCallConfig) (interface{}, error) {
 	timer := DoMetricRPCRequest(TraceCall)
 	defer DoRPCRequestDuration(TraceCall, timer)
 
@@ -200,11 +208,28 @@ func (s *PublicTracerService) TraceCall(ctx context.Context, args CallArgs, bloc
 			DoMetricRPCQueryInfo(TraceCall, FailedNumber)
 			return nil, err
 		}
+		header = block.Header()
+	}
+	// Apply the customized state rules if required.
+	var traceConfig *hmy.TraceConfig
+	if config != nil {
+		if err := config.StateOverrides.Apply(statedb); err != nil {
+			DoMetricRPCQueryInfo(TraceCall, FailedNumber)
+			return nil, err
+		}
+		traceConfig = &config.TraceConfig
 	}
 
 	// Execute the trace
-	msg := args.ToMessage(s.hmy.RPCGasCap)
+	msg, err := args.ToMessage(s.hmy.RPCGasCap, header.BaseFee())
+	if err != nil {
+		DoMetricRPCQueryInfo(TraceCall, FailedNumber)
+		return nil, err
+	}
 	vmctx := core.NewEVMContext(msg, header, s.hmy.BlockChain, nil)
+	if config != nil {
+		config.BlockOverrides.Apply(&vmctx)
+	}
 	// Trace the transaction and return
-	return s.hmy.TraceTx(ctx, msg, vmctx, statedb, config)
+	return s.hmy.TraceTx(ctx, msg, vmctx, statedb, traceConfig)
 }
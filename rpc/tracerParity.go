@@ -4,20 +4,65 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core"
+	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/eth/rpc"
 	"github.com/harmony-one/harmony/hmy"
+	"github.com/harmony-one/harmony/hmy/tracers"
 )
 
 var (
-	parityTraceGO = "ParityBlockTracer"
+	parityTraceGO  = "ParityBlockTracer"
+	parityReplayGO = "ParityReplayTracer"
+)
+
+const (
+	// traceFilterMaxBlocks is the maximum number of blocks scanned by a single
+	// trace_filter request
+	traceFilterMaxBlocks = 100
 )
 
 type PublicParityTracerService struct {
 	*PublicTracerService
 }
 
+// TraceFilterArgs are the arguments of trace_filter. A trace matches if its
+// sender is one of FromAddress and its receiver is one of ToAddress, an empty
+// list matching any address. After and Count paginate the matching traces.
+type TraceFilterArgs struct {
+	FromBlock   *rpc.BlockNumber `json:"fromBlock"`
+	ToBlock     *rpc.BlockNumber `json:"toBlock"`
+	FromAddress []common.Address `json:"fromAddress"`
+	ToAddress   []common.Address `json:"toAddress"`
+	After       *uint64          `json:"after"`
+	Count       *uint64          `json:"count"`
+}
+
+// TraceCallRequest is a call of trace_callMany, encoded as the pair
+// [callArgs, traceTypes].
+type TraceCallRequest struct {
+	Args       CallArgs
+	TraceTypes []string
+}
+
+// UnmarshalJSON decodes the [callArgs, traceTypes] pair of trace_callMany.
+func (r *TraceCallRequest) UnmarshalJSON(input []byte) error {
+	var pair []json.RawMessage
+	if err := json.Unmarshal(input, &pair); err != nil {
+		return err
+	}
+	if len(pair) != 2 {
+		return fmt.Errorf("expected [callArgs, traceTypes], got %d items", len(pair))
+	}
+	if err := json.Unmarshal(pair[0], &r.Args); err != nil {
+		return err
+	}
+	return json.Unmarshal(pair[1], &r.TraceTypes)
+}
+
 func (s *PublicParityTracerService) Transaction(ctx context.Context, hash common.Hash) (interface{}, error) {
 	timer := DoMetricRPCRequest(Transaction)
 	defer DoRPCRequestDuration(Transaction, timer)
@@ -33,20 +78,239 @@ func (s *PublicParityTracerService) Block(ctx context.Context, number rpc.BlockN
 	if block == nil {
 		return nil, nil
 	}
-	if results, err := s.hmy.NodeAPI.GetTraceResultByHash(block.Hash()); err == nil {
-		return results, nil
+	return s.blockTraces(ctx, block)
+}
+
+// trace_filter RPC
+func (s *PublicParityTracerService) Filter(ctx context.Context, args TraceFilterArgs) ([]json.RawMessage, error) {
+	timer := DoMetricRPCRequest(Filter)
+	defer DoRPCRequestDuration(Filter, timer)
+
+	fromBlock, toBlock := s.resolveBlockNumber(args.FromBlock), s.resolveBlockNumber(args.ToBlock)
+	if fromBlock > toBlock {
+		DoMetricRPCQueryInfo(Filter, FailedNumber)
+		return nil, fmt.Errorf("fromBlock %d is after toBlock %d", fromBlock, toBlock)
+	}
+	if toBlock-fromBlock >= traceFilterMaxBlocks {
+		DoMetricRPCQueryInfo(Filter, FailedNumber)
+		return nil, fmt.Errorf("block range is limited to %d blocks", traceFilterMaxBlocks)
+	}
+	var (
+		froms   = make(map[common.Address]struct{}, len(args.FromAddress))
+		tos     = make(map[common.Address]struct{}, len(args.ToAddress))
+		skipped uint64
+		results = make([]json.RawMessage, 0)
+	)
+	for _, addr := range args.FromAddress {
+		froms[addr] = struct{}{}
+	}
+	for _, addr := range args.ToAddress {
+		tos[addr] = struct{}{}
+	}
+	for number := fromBlock; number <= toBlock; number++ {
+		block := s.hmy.BlockChain.GetBlockByNumber(number)
+		if block == nil {
+			break
+		}
+		traces, err := s.blockTraces(ctx, block)
+		if err != nil {
+			DoMetricRPCQueryInfo(Filter, FailedNumber)
+			return nil, err
+		}
+		for _, trace := range traces {
+			from, to, err := traceAddresses(trace)
+			if err != nil {
+				DoMetricRPCQueryInfo(Filter, FailedNumber)
+				return nil, err
+			}
+			if _, ok := froms[from]; len(froms) > 0 && !ok {
+				continue
+			}
+			if _, ok := tos[to]; len(tos) > 0 && !ok {
+				continue
+			}
+			if args.After != nil && skipped < *args.After {
+				skipped++
+				continue
+			}
+			results = append(results, trace)
+			if args.Count != nil && uint64(len(results)) >= *args.Count {
+				return results, nil
+			}
+		}
+	}
+	return results, nil
+}
+
+// trace_replayBlockTransactions RPC
+func (s *PublicParityTracerService) ReplayBlockTransactions(ctx context.Context, number rpc.BlockNumber, traceTypes []string) ([]*tracers.ParityReplayResult, error) {
+	timer := DoMetricRPCRequest(ReplayBlockTransactions)
+	defer DoRPCRequestDuration(ReplayBlockTransactions, timer)
+
+	block := s.hmy.BlockChain.GetBlockByNumber(s.resolveBlockNumber(&number))
+	if block == nil {
+		return nil, nil
+	}
+	config, err := replayConfig(traceTypes)
+	if err != nil {
+		DoMetricRPCQueryInfo(ReplayBlockTransactions, FailedNumber)
+		return nil, err
+	}
+	results, err := s.hmy.TraceBlock(ctx, block, config)
+	if err != nil {
+		DoMetricRPCQueryInfo(ReplayBlockTransactions, FailedNumber)
+		return nil, err
+	}
+	replays := make([]*tracers.ParityReplayResult, 0, len(results))
+	for _, result := range results {
+		if result.Error != "" {
+			DoMetricRPCQueryInfo(ReplayBlockTransactions, FailedNumber)
+			return nil, errors.New(result.Error)
+		}
+		replay, ok := result.Result.(*tracers.ParityReplayResult)
+		if !ok {
+			DoMetricRPCQueryInfo(ReplayBlockTransactions, FailedNumber)
+			return nil, errors.New("tracer bug:expected *tracers.ParityReplayResult")
+		}
+		replays = append(replays, replay)
+	}
+	return replays, nil
+}
+
+// trace_call RPC
+func (s *PublicParityTracerService) Call(ctx context.Context, args CallArgs, traceTypes []string, blockNr *rpc.BlockNumber) (*tracers.ParityReplayResult, error) {
+	timer := DoMetricRPCRequest(ParityCall)
+	defer DoRPCRequestDuration(ParityCall, timer)
+
+	results, err := s.replayCalls(ctx, []TraceCallRequest{{Args: args, TraceTypes: traceTypes}}, blockNr)
+	if err != nil {
+		DoMetricRPCQueryInfo(ParityCall, FailedNumber)
+		return nil, err
+	}
+	return results[0], nil
+}
+
+// trace_callMany RPC
+func (s *PublicParityTracerService) CallMany(ctx context.Context, calls []TraceCallRequest, blockNr *rpc.BlockNumber) ([]*tracers.ParityReplayResult, error) {
+	timer := DoMetricRPCRequest(ParityCallMany)
+	defer DoRPCRequestDuration(ParityCallMany, timer)
+
+	results, err := s.replayCalls(ctx, calls, blockNr)
+	if err != nil {
+		DoMetricRPCQueryInfo(ParityCallMany, FailedNumber)
+		return nil, err
+	}
+	return results, nil
+}
+
+// replayCalls traces the calls one after the other on top of the state of
+// the given block, the latest one if nil, each call seeing the changes of
+// the previous ones.
+func (s *PublicParityTracerService) replayCalls(ctx context.Context, calls []TraceCallRequest, blockNr *rpc.BlockNumber) ([]*tracers.ParityReplayResult, error) {
+	number := rpc.LatestBlockNumber
+	if blockNr != nil {
+		number = *blockNr
+	}
+	statedb, header, err := s.hmy.StateAndHeaderByNumber(ctx, number)
+	if statedb == nil || err != nil {
+		return nil, fmt.Errorf("state of block %v not found: %v", number, err)
+	}
+	results := make([]*tracers.ParityReplayResult, 0, len(calls))
+	for i, call := range calls {
+		config, err := replayConfig(call.TraceTypes)
+		if err != nil {
+			return nil, err
+		}
+		msg, err := call.Args.ToMessage(s.hmy.RPCGasCap, header.BaseFee())
+		if err != nil {
+			return nil, err
+		}
+		vmctx := core.NewEVMContext(msg, header, s.hmy.BlockChain, nil)
+		res, err := s.hmy.TraceTx(ctx, msg, vmctx, statedb, config)
+		if err != nil {
+			return nil, fmt.Errorf("call %d: %v", i, err)
+		}
+		result, ok := res.(*tracers.ParityReplayResult)
+		if !ok {
+			return nil, errors.New("tracer bug:expected *tracers.ParityReplayResult")
+		}
+		results = append(results, result)
+		// Finalize the state so the next call sees the changes of this one
+		statedb.Finalise(true)
+	}
+	return results, nil
+}
+
+// blockTraces returns the parity traces of all the transactions of block,
+// from the trace result cache of the node if they are there.
+func (s *PublicParityTracerService) blockTraces(ctx context.Context, block *types.Block) ([]json.RawMessage, error) {
+	if cached, err := s.hmy.NodeAPI.GetTraceResultByHash(block.Hash()); err == nil {
+		var results []json.RawMessage
+		if err := json.Unmarshal(cached, &results); err == nil {
+			return results, nil
+		}
 	}
 	results, err := s.hmy.TraceBlock(ctx, block, &hmy.TraceConfig{Tracer: &parityTraceGO})
 	if err != nil {
-		return results, err
+		return nil, err
 	}
 	var resultArray = make([]json.RawMessage, 0)
 	for _, result := range results {
 		raw, ok := result.Result.([]json.RawMessage)
 		if !ok {
-			return results, errors.New("tracer bug:expected []json.RawMessage")
+			return nil, errors.New("tracer bug:expected []json.RawMessage")
 		}
 		resultArray = append(resultArray, raw...)
 	}
 	return resultArray, nil
 }
+
+// resolveBlockNumber returns the number of the block, the current block if
+// number is nil, latest or pending.
+func (s *PublicParityTracerService) resolveBlockNumber(number *rpc.BlockNumber) uint64 {
+	if number == nil || *number == rpc.LatestBlockNumber || *number == rpc.PendingBlockNumber {
+		return s.hmy.CurrentBlock().NumberU64()
+	}
+	return uint64(*number)
+}
+
+// replayConfig returns the trace config of the ParityReplayTracer for the
+// requested trace types.
+func replayConfig(traceTypes []string) (*hmy.TraceConfig, error) {
+	tracerConfig, err := json.Marshal(tracers.ParityReplayConfig{TraceTypes: traceTypes})
+	if err != nil {
+		return nil, err
+	}
+	return &hmy.TraceConfig{Tracer: &parityReplayGO, TracerConfig: tracerConfig}, nil
+}
+
+// traceAddresses returns the sender and the receiver of a parity trace: the
+// created contract for creations and the refund address for self destructs.
+func traceAddresses(trace json.RawMessage) (common.Address, common.Address, error) {
+	var decoded struct {
+		Action struct {
+			From          common.Address  `json:"from"`
+			To            *common.Address `json:"to"`
+			Address       common.Address  `json:"address"`
+			RefundAddress *common.Address `json:"refundAddress"`
+		} `json:"action"`
+		Result *struct {
+			Address *common.Address `json:"address"`
+		} `json:"result"`
+	}
+	if err := json.Unmarshal(trace, &decoded); err != nil {
+		return common.Address{}, common.Address{}, err
+	}
+	from, to := decoded.Action.From, common.Address{}
+	switch {
+	case decoded.Action.RefundAddress != nil:
+		from, to = decoded.Action.Address, *decoded.Action.RefundAddress
+	case decoded.Action.To != nil:
+		to = *decoded.Action.To
+	default:
+		if decoded.Result != nil && decoded.Result.Address != nil {
+			to = *decoded.Result.Address
+		}
+	}
+	return from, to, nil
+}
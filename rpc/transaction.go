@@ -164,6 +164,7 @@ func (s *PublicTransactionService) GetStakingTransactionsCount(
 // given transaction against the current pending block.
 func (s *PublicTransactionService) EstimateGas(
 	ctx context.Context, args CallArgs, blockNrOrHash *rpc.BlockNumberOrHash,
+	overrides *StateOverride, blockOverrides *BlockOverrides,
 ) (hexutil.Uint64, error) {
 	timer := DoMetricRPCRequest(RpcEstimateGas)
 	defer DoRPCRequestDuration(RpcEstimateGas, timer)
@@ -171,7 +172,7 @@ func (s *PublicTransactionService) EstimateGas(
 	if blockNrOrHash != nil {
 		bNrOrHash = *blockNrOrHash
 	}
-	gas, err := EstimateGas(ctx, s.hmy, args, bNrOrHash, nil)
+	gas, err := EstimateGas(ctx, s.hmy, args, bNrOrHash, overrides, blockOverrides, nil)
 	if err != nil {
 		return 0, err
 	}
@@ -192,7 +193,7 @@ func (s *PublicTransactionService) GetTransactionByHash(
 		// Try to return a pending transaction
 		if tx := s.hmy.TxPool.Get(hash); tx != nil {
 			if plainTx, ok := tx.(*types.Transaction); ok {
-				return s.newRPCTransaction(plainTx, common.Hash{}, 0, 0, 0)
+				return s.newRPCTransaction(plainTx, common.Hash{}, 0, 0, 0, nil)
 			}
 		}
 
@@ -213,11 +214,11 @@ func (s *PublicTransactionService) GetTransactionByHash(
 		return nil, nil
 	}
 
-	return s.newRPCTransaction(tx, blockHash, blockNumber, block.Time().Uint64(), index)
+	return s.newRPCTransaction(tx, blockHash, blockNumber, block.Time().Uint64(), index, block.BaseFee())
 }
 
 func (s *PublicTransactionService) newRPCTransaction(tx *types.Transaction, blockHash common.Hash,
-	blockNumber uint64, timestamp uint64, index uint64) (StructuredResponse, error) {
+	blockNumber uint64, timestamp uint64, index uint64, baseFee *big.Int) (StructuredResponse, error) {
 
 	// Format the response according to the version
 	switch s.version {
@@ -242,7 +243,7 @@ func (s *PublicTransactionService) newRPCTransaction(tx *types.Transaction, bloc
 			DoMetricRPCQueryInfo(GetTransactionByHash, FailedNumber)
 			return nil, err
 		}
-		tx, err := eth.NewTransaction(senderAddr, tx.ConvertToEth(), blockHash, blockNumber, timestamp, index)
+		tx, err := eth.NewTransaction(senderAddr, tx.ConvertToEth(), blockHash, blockNumber, timestamp, index, baseFee)
 		if err != nil {
 			DoMetricRPCQueryInfo(GetTransactionByHash, FailedNumber)
 			return nil, err
@@ -366,6 +367,48 @@ func (s *PublicTransactionService) GetTransactionsHistory(
 	return StructuredResponse{"transactions": txs}, nil
 }
 
+// GetInternalTransactionsHistory returns the list of internal transactions, the value transfers and
+// the contract creations done by contracts, that involve a particular address.
+func (s *PublicTransactionService) GetInternalTransactionsHistory(
+	ctx context.Context, args TxHistoryArgs,
+) (StructuredResponse, error) {
+	timer := DoMetricRPCRequest(GetInternalTransactionsHistory)
+	defer DoRPCRequestDuration(GetInternalTransactionsHistory, timer)
+
+	if s.version != V2 {
+		return nil, ErrUnknownRPCVersion
+	}
+	addr, err := internal_common.ParseAddr(args.Address)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetInternalTransactionsHistory, FailedNumber)
+		return nil, err
+	}
+	address, err := internal_common.AddressToBech32(addr)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetInternalTransactionsHistory, FailedNumber)
+		return nil, err
+	}
+	// the page is read from the indexer, not sliced from the whole history
+	itxs, err := s.hmy.GetInternalTransactionsHistory(
+		address, args.TxType, args.Order, int(args.PageIndex), int(historyPageSize(args.PageSize)),
+	)
+	if err != nil {
+		DoMetricRPCQueryInfo(GetInternalTransactionsHistory, FailedNumber)
+		return nil, err
+	}
+
+	txs := make([]*v2.InternalTransaction, 0, len(itxs))
+	for _, itx := range itxs {
+		tx, err := v2.NewInternalTransaction(itx)
+		if err != nil {
+			DoMetricRPCQueryInfo(GetInternalTransactionsHistory, FailedNumber)
+			return nil, err
+		}
+		txs = append(txs, tx)
+	}
+	return StructuredResponse{"transactions": txs}, nil
+}
+
 // GetStakingTransactionsHistory returns the list of transactions hashes that involve a particular address.
 func (s *PublicTransactionService) GetStakingTransactionsHistory(
 	ctx context.Context, args TxHistoryArgs,
@@ -774,7 +817,11 @@ func (s *PublicTransactionService) GetTransactionReceipt(
 			if err != nil {
 				return nil, err
 			}
-			RPCReceipt, err = eth.NewReceipt(senderAddr, tx.ConvertToEth(), blockHash, blockNumber, index, receipt)
+			var baseFee *big.Int
+			if header, _ := s.hmy.GetHeader(ctx, blockHash); header != nil {
+				baseFee = header.BaseFee()
+			}
+			RPCReceipt, err = eth.NewReceipt(senderAddr, tx.ConvertToEth(), blockHash, blockNumber, index, receipt, baseFee)
 		}
 		if err != nil {
 			return nil, err
@@ -832,12 +879,17 @@ func (s *PublicTransactionService) ResendCx(ctx context.Context, txID common.Has
 	return success, nil
 }
 
-// returnHashesWithPagination returns result with pagination (offset, page in TxHistoryArgs).
-func returnHashesWithPagination(hashes []common.Hash, pageIndex uint32, pageSize uint32) []common.Hash {
-	size := defaultPageSize
+// historyPageSize returns the page size of TxHistoryArgs, defaultPageSize if not set.
+func historyPageSize(pageSize uint32) uint32 {
 	if pageSize > 0 {
-		size = pageSize
+		return pageSize
 	}
+	return defaultPageSize
+}
+
+// returnHashesWithPagination returns result with pagination (offset, page in TxHistoryArgs).
+func returnHashesWithPagination(hashes []common.Hash, pageIndex uint32, pageSize uint32) []common.Hash {
+	size := historyPageSize(pageSize)
 	if uint64(size)*uint64(pageIndex) >= uint64(len(hashes)) {
 		return make([]common.Hash, 0)
 	}
@@ -848,7 +900,7 @@ func returnHashesWithPagination(hashes []common.Hash, pageIndex uint32, pageSize
 }
 
 // EstimateGas - estimate gas cost for a given operation
-func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, gasCap *big.Int) (uint64, error) {
+func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOrHash rpc.BlockNumberOrHash, overrides *StateOverride, blockOverrides *BlockOverrides, gasCap *big.Int) (uint64, error) {
 	// Binary search the gas requirement, as it may be higher than the amount used
 	var (
 		lo  uint64 = params.TxGas - 1
@@ -877,6 +929,9 @@ func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOr
 		if err != nil {
 			return 0, err
 		}
+		if err := overrides.Apply(state); err != nil {
+			return 0, err
+		}
 		balance := state.GetBalance(*args.From) // from can't be nil
 		available := new(big.Int).Set(balance)
 		if args.Value != nil {
@@ -909,7 +964,7 @@ func EstimateGas(ctx context.Context, hmy *hmy.Harmony, args CallArgs, blockNrOr
 	executable := func(gas uint64) (bool, *core.ExecutionResult, error) {
 		args.Gas = (*hexutil.Uint64)(&gas)
 
-		result, err := DoEVMCall(ctx, hmy, args, blockNrOrHash, 0)
+		result, err := DoEVMCall(ctx, hmy, args, blockNrOrHash, overrides, blockOverrides, 0)
 		if err != nil {
 			if errors.Is(err, core.ErrIntrinsicGas) {
 				return true, nil, nil // Special case, raise gas limit
@@ -7,6 +7,9 @@ import (
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/harmony-one/harmony/core/rawdb"
+	"github.com/harmony-one/harmony/core/state"
+	"github.com/harmony-one/harmony/core/vm"
 	internal_common "github.com/harmony-one/harmony/internal/common"
 	"github.com/pkg/errors"
 	"github.com/stretchr/testify/require"
@@ -94,3 +97,44 @@ func TestDelegation_IntoStructuredResponse(t *testing.T) {
 
 	require.JSONEq(t, string(js1), string(js2))
 }
+
+func TestStateOverride_Apply(t *testing.T) {
+	statedb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	statedb.SetState(testAddr1, common.HexToHash("0x01"), common.HexToHash("0x01"))
+	statedb.SetState(testAddr2, common.HexToHash("0x01"), common.HexToHash("0x01"))
+
+	var overrides StateOverride
+	require.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(`{
+		"%s": {"balance": "0x10", "nonce": "0x2", "code": "0x00", "state": {"0x0000000000000000000000000000000000000000000000000000000000000002": "0x0000000000000000000000000000000000000000000000000000000000000002"}},
+		"%s": {"stateDiff": {"0x0000000000000000000000000000000000000000000000000000000000000002": "0x0000000000000000000000000000000000000000000000000000000000000002"}}
+	}`, testAddr1.Hex(), testAddr2.Hex())), &overrides))
+	require.NoError(t, overrides.Apply(statedb))
+
+	require.Equal(t, big.NewInt(16), statedb.GetBalance(testAddr1))
+	require.Equal(t, uint64(2), statedb.GetNonce(testAddr1))
+	require.Equal(t, []byte{0}, statedb.GetCode(testAddr1))
+	// state replaces the whole storage, stateDiff only the given slots
+	require.Equal(t, common.Hash{}, statedb.GetState(testAddr1, common.HexToHash("0x01")))
+	require.Equal(t, common.HexToHash("0x02"), statedb.GetState(testAddr1, common.HexToHash("0x02")))
+	require.Equal(t, common.HexToHash("0x01"), statedb.GetState(testAddr2, common.HexToHash("0x01")))
+	require.Equal(t, common.HexToHash("0x02"), statedb.GetState(testAddr2, common.HexToHash("0x02")))
+
+	state := map[common.Hash]common.Hash{}
+	invalid := StateOverride{testAddr1: OverrideAccount{State: &state, StateDiff: &state}}
+	require.Error(t, invalid.Apply(statedb))
+}
+
+func TestBlockOverrides_Apply(t *testing.T) {
+	var overrides BlockOverrides
+	require.NoError(t, json.Unmarshal([]byte(fmt.Sprintf(
+		`{"number": "0x10", "time": "0x20", "coinbase": "%s"}`, testAddr1.Hex(),
+	)), &overrides))
+	blockCtx := vm.Context{BlockNumber: big.NewInt(1), Time: big.NewInt(1), GasLimit: 30}
+	overrides.Apply(&blockCtx)
+
+	require.Equal(t, big.NewInt(16), blockCtx.BlockNumber)
+	require.Equal(t, big.NewInt(32), blockCtx.Time)
+	require.Equal(t, uint64(30), blockCtx.GasLimit)
+	require.Equal(t, testAddr1, blockCtx.Coinbase)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/harmony-one/harmony/block"
 	"github.com/harmony-one/harmony/core/types"
 	"github.com/harmony-one/harmony/crypto/bls"
+	"github.com/harmony-one/harmony/hmy/tracers"
 	internal_common "github.com/harmony-one/harmony/internal/common"
 	staking "github.com/harmony-one/harmony/staking/types"
 )
@@ -106,24 +107,40 @@ type BlockWithFullTx struct {
 
 // Transaction represents a transaction that will serialize to the RPC representation of a transaction
 type Transaction struct {
-	BlockHash        common.Hash   `json:"blockHash"`
-	BlockNumber      *big.Int      `json:"blockNumber"`
-	From             string        `json:"from"`
-	Timestamp        uint64        `json:"timestamp"`
-	Gas              uint64        `json:"gas"`
-	GasPrice         *big.Int      `json:"gasPrice"`
-	Hash             common.Hash   `json:"hash"`
-	EthHash          common.Hash   `json:"ethHash"`
-	Input            hexutil.Bytes `json:"input"`
-	Nonce            uint64        `json:"nonce"`
-	To               string        `json:"to"`
-	TransactionIndex uint64        `json:"transactionIndex"`
-	Value            *big.Int      `json:"value"`
-	ShardID          uint32        `json:"shardID"`
-	ToShardID        uint32        `json:"toShardID"`
-	V                *hexutil.Big  `json:"v"`
-	R                *hexutil.Big  `json:"r"`
-	S                *hexutil.Big  `json:"s"`
+	BlockHash        common.Hash       `json:"blockHash"`
+	BlockNumber      *big.Int          `json:"blockNumber"`
+	From             string            `json:"from"`
+	Timestamp        uint64            `json:"timestamp"`
+	Gas              uint64            `json:"gas"`
+	GasPrice         *big.Int          `json:"gasPrice"`
+	Hash             common.Hash       `json:"hash"`
+	EthHash          common.Hash       `json:"ethHash"`
+	Input            hexutil.Bytes     `json:"input"`
+	Nonce            uint64            `json:"nonce"`
+	To               string            `json:"to"`
+	TransactionIndex uint64            `json:"transactionIndex"`
+	Value            *big.Int          `json:"value"`
+	ShardID          uint32            `json:"shardID"`
+	ToShardID        uint32            `json:"toShardID"`
+	Type             uint8             `json:"type"`
+	ChainID          *big.Int          `json:"chainId,omitempty"`
+	Accesses         *types.AccessList `json:"accessList,omitempty"`
+	V                *hexutil.Big      `json:"v"`
+	R                *hexutil.Big      `json:"r"`
+	S                *hexutil.Big      `json:"s"`
+}
+
+// InternalTransaction represents an internal transaction that will serialize to the RPC representation
+// of an internal transaction
+type InternalTransaction struct {
+	BlockNumber      uint64      `json:"blockNumber"`
+	TransactionHash  common.Hash `json:"transactionHash"`
+	TransactionIndex uint64      `json:"transactionIndex"`
+	TraceAddress     []uint64    `json:"traceAddress"`
+	Type             string      `json:"type"`
+	From             string      `json:"from"`
+	To               string      `json:"to"`
+	Value            *big.Int    `json:"value"`
 }
 
 // StakingTransaction represents a transaction that will serialize to the RPC representation of a staking transaction
@@ -218,6 +235,7 @@ type TxReceipt struct {
 	To                string         `json:"to"`
 	Root              hexutil.Bytes  `json:"root"`
 	Status            uint           `json:"status"`
+	Type              uint8          `json:"type"`
 }
 
 // StakingTxReceipt represents a staking transaction receipt that will serialize to the RPC representation.
@@ -303,10 +321,16 @@ func NewTransaction(
 		ShardID:   tx.ShardID(),
 		ToShardID: tx.ToShardID(),
 		Timestamp: timestamp,
+		Type:      tx.Type(),
 		V:         (*hexutil.Big)(v),
 		R:         (*hexutil.Big)(r),
 		S:         (*hexutil.Big)(s),
 	}
+	if tx.Type() != types.LegacyTxType {
+		al := tx.AccessList()
+		result.Accesses = &al
+		result.ChainID = tx.ChainID()
+	}
 	if blockHash != (common.Hash{}) {
 		result.BlockHash = blockHash
 		result.BlockNumber = new(big.Int).SetUint64(blockNumber)
@@ -388,6 +412,7 @@ func NewTxReceipt(
 		To:                receiver,
 		Root:              receipt.PostState,
 		Status:            uint(receipt.Status),
+		Type:              receipt.Type,
 	}
 
 	// Set optionals
@@ -779,6 +804,28 @@ func StakingTransactionsFromBlock(b *types.Block) ([]*StakingTransaction, error)
 	return rpcStakings, nil
 }
 
+// NewInternalTransaction returns an internal transaction that will serialize to the RPC representation
+func NewInternalTransaction(itx *tracers.InternalTransaction) (*InternalTransaction, error) {
+	from, err := internal_common.AddressToBech32(itx.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := internal_common.AddressToBech32(itx.To)
+	if err != nil {
+		return nil, err
+	}
+	return &InternalTransaction{
+		BlockNumber:      itx.BlockNumber,
+		TransactionHash:  itx.TxHash,
+		TransactionIndex: itx.TxIndex,
+		TraceAddress:     itx.TraceAddress,
+		Type:             itx.Type,
+		From:             from,
+		To:               to,
+		Value:            itx.Value,
+	}, nil
+}
+
 // NewStakingTransactionFromBlockHash returns a staking transaction that will serialize to the RPC representation.
 func NewStakingTransactionFromBlockHash(b *types.Block, hash common.Hash) (*StakingTransaction, error) {
 	for idx, tx := range b.StakingTransactions() {
@@ -157,6 +157,18 @@ func (tx *StakingTransaction) GasPrice() *big.Int {
 	return tx.data.Price
 }
 
+// GasFeeCap returns the max fee per gas of the staking transaction, which
+// always bids its gas price
+func (tx *StakingTransaction) GasFeeCap() *big.Int {
+	return tx.GasPrice()
+}
+
+// GasTipCap returns the max priority fee per gas of the staking transaction,
+// which always bids its gas price
+func (tx *StakingTransaction) GasTipCap() *big.Int {
+	return tx.GasPrice()
+}
+
 // Cost ..
 func (tx *StakingTransaction) Cost() (*big.Int, error) {
 	total := new(big.Int).Mul(tx.data.Price, new(big.Int).SetUint64(tx.data.GasLimit))
@@ -225,7 +225,7 @@ func makeHeader(chain consensus_engine.ChainReader, parent *block.Header, state
 		time = new(big.Int).Add(parent.Time(), big.NewInt(10)) // block time is fixed at 10 seconds
 	}
 
-	return factory.NewHeader(parent.Epoch()).With().
+	header := factory.NewHeader(parent.Epoch()).With().
 		Root(state.IntermediateRoot(chain.Config().IsS3(parent.Epoch()))).
 		ParentHash(parent.Hash()).
 		Coinbase(parent.Coinbase()).
@@ -233,6 +233,10 @@ func makeHeader(chain consensus_engine.ChainReader, parent *block.Header, state
 		Number(new(big.Int).Add(parent.Number(), common.Big1)).
 		Time(time).
 		Header()
+	if chain.Config().IsLondon(parent.Epoch()) {
+		header.SetBaseFee(core.CalcBaseFee(chain.Config(), parent))
+	}
+	return header
 }
 
 type fakeChainReader struct {
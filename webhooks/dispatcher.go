@@ -0,0 +1,420 @@
+package webhooks
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/harmony-one/harmony/internal/utils"
+)
+
+const (
+	// DefaultTimeout is the default timeout of a delivery request.
+	DefaultTimeout = 10 * time.Second
+	// DefaultMaxRetries is the default number of retries of a failed delivery.
+	DefaultMaxRetries = 8
+	// DefaultSyncStallTimeout is the default time without a new block after
+	// which the sync-stalled event is sent.
+	DefaultSyncStallTimeout = 5 * time.Minute
+
+	retryBaseDelay = time.Second
+	retryMaxDelay  = 10 * time.Minute
+	reloadInterval = 10 * time.Second
+	queueSize      = 1024
+	numWorkers     = 4
+)
+
+// Dispatcher delivers the events to the subscribed webhooks, retrying the
+// failed deliveries with an exponential backoff. The pending deliveries are
+// persisted in the outbox of the hooks, and the hooks are reloaded when
+// their YAML file changes.
+//
+// A nil Dispatcher is valid and drops all the events.
+type Dispatcher struct {
+	path   string
+	client *http.Client
+
+	mu      sync.RWMutex
+	hooks   *Hooks
+	subs    []*Subscription
+	modTime time.Time
+	outbox  *outbox
+	started bool
+
+	queue     chan *delivery
+	retryBase time.Duration
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stopC     chan struct{}
+	wg        sync.WaitGroup
+}
+
+// NewDispatcher returns a Dispatcher of the hooks of the YAML file at path.
+func NewDispatcher(path string) (*Dispatcher, error) {
+	d := &Dispatcher{
+		path:      path,
+		client:    &http.Client{},
+		queue:     make(chan *delivery, queueSize),
+		retryBase: retryBaseDelay,
+		stopC:     make(chan struct{}),
+	}
+	if err := d.reload(); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Start starts the delivery of the events and the pending deliveries of the
+// outbox, and the watch of the YAML file.
+func (d *Dispatcher) Start() {
+	if d == nil {
+		return
+	}
+	d.startOnce.Do(func() {
+		for i := 0; i < numWorkers; i++ {
+			d.wg.Add(1)
+			go d.deliverLoop()
+		}
+		d.wg.Add(1)
+		go d.watchLoop()
+
+		// the deliveries emitted from now on are queued by Emit, the
+		// previous ones are loaded from the outbox
+		d.mu.Lock()
+		d.started = true
+		pending, err := d.outbox.load()
+		d.mu.Unlock()
+		if err != nil {
+			utils.Logger().Warn().Err(err).Msg("[WebHooks] cannot load the outbox")
+		}
+		for _, dl := range pending {
+			d.enqueue(dl)
+		}
+	})
+}
+
+// Stop stops the delivery of the events. The pending deliveries are kept
+// in the outbox.
+func (d *Dispatcher) Stop() {
+	if d == nil {
+		return
+	}
+	d.stopOnce.Do(func() {
+		close(d.stopC)
+		d.wg.Wait()
+	})
+}
+
+// Hooks returns the current hooks.
+func (d *Dispatcher) Hooks() *Hooks {
+	if d == nil {
+		return nil
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return d.hooks
+}
+
+// Subscribed returns whether any webhook is subscribed to the event, to
+// skip computing the events nobody receives.
+func (d *Dispatcher) Subscribed(event EventType) bool {
+	return len(d.subscribers(event)) > 0
+}
+
+// LargeTransferThreshold returns the min value in Atto of the large-transfer
+// events.
+func (d *Dispatcher) LargeTransferThreshold() *big.Int {
+	hooks := d.Hooks()
+	if hooks == nil {
+		return new(big.Int)
+	}
+	threshold, _ := new(big.Float).Mul(
+		big.NewFloat(hooks.LargeTransferThreshold), big.NewFloat(1e18),
+	).Int(nil)
+	return threshold
+}
+
+// SyncStallTimeout returns the time without a new block after which the
+// sync-stalled event is sent.
+func (d *Dispatcher) SyncStallTimeout() time.Duration {
+	if hooks := d.Hooks(); hooks != nil && hooks.SyncStallTimeout > 0 {
+		return hooks.SyncStallTimeout
+	}
+	return DefaultSyncStallTimeout
+}
+
+// Emit sends the event with the given data to the subscribed webhooks. The
+// deliveries are persisted in the outbox before being queued, so that they
+// survive a full queue and a restart, and are sent by the delivery workers so
+// that a slow webhook never blocks the caller.
+func (d *Dispatcher) Emit(event EventType, data interface{}) {
+	subs := d.subscribers(event)
+	if len(subs) == 0 {
+		return
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		utils.Logger().Error().Err(err).Str("event", string(event)).Msg("[WebHooks] cannot encode the event")
+		return
+	}
+	id := newID()
+	payload, err := json.Marshal(&Event{
+		ID:        id,
+		Type:      event,
+		Timestamp: time.Now().Unix(),
+		Data:      raw,
+	})
+	if err != nil {
+		return
+	}
+
+	for i, sub := range subs {
+		dl := &delivery{
+			ID:      fmt.Sprintf("%s-%d", id, i),
+			URL:     sub.URL,
+			Event:   event,
+			Payload: payload,
+			Created: time.Now(),
+		}
+		if sub.legacy {
+			dl.Payload = raw
+		}
+		// the outbox is written under the lock, so that a delivery persisted
+		// before the start is loaded by Start rather than queued twice
+		d.mu.RLock()
+		ob, started := d.outbox, d.started
+		err := ob.put(dl)
+		d.mu.RUnlock()
+		if err != nil {
+			utils.Logger().Warn().Err(err).Str("url", dl.URL).Msg("[WebHooks] cannot persist the delivery")
+		}
+		if started || err != nil || ob.dir == "" {
+			d.enqueue(dl)
+		}
+	}
+}
+
+func (d *Dispatcher) subscribers(event EventType) []*Subscription {
+	if d == nil {
+		return nil
+	}
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	var subs []*Subscription
+	for _, sub := range d.subs {
+		if sub.subscribed(event) {
+			subs = append(subs, sub)
+		}
+	}
+	return subs
+}
+
+// subscription returns the current subscription of the url to the event,
+// nil if it was removed by a reload.
+func (d *Dispatcher) subscription(url string, event EventType) *Subscription {
+	for _, sub := range d.subscribers(event) {
+		if sub.URL == url {
+			return sub
+		}
+	}
+	return nil
+}
+
+func (s *Subscription) subscribed(event EventType) bool {
+	if len(s.Events) == 0 {
+		return true
+	}
+	for _, e := range s.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Subscription) timeout() time.Duration {
+	if s.Timeout > 0 {
+		return s.Timeout
+	}
+	return DefaultTimeout
+}
+
+func (s *Subscription) maxRetries() int {
+	if s.MaxRetries != nil {
+		return *s.MaxRetries
+	}
+	return DefaultMaxRetries
+}
+
+func (d *Dispatcher) enqueue(dl *delivery) {
+	select {
+	case d.queue <- dl:
+	default:
+		// the delivery was persisted by Emit, it stays in the outbox and is
+		// sent on the next start
+		utils.Logger().Warn().Str("url", dl.URL).Str("event", string(dl.Event)).
+			Msg("[WebHooks] delivery queue is full, dropping the delivery")
+	}
+}
+
+func (d *Dispatcher) deliverLoop() {
+	defer d.wg.Done()
+	for {
+		select {
+		case <-d.stopC:
+			return
+		case dl := <-d.queue:
+			d.deliver(dl)
+		}
+	}
+}
+
+func (d *Dispatcher) deliver(dl *delivery) {
+	d.mu.RLock()
+	ob := d.outbox
+	d.mu.RUnlock()
+
+	sub := d.subscription(dl.URL, dl.Event)
+	if sub == nil {
+		ob.remove(dl.ID)
+		return
+	}
+	err := d.post(sub, dl)
+	if err == nil {
+		ob.remove(dl.ID)
+		return
+	}
+	dl.Attempts++
+	if dl.Attempts > sub.maxRetries() {
+		utils.Logger().Warn().Err(err).Str("url", dl.URL).Str("event", string(dl.Event)).
+			Int("attempts", dl.Attempts).Msg("[WebHooks] giving up the delivery")
+		ob.remove(dl.ID)
+		return
+	}
+	if err := ob.put(dl); err != nil {
+		utils.Logger().Warn().Err(err).Str("url", dl.URL).Msg("[WebHooks] cannot persist the delivery")
+	}
+	delay := d.retryDelay(dl.Attempts)
+	utils.Logger().Debug().Err(err).Str("url", dl.URL).Str("event", string(dl.Event)).
+		Dur("retryIn", delay).Msg("[WebHooks] delivery failed")
+	time.AfterFunc(delay, func() {
+		select {
+		case <-d.stopC:
+		default:
+			d.enqueue(dl)
+		}
+	})
+}
+
+// retryDelay returns the delay before the given attempt, doubling from
+// retryBase up to retryMaxDelay.
+func (d *Dispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.retryBase
+	for i := 1; i < attempts && delay < retryMaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > retryMaxDelay {
+		delay = retryMaxDelay
+	}
+	return delay
+}
+
+func (d *Dispatcher) post(sub *Subscription, dl *delivery) error {
+	req, err := http.NewRequest(http.MethodPost, sub.URL, bytes.NewReader(dl.Payload))
+	if err != nil {
+		return err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(dl.Event))
+	req.Header.Set(HeaderDelivery, dl.ID)
+	req.Header.Set(HeaderTimestamp, timestamp)
+	if sub.Secret != "" {
+		req.Header.Set(HeaderSignature, Sign(sub.Secret, timestamp, dl.Payload))
+	}
+	for k, v := range sub.Headers {
+		req.Header.Set(k, v)
+	}
+
+	client := *d.client
+	client.Timeout = sub.timeout()
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+func (d *Dispatcher) watchLoop() {
+	defer d.wg.Done()
+	ticker := time.NewTicker(reloadInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.stopC:
+			return
+		case <-ticker.C:
+			if err := d.reload(); err != nil {
+				utils.Logger().Warn().Err(err).Str("path", d.path).
+					Msg("[WebHooks] cannot reload the hooks, keeping the previous ones")
+			}
+		}
+	}
+}
+
+// reload loads the hooks of the YAML file if it changed since the last load.
+func (d *Dispatcher) reload() error {
+	info, err := os.Stat(d.path)
+	if err != nil {
+		return err
+	}
+	d.mu.RLock()
+	unchanged := d.hooks != nil && info.ModTime().Equal(d.modTime)
+	d.mu.RUnlock()
+	if unchanged {
+		return nil
+	}
+
+	hooks, err := NewWebHooksFromPath(d.path)
+	if err != nil {
+		return err
+	}
+	d.mu.RLock()
+	ob := d.outbox
+	d.mu.RUnlock()
+	if ob == nil || ob.dir != hooks.Outbox {
+		if ob, err = newOutbox(hooks.Outbox); err != nil {
+			return err
+		}
+	}
+
+	d.mu.Lock()
+	reloaded := d.hooks != nil
+	d.hooks, d.subs, d.modTime, d.outbox = hooks, hooks.subscriptions(), info.ModTime(), ob
+	d.mu.Unlock()
+	if reloaded {
+		utils.Logger().Info().Str("path", d.path).Int("subscriptions", len(d.subs)).
+			Msg("[WebHooks] reloaded the hooks")
+	}
+	return nil
+}
+
+func newID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
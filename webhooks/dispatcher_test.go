@@ -0,0 +1,316 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testReceiver struct {
+	mu       sync.Mutex
+	failures int // number of requests to fail before accepting
+	requests []*http.Request
+	bodies   [][]byte
+	received chan struct{}
+}
+
+func newTestReceiver(failures int) (*testReceiver, *httptest.Server) {
+	r := &testReceiver{failures: failures, received: make(chan struct{}, 16)}
+	return r, httptest.NewServer(r)
+}
+
+func (r *testReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	fail := r.failures > 0
+	if fail {
+		r.failures--
+	} else {
+		r.requests = append(r.requests, req)
+		r.bodies = append(r.bodies, body)
+	}
+	r.mu.Unlock()
+	if fail {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	r.received <- struct{}{}
+}
+
+func (r *testReceiver) wait(t *testing.T) (*http.Request, []byte) {
+	t.Helper()
+	select {
+	case <-r.received:
+	case <-time.After(5 * time.Second):
+		t.Fatal("delivery not received")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := len(r.requests) - 1
+	return r.requests[n], r.bodies[n]
+}
+
+func writeHooks(t *testing.T, path, yaml string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(yaml), 0600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func newTestDispatcher(t *testing.T, yaml string) *Dispatcher {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "hooks.yaml")
+	writeHooks(t, path, yaml)
+	d, err := NewDispatcher(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.retryBase = 10 * time.Millisecond
+	return d
+}
+
+func TestDispatcherSignedDelivery(t *testing.T) {
+	r, srv := newTestReceiver(2)
+	defer srv.Close()
+	d := newTestDispatcher(t, fmt.Sprintf(`
+subscriptions:
+  - url: %s
+    events: [new-epoch]
+    secret: s3cret
+    headers:
+      Authorization: Bearer token
+`, srv.URL))
+	d.Start()
+	defer d.Stop()
+
+	if d.Subscribed(EventViewChange) || !d.Subscribed(EventNewEpoch) {
+		t.Fatal("unexpected subscriptions")
+	}
+	d.Emit(EventViewChange, map[string]uint64{"viewID": 1})
+	d.Emit(EventNewEpoch, map[string]uint64{"epoch": 7})
+
+	req, body := r.wait(t)
+	var event Event
+	if err := json.Unmarshal(body, &event); err != nil {
+		t.Fatal(err)
+	}
+	if event.Type != EventNewEpoch || string(event.Data) != `{"epoch":7}` {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if req.Header.Get(HeaderEvent) != string(EventNewEpoch) || req.Header.Get("Authorization") != "Bearer token" {
+		t.Errorf("unexpected headers %v", req.Header)
+	}
+	if sig := Sign("s3cret", req.Header.Get(HeaderTimestamp), body); req.Header.Get(HeaderSignature) != sig {
+		t.Errorf("signature %v, want %v", req.Header.Get(HeaderSignature), sig)
+	}
+}
+
+func TestDispatcherLegacyHooks(t *testing.T) {
+	r, srv := newTestReceiver(0)
+	defer srv.Close()
+	d := newTestDispatcher(t, fmt.Sprintf(`
+protocol-hooks:
+  on-cannot-commit-block: %s
+`, srv.URL))
+	d.Start()
+	defer d.Stop()
+
+	d.Emit(EventBadBlock, map[string]string{"reason": "bad"})
+	if _, body := r.wait(t); string(body) != `{"reason":"bad"}` {
+		t.Errorf("unexpected legacy payload %s", body)
+	}
+}
+
+func TestDispatcherGiveUp(t *testing.T) {
+	r, srv := newTestReceiver(100)
+	defer srv.Close()
+	outbox := t.TempDir()
+	d := newTestDispatcher(t, fmt.Sprintf(`
+outbox: %s
+subscriptions:
+  - url: %s
+    max-retries: 1
+`, outbox, srv.URL))
+	d.Start()
+	defer d.Stop()
+
+	d.Emit(EventSyncStalled, nil)
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		r.mu.Lock()
+		failures := r.failures
+		r.mu.Unlock()
+		entries, _ := os.ReadDir(outbox)
+		if failures == 98 && len(entries) == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("delivery not given up: %d failures left, %d pending", failures, len(entries))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDispatcherOutbox(t *testing.T) {
+	r, srv := newTestReceiver(1)
+	defer srv.Close()
+	outbox := t.TempDir()
+	yaml := fmt.Sprintf(`
+outbox: %s
+subscriptions:
+  - url: %s
+`, outbox, srv.URL)
+	pending := func() int {
+		entries, _ := os.ReadDir(outbox)
+		return len(entries)
+	}
+
+	// the delivery emitted before the start is persisted
+	d := newTestDispatcher(t, yaml)
+	d.Emit(EventLargeTransfer, map[string]string{"value": "1"})
+	if n := pending(); n != 1 {
+		t.Fatalf("have %d pending deliveries before start, want 1", n)
+	}
+
+	// and stays in the outbox after a failure
+	d.retryBase = time.Hour
+	d.Start()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		r.mu.Lock()
+		failures := r.failures
+		r.mu.Unlock()
+		if failures == 0 && pending() == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("delivery not persisted: %d failures left, %d pending", failures, pending())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	d.Stop()
+	if n := pending(); n != 1 {
+		t.Fatalf("have %d pending deliveries after stop, want 1", n)
+	}
+
+	// and is sent on the next start
+	d = newTestDispatcher(t, yaml)
+	d.Start()
+	defer d.Stop()
+	if _, body := r.wait(t); len(body) == 0 {
+		t.Fatal("empty payload")
+	}
+	deadline = time.Now().Add(5 * time.Second)
+	for pending() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("delivery not removed from the outbox")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestDispatcherQueueFull(t *testing.T) {
+	r, srv := newTestReceiver(0)
+	defer srv.Close()
+	outbox := t.TempDir()
+	yaml := fmt.Sprintf(`
+outbox: %s
+subscriptions:
+  - url: %s
+`, outbox, srv.URL)
+
+	// the delivery dropped by the full queue is kept in the outbox
+	d := newTestDispatcher(t, yaml)
+	d.started = true
+	d.queue = make(chan *delivery)
+	d.Emit(EventNewEpoch, map[string]uint64{"epoch": 7})
+	if pending, err := d.outbox.load(); err != nil || len(pending) != 1 {
+		t.Fatalf("have %d pending deliveries, want 1: %v", len(pending), err)
+	}
+
+	// and sent once on the next start
+	d = newTestDispatcher(t, yaml)
+	d.Start()
+	defer d.Stop()
+	if _, body := r.wait(t); len(body) == 0 {
+		t.Fatal("empty payload")
+	}
+	select {
+	case <-r.received:
+		t.Fatal("delivery sent twice")
+	case <-time.After(100 * time.Millisecond):
+	}
+}
+
+func TestDispatcherReload(t *testing.T) {
+	d := newTestDispatcher(t, `
+subscriptions:
+  - url: http://localhost:1/a
+    events: [view-change]
+large-transfer-threshold: 1.5
+`)
+	if !d.Subscribed(EventViewChange) || d.LargeTransferThreshold().String() != "1500000000000000000" {
+		t.Fatal("unexpected hooks")
+	}
+
+	writeHooks(t, d.path, `
+subscriptions:
+  - url: http://localhost:1/a
+    events: [unknown-event]
+`)
+	os.Chtimes(d.path, time.Now(), time.Now().Add(time.Minute))
+	if err := d.reload(); err == nil {
+		t.Fatal("expected error for unknown event")
+	}
+	if !d.Subscribed(EventViewChange) {
+		t.Fatal("previous hooks not kept")
+	}
+
+	writeHooks(t, d.path, `
+subscriptions:
+  - url: http://localhost:1/a
+    events: [bad-block]
+`)
+	os.Chtimes(d.path, time.Now(), time.Now().Add(2*time.Minute))
+	if err := d.reload(); err != nil {
+		t.Fatal(err)
+	}
+	if d.Subscribed(EventViewChange) || !d.Subscribed(EventBadBlock) {
+		t.Fatal("hooks not reloaded")
+	}
+}
+
+func TestNilDispatcher(t *testing.T) {
+	var d *Dispatcher
+	d.Start()
+	d.Emit(EventNewEpoch, nil)
+	if d.Subscribed(EventNewEpoch) || d.Hooks() != nil || d.SyncStallTimeout() != DefaultSyncStallTimeout {
+		t.Fatal("unexpected nil dispatcher state")
+	}
+	d.Stop()
+}
+
+func TestRetryDelay(t *testing.T) {
+	d := &Dispatcher{retryBase: time.Second}
+	tests := []struct {
+		attempts int
+		delay    time.Duration
+	}{
+		{1, time.Second},
+		{2, 2 * time.Second},
+		{4, 8 * time.Second},
+		{100, retryMaxDelay},
+	}
+	for i, test := range tests {
+		if delay := d.retryDelay(test.attempts); delay != test.delay {
+			t.Errorf("Test %v: delay %v, want %v", i, delay, test.delay)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// EventType is the type of an event sent to the webhooks.
+type EventType string
+
+// The event types the webhooks can subscribe to.
+const (
+	EventNewEpoch                   EventType = "new-epoch"
+	EventCommitteeChange            EventType = "committee-change"
+	EventValidatorElected           EventType = "validator-elected"
+	EventValidatorBooted            EventType = "validator-booted"
+	EventViewChange                 EventType = "view-change"
+	EventBadBlock                   EventType = "bad-block"
+	EventSyncStalled                EventType = "sync-stalled"
+	EventLargeTransfer              EventType = "large-transfer"
+	EventDoubleSign                 EventType = "double-sign"
+	EventAvailabilityBelowThreshold EventType = "availability-below-threshold"
+)
+
+var eventTypes = map[EventType]struct{}{
+	EventNewEpoch:                   {},
+	EventCommitteeChange:            {},
+	EventValidatorElected:           {},
+	EventValidatorBooted:            {},
+	EventViewChange:                 {},
+	EventBadBlock:                   {},
+	EventSyncStalled:                {},
+	EventLargeTransfer:              {},
+	EventDoubleSign:                 {},
+	EventAvailabilityBelowThreshold: {},
+}
+
+func (e EventType) valid() bool {
+	_, ok := eventTypes[e]
+	return ok
+}
+
+// Event is the payload of a delivery to a webhook.
+type Event struct {
+	ID        string          `json:"id"`
+	Type      EventType       `json:"type"`
+	Timestamp int64           `json:"timestamp"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Headers of the deliveries.
+const (
+	HeaderEvent     = "X-Harmony-Event"
+	HeaderDelivery  = "X-Harmony-Delivery"
+	HeaderTimestamp = "X-Harmony-Timestamp"
+	HeaderSignature = "X-Harmony-Signature"
+)
+
+// Sign returns the signature of a payload sent at timestamp, as set in the
+// HeaderSignature header: the hex encoded HMAC-SHA256 of the timestamp, a
+// dot and the payload, keyed by the secret of the subscription.
+func Sign(secret, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
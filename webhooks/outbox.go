@@ -0,0 +1,98 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+const outboxExt = ".json"
+
+// delivery is a pending delivery of an event to a webhook.
+type delivery struct {
+	ID       string    `json:"id"`
+	URL      string    `json:"url"`
+	Event    EventType `json:"event"`
+	Payload  []byte    `json:"payload"`
+	Attempts int       `json:"attempts"`
+	Created  time.Time `json:"created"`
+}
+
+// outbox persists the pending deliveries, one file each, so that they are
+// retried after a restart. The zero dir keeps them in memory only.
+type outbox struct {
+	dir string
+}
+
+func newOutbox(dir string) (*outbox, error) {
+	if dir != "" {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return nil, err
+		}
+	}
+	return &outbox{dir: dir}, nil
+}
+
+func (o *outbox) path(id string) string {
+	return filepath.Join(o.dir, id+outboxExt)
+}
+
+// put writes the delivery, replacing the previous version if any.
+func (o *outbox) put(d *delivery) error {
+	if o.dir == "" {
+		return nil
+	}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	tmp := o.path(d.ID) + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, o.path(d.ID))
+}
+
+func (o *outbox) remove(id string) error {
+	if o.dir == "" {
+		return nil
+	}
+	if err := os.Remove(o.path(id)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// load returns the persisted deliveries, oldest first. The unreadable files
+// are skipped.
+func (o *outbox) load() ([]*delivery, error) {
+	if o.dir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(o.dir)
+	if err != nil {
+		return nil, err
+	}
+	var deliveries []*delivery
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), outboxExt) {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(o.dir, entry.Name()))
+		if err != nil {
+			continue
+		}
+		d := new(delivery)
+		if err := json.Unmarshal(data, d); err != nil || d.ID == "" {
+			continue
+		}
+		deliveries = append(deliveries, d)
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].Created.Before(deliveries[j].Created)
+	})
+	return deliveries, nil
+}
@@ -3,9 +3,12 @@ package webhooks
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
@@ -30,11 +33,76 @@ type BadBlockHooks struct {
 	OnCannotCommit string `yaml:"on-cannot-commit-block"`
 }
 
+// Subscription is a webhook receiving the events of the given types.
+type Subscription struct {
+	URL        string            `yaml:"url"`
+	Events     []EventType       `yaml:"events"`      // all the events if empty
+	Secret     string            `yaml:"secret"`      // HMAC-SHA256 key of the payloads, unsigned if empty
+	Headers    map[string]string `yaml:"headers"`     // extra request headers, such as Authorization
+	Timeout    time.Duration     `yaml:"timeout"`     // request timeout, DefaultTimeout if zero
+	MaxRetries *int              `yaml:"max-retries"` // retries of a failed delivery, DefaultMaxRetries if nil
+
+	// legacy subscriptions receive the bare event data, as DoPost did
+	legacy bool
+}
+
 // Hooks ..
 type Hooks struct {
 	Slashing       *DoubleSignWebHooks `yaml:"slashing-hooks"`
 	Availability   *AvailabilityHooks  `yaml:"availability-hooks"`
 	ProtocolIssues *BadBlockHooks      `yaml:"protocol-hooks"`
+
+	Subscriptions []*Subscription `yaml:"subscriptions"`
+	// Outbox is the directory persisting the pending deliveries across
+	// restarts, in memory only if empty.
+	Outbox string `yaml:"outbox"`
+	// LargeTransferThreshold is the min value in ONE of the large-transfer events.
+	LargeTransferThreshold float64 `yaml:"large-transfer-threshold"`
+	// SyncStallTimeout is the time without a new block after which the
+	// sync-stalled event is sent, DefaultSyncStallTimeout if zero.
+	SyncStallTimeout time.Duration `yaml:"sync-stall-timeout"`
+}
+
+// subscriptions returns the subscriptions of the hooks, including the ones
+// of the legacy sections.
+func (h *Hooks) subscriptions() []*Subscription {
+	subs := make([]*Subscription, 0, len(h.Subscriptions)+3)
+	if h.Slashing != nil && h.Slashing.OnNoticeDoubleSign != "" {
+		subs = append(subs, &Subscription{
+			URL: h.Slashing.OnNoticeDoubleSign, Events: []EventType{EventDoubleSign}, legacy: true,
+		})
+	}
+	if h.Availability != nil && h.Availability.OnDroppedBelowThreshold != "" {
+		subs = append(subs, &Subscription{
+			URL: h.Availability.OnDroppedBelowThreshold, Events: []EventType{EventAvailabilityBelowThreshold}, legacy: true,
+		})
+	}
+	if h.ProtocolIssues != nil && h.ProtocolIssues.OnCannotCommit != "" {
+		subs = append(subs, &Subscription{
+			URL: h.ProtocolIssues.OnCannotCommit, Events: []EventType{EventBadBlock}, legacy: true,
+		})
+	}
+	return append(subs, h.Subscriptions...)
+}
+
+func (h *Hooks) validate() error {
+	for i, sub := range h.Subscriptions {
+		if sub == nil || sub.URL == "" {
+			return fmt.Errorf("subscription %d: missing url", i)
+		}
+		for _, event := range sub.Events {
+			if !event.valid() {
+				return fmt.Errorf("subscription %d: unknown event %q", i, event)
+			}
+		}
+		if sub.MaxRetries != nil && *sub.MaxRetries < 0 {
+			return fmt.Errorf("subscription %d: negative max-retries", i)
+		}
+	}
+	if h.LargeTransferThreshold < 0 {
+		return errors.New("negative large-transfer-threshold")
+	}
+	return nil
 }
 
 // ReportResult ..
@@ -53,7 +121,7 @@ func NewFailure(payload string) *ReportResult {
 	return &ReportResult{"failure", payload}
 }
 
-// DoPost is a fire and forget helper
+// DoPost is a fire and forget helper, superseded by the Dispatcher
 func DoPost(url string, record interface{}) (*ReportResult, error) {
 	payload, err := json.Marshal(record)
 	if err != nil {
@@ -85,5 +153,8 @@ func NewWebHooksFromPath(yamlPath string) (*Hooks, error) {
 	if err := yaml.UnmarshalStrict(rawYAML, &t); err != nil {
 		return nil, err
 	}
+	if err := t.validate(); err != nil {
+		return nil, err
+	}
 	return &t, nil
 }